@@ -0,0 +1,169 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"xiam.li/protonats/go/plugin"
+)
+
+var (
+	flagPkg = protogen.GoImportPath("flag")
+	fmtPkg  = protogen.GoImportPath("fmt")
+	ioPkg   = protogen.GoImportPath("io")
+)
+
+func generateCLIFile(gen *protogen.Plugin, file *protogen.File) error {
+	if len(file.Services) == 0 {
+		return nil
+	}
+	filename := file.GeneratedFilenamePrefix + "_nats_cli.pb.go"
+	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-nats. DO NOT EDIT.")
+	g.P("// Versions:")
+	g.P("// - protoc-gen-go-nats ", version)
+	g.P("// - protoc        v", plugin.ProtocVersion(gen))
+	if file.Proto.GetOptions().GetDeprecated() {
+		g.P("// ", file.Desc.Path(), " is a deprecated file.")
+	} else {
+		g.P("// source: ", file.Desc.Path())
+	}
+	g.P()
+	g.P("package ", file.GoPackageName)
+	g.P()
+	for _, service := range file.Services {
+		generateCLI(g, service)
+	}
+	return nil
+}
+
+func generateCLI(g *protogen.GeneratedFile, service *protogen.Service) {
+	cliName := service.GoName + "NATSCLI"
+	var methods []*protogen.Method
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			// TODO: Skipping currently unsupported streaming methods for now
+			continue
+		}
+		methods = append(methods, method)
+	}
+
+	g.P("//region CLI")
+	g.P("// ", cliName, " is a command line interface calling the methods of ", service.GoName, " through a ", service.GoName, "NATSClient")
+	g.P("type ", cliName, " struct {")
+	g.P("client ", service.GoName, "NATSClient")
+	g.P("out ", ioPkg.Ident("Writer"))
+	g.P("}")
+	g.P()
+
+	g.P("// New", cliName, " creates a command line interface for client, writing all responses to out")
+	g.P("func New", cliName, "(client ", service.GoName, "NATSClient, out ", ioPkg.Ident("Writer"), ") *", cliName, " {")
+	g.P("return &", cliName, "{client: client, out: out}")
+	g.P("}")
+	g.P()
+
+	g.P("// Run calls the method named by the first argument, with the request built from the remaining arguments")
+	g.P("func (c *", cliName, ") Run(args []string) error {")
+	g.P("if len(args) == 0 {")
+	g.P("c.Usage()")
+	g.P("return ", errorsPkg.Ident("New"), "(", strconv.Quote("no method given"), ")")
+	g.P("}")
+	g.P("switch args[0] {")
+	for _, method := range methods {
+		g.P("case ", strconv.Quote(method.GoName), ":")
+		g.P("return c.call", method.GoName, "(args[1:])")
+	}
+	g.P("default:")
+	g.P("c.Usage()")
+	g.P("return ", fmtPkg.Ident("Errorf"), "(", strconv.Quote("unknown method %s"), ", args[0])")
+	g.P("}")
+	g.P("}")
+	g.P()
+
+	g.P("// Usage writes the available methods to the output")
+	g.P("func (c *", cliName, ") Usage() {")
+	g.P(fmtPkg.Ident("Fprintln"), "(c.out, ", strconv.Quote("Usage: <method> [flags]"), ")")
+	g.P(fmtPkg.Ident("Fprintln"), "(c.out, ", strconv.Quote("Methods:"), ")")
+	for _, method := range methods {
+		usage := "  " + method.GoName
+		if comment := strings.TrimSpace(string(method.Comments.Leading)); comment != "" {
+			usage += "  " + strings.SplitN(comment, "\n", 2)[0]
+		}
+		g.P(fmtPkg.Ident("Fprintln"), "(c.out, ", strconv.Quote(usage), ")")
+	}
+	g.P("}")
+	g.P()
+
+	for _, method := range methods {
+		generateCLIMethod(g, cliName, method)
+	}
+	g.P("//endregion")
+	g.P()
+}
+
+func generateCLIMethod(g *protogen.GeneratedFile, cliName string, method *protogen.Method) {
+	broadcasting := plugin.IsUsingBroadcasting(method)
+
+	g.P("func (c *", cliName, ") call", method.GoName, "(args []string) error {")
+	g.P("fs := ", flagPkg.Ident("NewFlagSet"), "(", strconv.Quote(method.GoName), ", ", flagPkg.Ident("ContinueOnError"), ")")
+	g.P("fs.SetOutput(c.out)")
+	g.P("flags := ", goNatsExtPkg.Ident("NewCallFlags"), "(fs)")
+	var req string
+	if method.Input.Location.SourceFile != emptyPb {
+		g.P("req := new(", method.Input.GoIdent, ")")
+		g.P("apply := ", goNatsExtPkg.Ident("RequestFlags"), "(fs, req)")
+		req = "req, "
+	}
+	g.P("if err := fs.Parse(args); err != nil {")
+	g.P("return err")
+	g.P("}")
+	if req != "" {
+		g.P("if err := apply(); err != nil {")
+		g.P("return err")
+		g.P("}")
+	}
+	g.P()
+
+	if isDurable(method) {
+		g.P("if len(flags.Instances) > 0 {")
+		g.P("return ", errorsPkg.Ident("New"), "(", strconv.Quote("durable methods can't be sent to specific instances"), ")")
+		g.P("}")
+		g.P("call, err := c.client.", method.GoName, "(", req, "flags.Options()...)")
+		g.P("if err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("return ", goNatsExtPkg.Ident("PrintDurableReceipt"), "(c.out, call.DurableReceipt)")
+	} else if broadcasting {
+		g.P("var results ", broadcastResultType(g, method))
+		g.P("var err error")
+		g.P("if target, ok := flags.Target(); ok {")
+		g.P("results, err = c.client.", method.GoName, "To(target, ", req, "flags.Options()...)")
+		g.P("} else {")
+		g.P("results, err = c.client.", method.GoName, "(", req, "flags.Options()...)")
+		g.P("}")
+		g.P("if err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("return ", goNatsExtPkg.Ident("PrintResults"), "(c.out, results)")
+	} else {
+		g.P("if len(flags.Instances) > 1 {")
+		g.P("return ", errorsPkg.Ident("New"), "(", strconv.Quote("only broadcasting methods can be sent to multiple instances"), ")")
+		g.P("}")
+		if method.Output.Location.SourceFile != emptyPb {
+			g.P("resp, err := c.client.", method.GoName, "(", req, "flags.Options()...)")
+			g.P("if err != nil {")
+			g.P("return err")
+			g.P("}")
+			g.P("return ", goNatsExtPkg.Ident("PrintMessage"), "(c.out, resp)")
+		} else {
+			g.P("if err := c.client.", method.GoName, "(", req, "flags.Options()...); err != nil {")
+			g.P("return err")
+			g.P("}")
+			g.P("_, err := ", fmtPkg.Ident("Fprintln"), "(c.out, ", strconv.Quote("{}"), ")")
+			g.P("return err")
+		}
+	}
+	g.P("}")
+	g.P()
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"xiam.li/go-protonats/gonats"
+)
+
+// eventMessages returns the messages, including nested ones, that use the event option.
+func eventMessages(messages []*protogen.Message) []*protogen.Message {
+	var events []*protogen.Message
+	for _, message := range messages {
+		if proto.HasExtension(message.Desc.Options(), gonats.E_Event) {
+			events = append(events, message)
+		}
+		events = append(events, eventMessages(message.Messages)...)
+	}
+	return events
+}
+
+// generateEvents generates a typed publisher and subscriber for every message using the event option.
+func generateEvents(g *protogen.GeneratedFile, events []*protogen.Message) error {
+	if len(events) == 0 {
+		return nil
+	}
+	g.P("//region Events")
+	g.P()
+	for _, message := range events {
+		if err := generateEvent(g, message); err != nil {
+			return err
+		}
+	}
+	g.P("//endregion")
+	g.P()
+	return nil
+}
+
+func generateEvent(g *protogen.GeneratedFile, message *protogen.Message) error {
+	event := proto.GetExtension(message.Desc.Options(), gonats.E_Event).(*gonats.Event)
+	pattern := event.GetSubject()
+	if pattern == "" {
+		return fmt.Errorf("event %s must declare a subject", message.Desc.FullName())
+	}
+	var fields []*protogen.Field
+	for _, name := range gonats.EventTokens(pattern) {
+		field, err := eventTokenField(message, name)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, field)
+	}
+
+	name := message.GoIdent.GoName
+	g.P("// ", name, "SubjectPattern is the subject pattern ", name, " events are published to.")
+	g.P("const ", name, "SubjectPattern = ", strconv.Quote(pattern))
+	g.P()
+	g.P("// ", name, "Subject returns the subject event is published to, filling the tokens of ", name, "SubjectPattern from its fields.")
+	g.P("func ", name, "Subject(event *", message.GoIdent, ") (string, error) {")
+	if len(fields) == 0 {
+		g.P("return ", goNatsExtPkg.Ident("EventSubject"), "(", name, "SubjectPattern, nil)")
+	} else {
+		g.P("return ", goNatsExtPkg.Ident("EventSubject"), "(", name, "SubjectPattern, map[string]string{")
+		for _, field := range fields {
+			g.P(strconv.Quote(string(field.Desc.Name())), ": ", fmtPkg.Ident("Sprint"), "(event.Get", field.GoName, "()),")
+		}
+		g.P("})")
+	}
+	g.P("}")
+	g.P()
+	g.P("// Publish", name, " publishes event to the subject returned by ", name, "Subject.")
+	g.P("func Publish", name, "(nc *", natsConn, ", event *", message.GoIdent, ") error {")
+	g.P("subject, err := ", name, "Subject(event)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("return ", goNatsExtPkg.Ident("PublishEvent"), "(nc, subject, event)")
+	g.P("}")
+	g.P()
+	g.P("// Subscribe", name, " calls handler for every ", name, " event.")
+	g.P("// Use gonats.WithToken to only receive events with specific values of the tokens of ", name, "SubjectPattern.")
+	g.P("func Subscribe", name, "(nc *", natsConn, ", handler func(event *", message.GoIdent, ", msg *", natsPkg.Ident("Msg"), "), opts ...", goNatsExtPkg.Ident("SubscribeOption"), ") (*", natsPkg.Ident("Subscription"), ", error) {")
+	g.P("return ", goNatsExtPkg.Ident("SubscribeEvents"), "(nc, ", name, "SubjectPattern, func() *", message.GoIdent, " { return new(", message.GoIdent, ") }, handler, opts...)")
+	g.P("}")
+	g.P()
+	return nil
+}
+
+// eventTokenField returns the field of message a token of its subject is filled from.
+// Only singular scalar and enum fields can be used, as other values can't be formatted as a single token.
+func eventTokenField(message *protogen.Message, name string) (*protogen.Field, error) {
+	for _, field := range message.Fields {
+		if string(field.Desc.Name()) != name {
+			continue
+		}
+		if field.Desc.IsList() || field.Desc.IsMap() {
+			return nil, fmt.Errorf("token {%s} of event %s must not refer to a repeated field", name, message.Desc.FullName())
+		}
+		switch field.Desc.Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind, protoreflect.BytesKind, protoreflect.FloatKind, protoreflect.DoubleKind:
+			return nil, fmt.Errorf("token {%s} of event %s must refer to a string, bool, integer or enum field", name, message.Desc.FullName())
+		}
+		return field, nil
+	}
+	return nil, fmt.Errorf("token {%s} of event %s does not refer to a field", name, message.Desc.FullName())
+}
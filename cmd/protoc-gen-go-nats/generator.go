@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/nats-io/nats.go/micro"
 	"google.golang.org/protobuf/compiler/protogen"
+	"slices"
 	"strconv"
 	"strings"
 	"xiam.li/protonats/go/plugin"
@@ -13,15 +14,19 @@ import (
 
 const (
 	natsPkg       = protogen.GoImportPath("github.com/nats-io/nats.go")
+	nkeysPkg      = protogen.GoImportPath("github.com/nats-io/nkeys")
 	microPkg      = protogen.GoImportPath("github.com/nats-io/nats.go/micro")
 	nuidPkg       = protogen.GoImportPath("github.com/nats-io/nuid")
 	timePkg       = protogen.GoImportPath("time")
 	protoPkg      = protogen.GoImportPath("google.golang.org/protobuf/proto")
 	goNatsPkg     = protogen.GoImportPath("xiam.li/protonats/go/protonats")
 	goNatsImplPkg = protogen.GoImportPath("xiam.li/protonats/go/impl")
+	goNatsExtPkg  = protogen.GoImportPath("xiam.li/go-protonats/gonats")
+	grpcNatsPkg   = protogen.GoImportPath("xiam.li/go-protonats/gonats/grpcnats")
 	errorsPkg     = protogen.GoImportPath("errors")
 	slogPkg       = protogen.GoImportPath("log/slog")
 	contextPkg    = protogen.GoImportPath("context")
+	descriptorPkg = protogen.GoImportPath("google.golang.org/protobuf/types/descriptorpb")
 )
 
 var (
@@ -44,7 +49,8 @@ var (
 )
 
 func generateFile(gen *protogen.Plugin, file *protogen.File) error {
-	if len(file.Services) == 0 {
+	events := eventMessages(file.Messages)
+	if len(file.Services) == 0 && len(events) == 0 {
 		return nil
 	}
 	filename := file.GeneratedFilenamePrefix + "_nats.pb.go"
@@ -66,7 +72,7 @@ func generateFile(gen *protogen.Plugin, file *protogen.File) error {
 			return err
 		}
 	}
-	return nil
+	return generateEvents(g, events)
 }
 
 func generateServer(g *protogen.GeneratedFile, service *protogen.Service) error {
@@ -135,41 +141,117 @@ func generateServer(g *protogen.GeneratedFile, service *protogen.Service) error
 		g.P()
 	}
 
+	generateUnimplementedServer(g, service)
+
+	if hasServiceConfig(service) {
+		generateServiceConfig(g, service)
+	}
+
 	// Generate SetId interface
 	g.P("type ", service.GoName, "Id interface {")
 	g.P("Set", service.GoName, "Id(string)")
 	g.P("}")
 	g.P()
-
-	// Generate NewServer function
-	g.P("func New", srvName, "(nc *", natsConn, ", server ", srvName, ", opts ...", goNatsPkg.Ident("ServerOption"), ") ", microPkg.Ident("Service"), " {")
-	g.P("service, options, err := ", goNatsImplPkg.Ident("NewService"), "(", strconv.Quote(service.GoName), ", nc, server, opts...)")
-	g.P("if err != nil {")
-	g.P("panic(err) // TODO: Update this to proper error handling")
+	// Generate optional interfaces for the handlers of the micro.Config, which are wired by impl.NewService
+	g.P("// ", service.GoName, "Stats can be implemented by a server to add custom data to the stats of every endpoint.")
+	g.P("type ", service.GoName, "Stats interface {")
+	g.P("Stats(*", microPkg.Ident("Endpoint"), ") any")
 	g.P("}")
-	g.P("if setId, ok := server.(", service.GoName, "Id); ok {")
-	g.P("setId.Set", service.GoName, "Id(service.Info().ID)")
+	g.P()
+	g.P("// ", service.GoName, "Done can be implemented by a server to be notified when the service is stopped.")
+	g.P("type ", service.GoName, "Done interface {")
+	g.P("Done(", microPkg.Ident("Service"), ")")
+	g.P("}")
+	g.P()
+	g.P("// ", service.GoName, "Err can be implemented by a server to be notified about asynchronous errors of the service subscriptions.")
+	g.P("type ", service.GoName, "Err interface {")
+	g.P("Err(", microPkg.Ident("Service"), ", *", microPkg.Ident("NATSError"), ")")
 	g.P("}")
-
-	g.P("_new", service.GoName, "Server(service, server, options)")
 	g.P()
 
+	// Generate optional interfaces passing the request to the methods, e.g. to get its signer
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			continue
+		}
+		if _, ok := reservedKeywords[strings.ToLower(method.GoName)]; ok {
+			continue
+		}
+		g.P("// ", withRequestName(service, method), " can be implemented by a server to get the request of ", method.GoName, ", e.g. its signer with gonats.SignerOf.")
+		g.P("// If it is implemented, ", method.GoName, "WithRequest is called instead of ", method.GoName, ".")
+		g.P("type ", withRequestName(service, method), " interface {")
+		g.P(method.GoName, "WithRequest", withRequestSignature(g, method))
+		g.P("}")
+		g.P()
+	}
+
+	if len(leaderMethods) > 0 || len(followerMethods) > 0 {
+		g.P("// ", service.GoName, "RoleChanged can be implemented by a server to be notified when the role of the instance changes,")
+		g.P("// by gonats.WithLeaderElection or SetRole, after the endpoints of the methods with a consensus_target were switched.")
+		g.P("type ", service.GoName, "RoleChanged interface {")
+		g.P("RoleChanged(", goNatsExtPkg.Ident("Role"), ")")
+		g.P("}")
+		g.P()
+	}
+
+	// Generate NewServer function
+	hasRoles := len(leaderMethods) > 0 || len(followerMethods) > 0
+	if hasRoles {
+		g.P("// New", srvName, " serves server on nc. The role of the instance can be switched with gonats.RoleOf(service) if it was created with gonats.WithRole.")
+	}
+	g.P("func New", srvName, "(nc *", natsConn, ", server ", srvName, ", opts ...", goNatsPkg.Ident("ServerOption"), ") (", microPkg.Ident("Service"), ", error) {")
+	generateNewService(g, service)
+	generateRegistration(g, "_new"+service.GoName+"Server(service, server, options, inFlight)")
+
 	if len(leaderMethods) > 0 {
 		g.P("if !options.WithoutLeaderFunctions {")
-		g.P("_new", service.GoName, "LeaderServer(service, server, options)")
+		generateRegistration(g, "_new"+service.GoName+"LeaderServer(service, server, options, inFlight)")
+		if hasForwardedMethods(service) {
+			g.P("} else if inFlight.ForwardsToLeader() {")
+			generateRegistration(g, "_forward"+service.GoName+"LeaderServer(service, options, inFlight)")
+		}
 		g.P("}")
 	}
 	if len(followerMethods) > 0 {
 		g.P("if !options.WithoutFollowerFunctions {")
-		g.P("_new", service.GoName, "FollowerServer(service, server, options)")
+		generateRegistration(g, "_new"+service.GoName+"FollowerServer(service, server, options, inFlight)")
 		g.P("}")
 	}
-	g.P("return service")
+	g.P("return service, nil")
+	g.P("}")
+	g.P()
+
+	// Generate reflection endpoint registration
+	g.P("func _register", service.GoName, "Reflection(service micro.Service, opts *", goNatsImplPkg.Ident("ServerOpts"), ") error {")
+	g.P("handler, err := ", goNatsExtPkg.Ident("ReflectionHandler"), "(", strconv.Quote(string(service.Desc.FullName())), ", ", goNatsExtPkg.Ident("WithInstanceHeader"), "(service.Info().ID))")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("err = service.AddEndpoint(", goNatsExtPkg.Ident("ReflectionEndpoint"), ", handler, opts.Subject(", goNatsExtPkg.Ident("ReflectionSubject"), "(", strconv.Quote(service.GoName), "), ", strconv.Quote(""), "))")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("return service.AddEndpoint(", goNatsExtPkg.Ident("ReflectionEndpoint"), "+", strconv.Quote("-Direct"), ", handler, opts.Subject(", goNatsExtPkg.Ident("ReflectionSubject"), "(", strconv.Quote(service.GoName), "), service.Info().ID))")
 	g.P("}")
+	g.P()
+
+	if hasRoles {
+		// Generate role endpoint registration
+		g.P("func _register", service.GoName, "Role(service micro.Service, opts *", goNatsImplPkg.Ident("ServerOpts"), ", inFlight *", goNatsExtPkg.Ident("InFlight"), ") error {")
+		g.P("err := service.AddEndpoint(", goNatsExtPkg.Ident("RoleEndpoint"), ", inFlight.RoleHandler(), ", microPkg.Ident("WithEndpointQueueGroup"), "(", nuidPkg.Ident("Next"), "()), opts.Subject(", goNatsExtPkg.Ident("RoleSubject"), "(", strconv.Quote(service.GoName), "), ", strconv.Quote(""), "))")
+		g.P("if err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("return service.AddEndpoint(", goNatsExtPkg.Ident("RoleEndpoint"), "+", strconv.Quote("-Direct"), ", inFlight.RoleHandler(), opts.Subject(", goNatsExtPkg.Ident("RoleSubject"), "(", strconv.Quote(service.GoName), "), service.Info().ID))")
+		g.P("}")
+		g.P()
+	}
 
-	g.P("func _new", service.GoName, "Server(service micro.Service, server ", service.GoName, "NATSServer, opts *", goNatsImplPkg.Ident("ServerOpts"), ") {")
+	g.P("func _new", service.GoName, "Server(service micro.Service, server ", service.GoName, "NATSServer, opts *", goNatsImplPkg.Ident("ServerOpts"), ", inFlight *", goNatsExtPkg.Ident("InFlight"), ") error {")
 	g.P("var err error")
 	g.P("_ = err") // In case there are no more methods so that err isn't unused
+	g.P("idHeader := ", goNatsExtPkg.Ident("WithInstanceHeader"), "(service.Info().ID)")
+	g.P("_ = idHeader")
 	g.P()
 
 	// Generate service endpoints
@@ -185,27 +267,23 @@ func generateServer(g *protogen.GeneratedFile, service *protogen.Service) error
 		generateEndpointHandler(g, service, method)
 	}
 
+	g.P("return nil")
 	g.P("}")
 	g.P()
 
 	// Generate NewLeaderServer function
 	if len(leaderMethods) > 0 {
-		g.P("func New", service.GoName, "NATSLeaderServer(nc *", natsConn, ", server ", service.GoName, "NATSLeaderServer, opts ...", goNatsPkg.Ident("ServerOption"), ") ", microPkg.Ident("Service"), " {")
-		g.P("service, options, err := ", goNatsImplPkg.Ident("NewService"), "(", strconv.Quote(service.GoName), ", nc, server, opts...)")
-		g.P("if err != nil {")
-		g.P("panic(err) // TODO: Update this to proper error handling")
-		g.P("}")
-		g.P("if setId, ok := server.(", service.GoName, "Id); ok {")
-		g.P("setId.Set", service.GoName, "Id(service.Info().ID)")
-		g.P("}")
-		g.P("_new", service.GoName, "LeaderServer(service, server, options)")
-		g.P("return service")
+		g.P("func New", service.GoName, "NATSLeaderServer(nc *", natsConn, ", server ", service.GoName, "NATSLeaderServer, opts ...", goNatsPkg.Ident("ServerOption"), ") (", microPkg.Ident("Service"), ", error) {")
+		generateNewService(g, service)
+		generateRegistration(g, "_new"+service.GoName+"LeaderServer(service, server, options, inFlight)")
+		g.P("return service, nil")
 		g.P("}")
 		g.P()
 
-		g.P("func _new", service.GoName, "LeaderServer(service micro.Service, server ", service.GoName, "NATSLeaderServer, opts *", goNatsImplPkg.Ident("ServerOpts"), ") {")
+		g.P("func _new", service.GoName, "LeaderServer(service micro.Service, server ", service.GoName, "NATSLeaderServer, opts *", goNatsImplPkg.Ident("ServerOpts"), ", inFlight *", goNatsExtPkg.Ident("InFlight"), ") error {")
 		g.P("var err error")
 		g.P("_ = err") // In case there are no more methods so that err isn't unused
+		g.P("idHeader := ", goNatsExtPkg.Ident("WithInstanceHeader"), "(service.Info().ID)")
 
 		for _, method := range service.Methods {
 			if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
@@ -216,28 +294,47 @@ func generateServer(g *protogen.GeneratedFile, service *protogen.Service) error
 				generateEndpointHandler(g, service, method)
 			}
 		}
+		g.P("return nil")
+		g.P("}")
+		g.P()
+	}
+
+	// Generate registration of the endpoints forwarding to the leader
+	if hasForwardedMethods(service) {
+		g.P("func _forward", service.GoName, "LeaderServer(service micro.Service, opts *", goNatsImplPkg.Ident("ServerOpts"), ", inFlight *", goNatsExtPkg.Ident("InFlight"), ") error {")
+		g.P("var err error")
+		for _, method := range service.Methods {
+			if !isForwarded(method) {
+				continue
+			}
+			subject := strconv.Quote(plugin.SubjectName(service, method))
+			g.P("err = service.AddEndpoint(", strconv.Quote(method.GoName), ", inFlight.Forward(", subject, "), opts.Subject(", subject, ", ", strconv.Quote(""), "))")
+			g.P("if err != nil {")
+			g.P("return err")
+			g.P("}")
+			g.P("err = service.AddEndpoint(", strconv.Quote(method.GoName+"-Direct"), ", inFlight.Forward(", subject, "), opts.Subject(", subject, ", service.Info().ID))")
+			g.P("if err != nil {")
+			g.P("return err")
+			g.P("}")
+		}
+		g.P("return nil")
 		g.P("}")
 		g.P()
 	}
 
 	// Generate NewFollowerServer function
 	if len(followerMethods) > 0 {
-		g.P("func New", service.GoName, "NATSFollowerServer(nc *", natsConn, ", server ", service.GoName, "NATSFollowerServer, opts ...", goNatsPkg.Ident("ServerOption"), ") ", microPkg.Ident("Service"), " {")
-		g.P("service, options, err := ", goNatsImplPkg.Ident("NewService"), "(", strconv.Quote(service.GoName), ", nc, server, opts...)")
-		g.P("if err != nil {")
-		g.P("panic(err) // TODO: Update this to proper error handling")
-		g.P("}")
-		g.P("if setId, ok := server.(", service.GoName, "Id); ok {")
-		g.P("setId.Set", service.GoName, "Id(service.Info().ID)")
-		g.P("}")
-		g.P("_new", service.GoName, "FollowerServer(service, server, options)")
-		g.P("return service")
+		g.P("func New", service.GoName, "NATSFollowerServer(nc *", natsConn, ", server ", service.GoName, "NATSFollowerServer, opts ...", goNatsPkg.Ident("ServerOption"), ") (", microPkg.Ident("Service"), ", error) {")
+		generateNewService(g, service)
+		generateRegistration(g, "_new"+service.GoName+"FollowerServer(service, server, options, inFlight)")
+		g.P("return service, nil")
 		g.P("}")
 		g.P()
 
-		g.P("func _new", service.GoName, "FollowerServer(service micro.Service, server ", service.GoName, "NATSFollowerServer, opts *", goNatsImplPkg.Ident("ServerOpts"), ") {")
+		g.P("func _new", service.GoName, "FollowerServer(service micro.Service, server ", service.GoName, "NATSFollowerServer, opts *", goNatsImplPkg.Ident("ServerOpts"), ", inFlight *", goNatsExtPkg.Ident("InFlight"), ") error {")
 		g.P("var err error")
 		g.P("_ = err") // In case there are no more methods so that err isn't unused
+		g.P("idHeader := ", goNatsExtPkg.Ident("WithInstanceHeader"), "(service.Info().ID)")
 
 		for _, method := range service.Methods {
 			if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
@@ -248,6 +345,7 @@ func generateServer(g *protogen.GeneratedFile, service *protogen.Service) error
 				generateEndpointHandler(g, service, method)
 			}
 		}
+		g.P("return nil")
 		g.P("}")
 		g.P()
 	}
@@ -255,6 +353,35 @@ func generateServer(g *protogen.GeneratedFile, service *protogen.Service) error
 	return nil
 }
 
+// generateNewService generates the creation of the micro.Service for server, shared by all New...Server functions.
+func generateNewService(g *protogen.GeneratedFile, service *protogen.Service) {
+	g.P("inFlight := ", goNatsExtPkg.Ident("NewInFlight"), "(server)")
+	serviceConfig := g.QualifiedGoIdent(goNatsExtPkg.Ident("ServiceConfig")) + "{}"
+	if hasServiceConfig(service) {
+		serviceConfig = "_" + service.GoName + "ServiceConfig"
+	}
+	g.P("service, options, err := ", goNatsExtPkg.Ident("NewService"), "(", strconv.Quote(service.GoName), ", nc, inFlight, ", serviceConfig, ", opts...)")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P("if setId, ok := server.(", service.GoName, "Id); ok {")
+	g.P("setId.Set", service.GoName, "Id(service.Info().ID)")
+	g.P("}")
+	generateRegistration(g, "_register"+service.GoName+"Reflection(service, options)")
+	if hasConsensusMethods(service) {
+		generateRegistration(g, "_register"+service.GoName+"Role(service, options, inFlight)")
+	}
+}
+
+// generateRegistration generates a call of a registration function inside a New...Server function,
+// stopping the service and returning the error if it fails.
+func generateRegistration(g *protogen.GeneratedFile, call string) {
+	g.P("if err := ", call, "; err != nil {")
+	g.P("_ = service.Stop()")
+	g.P("return nil, err")
+	g.P("}")
+}
+
 func generateEndpointHandler(g *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method) {
 	handler := method.GoName + "Handler"
 	g.P(handler, " := ", microPkg.Ident("HandlerFunc"), "(func(request ", microRequest, ") {")
@@ -264,7 +391,7 @@ func generateEndpointHandler(g *protogen.GeneratedFile, service *protogen.Servic
 		handlerReq = "&req"
 		g.P("var req ", method.Input.GoIdent)
 		g.P("if err := ", protoUnmarshal, "(request.Data(), &req); err != nil {")
-		g.P("request.Error(", strconv.Quote("560"), ", ", strconv.Quote("Failed to unmarshal proto message"), ", []byte(err.Error()))")
+		g.P("request.Error(", strconv.Quote("560"), ", ", strconv.Quote("Failed to unmarshal proto message"), ", []byte(err.Error()), idHeader)")
 		g.P("return")
 		g.P("}")
 		g.P()
@@ -272,17 +399,27 @@ func generateEndpointHandler(g *protogen.GeneratedFile, service *protogen.Servic
 	var handlerResp string
 	if method.Output.Location.SourceFile != emptyPb {
 		handlerResp = "response, "
+		g.P("var response *", method.Output.GoIdent)
+	}
+	withRequestReq := "request"
+	if handlerReq != "" {
+		withRequestReq += ", " + handlerReq
 	}
-	g.P(handlerResp, "err := server.", method.GoName, "(", handlerReq, ")")
+	g.P("var err error")
+	g.P("if withRequest, ok := server.(", withRequestName(service, method), "); ok {")
+	g.P(handlerResp, "err = withRequest.", method.GoName, "WithRequest(", withRequestReq, ")")
+	g.P("} else {")
+	g.P(handlerResp, "err = server.", method.GoName, "(", handlerReq, ")")
+	g.P("}")
 	g.P("if err != nil {")
 	g.P("if ", goNatsPkg.Ident("IsServiceError"), "(err) {")
 	g.P(slogPkg.Ident("Warn"), "(", strconv.Quote("Server implementations should not return ServiceError, use go_nats.NewServerError instead"), ", ", strconv.Quote("error"), ", err)")
 	g.P("}")
 	g.P("var serverErr ", goNatsPkg.Ident("ServerError"))
 	g.P("if ", errorsPkg.Ident("As"), "(err, &serverErr) {")
-	g.P("request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())")
+	g.P("request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)")
 	g.P("} else {")
-	g.P("request.Error(", strconv.Quote("500"), ", ", strconv.Quote("Internal server error"), ", []byte(err.Error()))")
+	g.P("request.Error(", strconv.Quote("500"), ", ", strconv.Quote("Internal server error"), ", []byte(err.Error()), idHeader)")
 	g.P("}")
 	g.P("return")
 	g.P("}")
@@ -290,33 +427,110 @@ func generateEndpointHandler(g *protogen.GeneratedFile, service *protogen.Servic
 	if method.Output.Location.SourceFile != emptyPb {
 		g.P("data, err := ", protoMarshal, "(response)")
 		g.P("if err != nil {")
-		g.P("request.Error(", strconv.Quote("560"), ", ", strconv.Quote("Failed to marshal proto message"), ", []byte(err.Error()))")
+		g.P("request.Error(", strconv.Quote("560"), ", ", strconv.Quote("Failed to marshal proto message"), ", []byte(err.Error()), idHeader)")
 		g.P("return")
 		g.P("}")
-		g.P("request.Respond(data)")
+		g.P("request.Respond(data, idHeader)")
 	} else {
-		g.P("request.Respond(nil)")
+		g.P("request.Respond(nil, idHeader)")
 	}
 	g.P("})")
+	generateEndpointRegistration(g, service, method)
+}
+
+// withRequestName returns the name of the optional interface passing the request to method.
+func withRequestName(service *protogen.Service, method *protogen.Method) string {
+	return service.GoName + method.GoName + "WithRequest"
+}
+
+// withRequestSignature returns the parameters and results of the WithRequest method of method.
+func withRequestSignature(g *protogen.GeneratedFile, method *protogen.Method) string {
+	params := "request " + g.QualifiedGoIdent(microPkg.Ident("Request"))
+	if method.Input.Location.SourceFile != emptyPb {
+		params += ", req *" + g.QualifiedGoIdent(method.Input.GoIdent)
+	}
+	var resp string
+	if method.Output.Location.SourceFile != emptyPb {
+		resp = "*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", "
+	}
+	return "(" + params + ") (" + resp + "error)"
+}
 
+// generateEndpointRegistration generates the registration of the endpoints of method, using the handler variable named after it.
+func generateEndpointRegistration(g *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method) {
+	handler := method.GoName + "Handler"
+	config := method.GoName + "Config"
+	tracked := func(endpoint string) string {
+		return "inFlight.Handler(" + strconv.Quote(endpoint) + ", " + handler + ", " + config + ")"
+	}
+	metadata := endpointMetadata(g, method)
+	generateEndpointConfig(g, config, method)
+	if isDurable(method) {
+		// Consume the requests of the method from its stream instead of adding endpoints
+		g.P("err = inFlight.Durable(", strconv.Quote(service.GoName), ", ", strconv.Quote(method.GoName), ", ", handler, ", ", config, ")")
+		g.P("if err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P()
+		return
+	}
+	role := consensusRole(method)
+	if role != nil {
+		// Switch the endpoints of the method with the role of the instance if it is decided at runtime
+		queueGroup := strconv.Quote("")
+		if plugin.IsUsingBroadcasting(method) {
+			queueGroup = g.QualifiedGoIdent(nuidPkg.Ident("Next")) + "()"
+		}
+		name := method.GoName
+		if plugin.IsUsingBroadcasting(method) {
+			name += "-Broadcast"
+		}
+		g.P("if inFlight.DynamicRoles() {")
+		g.P("err = inFlight.AddRoleEndpoint(", role, ", ", strconv.Quote(name), ", ", tracked(name), ", ", strconv.Quote(plugin.SubjectName(service, method)), ", ", strconv.Quote(""), ", ", queueGroup, ")")
+		g.P("if err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("err = inFlight.AddRoleEndpoint(", role, ", ", strconv.Quote(method.GoName+"-Direct"), ", ", tracked(method.GoName+"-Direct"), ", ", strconv.Quote(plugin.SubjectName(service, method)), ", service.Info().ID, ", strconv.Quote(""), ")")
+		g.P("if err != nil {")
+		g.P("return err")
+		g.P("}")
+		if isForwarded(method) {
+			// Forward the requests to the leader while the instance is a follower
+			g.P("if inFlight.ForwardsToLeader() {")
+			subject := strconv.Quote(plugin.SubjectName(service, method))
+			g.P("err = inFlight.AddRoleEndpoint(", goNatsExtPkg.Ident("RoleFollower"), ", ", strconv.Quote(method.GoName), ", inFlight.Forward(", subject, "), ", subject, ", ", strconv.Quote(""), ", ", strconv.Quote(""), ")")
+			g.P("if err != nil {")
+			g.P("return err")
+			g.P("}")
+			g.P("err = inFlight.AddRoleEndpoint(", goNatsExtPkg.Ident("RoleFollower"), ", ", strconv.Quote(method.GoName+"-Direct"), ", inFlight.Forward(", subject, "), ", subject, ", service.Info().ID, ", strconv.Quote(""), ")")
+			g.P("if err != nil {")
+			g.P("return err")
+			g.P("}")
+			g.P("}")
+		}
+		g.P("} else {")
+	}
 	if plugin.IsUsingBroadcasting(method) {
 		// Add a broadcast endpoint for the method
-		g.P("err = service.AddEndpoint(", strconv.Quote(method.GoName+"-Broadcast"), ", ", handler, ", ", microPkg.Ident("WithEndpointQueueGroup"), "(", nuidPkg.Ident("Next"), "()), opts.Subject(", strconv.Quote(plugin.SubjectName(service, method)), ", ", strconv.Quote(""), "))")
+		g.P("err = service.AddEndpoint(", strconv.Quote(method.GoName+"-Broadcast"), ", ", tracked(method.GoName+"-Broadcast"), ", ", microPkg.Ident("WithEndpointQueueGroup"), "(", nuidPkg.Ident("Next"), "()), opts.Subject(", strconv.Quote(plugin.SubjectName(service, method)), ", ", strconv.Quote(""), ")", metadata, ")")
 		g.P("if err != nil {")
-		g.P("panic(err) // TODO: Update this to proper error handling")
+		g.P("return err")
 		g.P("}")
 	} else {
 		// Add a shared endpoint for the method
-		g.P("err = service.AddEndpoint(", strconv.Quote(method.GoName), ", ", handler, ", opts.Subject(", strconv.Quote(plugin.SubjectName(service, method)), ", ", strconv.Quote(""), "))")
+		g.P("err = service.AddEndpoint(", strconv.Quote(method.GoName), ", ", tracked(method.GoName), ", opts.Subject(", strconv.Quote(plugin.SubjectName(service, method)), ", ", strconv.Quote(""), ")", metadata, ")")
 		g.P("if err != nil {")
-		g.P("panic(err) // TODO: Update this to proper error handling")
+		g.P("return err")
 		g.P("}")
 	}
 	// Add a direct endpoint for the method
-	g.P("err = service.AddEndpoint(", strconv.Quote(method.GoName+"-Direct"), ", ", handler, ", opts.Subject(", strconv.Quote(plugin.SubjectName(service, method)), ", service.Info().ID))")
+	g.P("err = service.AddEndpoint(", strconv.Quote(method.GoName+"-Direct"), ", ", tracked(method.GoName+"-Direct"), ", opts.Subject(", strconv.Quote(plugin.SubjectName(service, method)), ", service.Info().ID)", metadata, ")")
 	g.P("if err != nil {")
-	g.P("panic(err) // TODO: Update this to proper error handling")
+	g.P("return err")
 	g.P("}")
+	if role != nil {
+		g.P("}")
+	}
 	g.P()
 }
 
@@ -337,26 +551,53 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 		if _, ok := reservedKeywords[strings.ToLower(method.GoName)]; ok {
 			return errors.New("reserved keyword '" + method.GoName + "' used as method name")
 		}
-		broadcasting := plugin.IsUsingBroadcasting(method)
+		if strings.EqualFold(method.GoName, "leader") && hasConsensusMethods(service) {
+			return errors.New("method name 'Leader' is reserved in services with consensus_target methods")
+		}
 		var req, resp string
 		if method.Input.Location.SourceFile != emptyPb {
 			req = "req *" + g.QualifiedGoIdent(method.Input.GoIdent) + ", "
 		}
-		if method.Output.Location.SourceFile != emptyPb {
-			var prefix string
-			if broadcasting {
-				prefix = "[]"
-			}
-			resp = prefix + "*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", "
+		if isDurable(method) && plugin.IsUsingBroadcasting(method) {
+			return errors.New("durable method '" + method.GoName + "' can't use broadcasting")
+		}
+		if isDurable(method) && plugin.GetConsensusTarget(method) != nil {
+			return errors.New("durable method '" + method.GoName + "' can't have a consensus_target")
+		}
+		if isOneWay(method) && (method.Output.Location.SourceFile != emptyPb || plugin.IsUsingBroadcasting(method) || isDurable(method)) {
+			return errors.New("one-way method '" + method.GoName + "' must return google.protobuf.Empty and can't use broadcasting or the durable option")
+		}
+		if isDurable(method) {
+			resp = durableCallType(g, method) + ", "
+		} else if plugin.IsUsingBroadcasting(method) {
+			resp = broadcastResultType(g, method) + ", "
+		} else if method.Output.Location.SourceFile != emptyPb {
+			resp = "*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", "
 		}
 		g.AnnotateSymbol(cliName+"."+method.GoName, protogen.Annotation{Location: method.Location})
-		if broadcasting {
-			g.P(method.Comments.Leading, method.GoName, "(", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, "[]", goNatsPkg.Ident("ServiceError"), ", error)")
-		} else {
-			g.P(method.Comments.Leading, method.GoName, "(", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, "error)")
+		g.P(method.Comments.Leading, method.GoName, "(", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, "error)")
+		if isDurable(method) {
+			g.P("// ", method.GoName, "ReplyTo is like ", method.GoName, ", but also publishes the result to the subject reply")
+			g.P(method.GoName, "ReplyTo(reply string, ", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, "error)")
+		}
+		if plugin.IsUsingBroadcasting(method) {
+			g.P("// ", method.GoName, "To is like ", method.GoName, ", but only sends the request to the direct endpoints of the instances selected by target")
+			g.P(method.GoName, "To(target ", goNatsExtPkg.Ident("Target"), ", ", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, "error)")
 		}
 	}
+	if hasConsensusMethods(service) {
+		g.P("// Leader returns the instance ID of the current leader of this service, to which calls with gonats.WithLeader are pinned")
+		g.P("Leader(opts ...", goNatsPkg.Ident("CallOption"), ") (string, error)")
+	}
 	g.P("SetTimeout(", timeDuration, ")")
+	g.P("// SetBalancer sends all following calls to the direct endpoint of the instance picked by balancer, or to the queue group if it is nil")
+	g.P("SetBalancer(balancer ", goNatsExtPkg.Ident("Balancer"), ")")
+	g.P("// SetSigner signs all following requests with the NKey signer, or stops signing them if it is nil")
+	g.P("SetSigner(signer ", nkeysPkg.Ident("KeyPair"), ")")
+	g.P("// SetXKey encrypts all following requests and their responses end to end with the public xkey of the service, or stops encrypting them if it is empty")
+	g.P("SetXKey(xkey string)")
+	g.P("// EnableEncryption encrypts all following requests and their responses end to end with the xkey advertised by the instances of this service")
+	g.P("EnableEncryption(opts ...", goNatsPkg.Ident("CallOption"), ") error")
 	g.P("// ListInstances returns a list containing all instances of this service")
 	g.P("// This is a convenience method that calls ", goNatsPkg.Ident("Ping"), " with no options")
 	g.P("ListInstances() ([]*", goNatsPkg.Ident("Ping"), ", error)")
@@ -366,6 +607,10 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 	g.P("Stats(opts ...", goNatsPkg.Ident("CallOption"), ") ([]*micro.Stats, error)")
 	g.P("// Info returns the info of either all instances or a specific instance of this service")
 	g.P("Info(opts ...", goNatsPkg.Ident("CallOption"), ") ([]*micro.Info, error)")
+	if !declaresReflect(service) {
+		g.P("// Reflect returns the descriptors of the proto file declaring this service and all of its dependencies from either any or a specific instance of this service")
+		g.P("Reflect(opts ...", goNatsPkg.Ident("CallOption"), ") (*", descriptorPkg.Ident("FileDescriptorSet"), ", error)")
+	}
 	g.P("}")
 	g.P()
 
@@ -373,6 +618,11 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 	g.P("type ", unexport(cliName), " struct {")
 	g.P("nc *", natsConn)
 	g.P("timeout ", timeDuration)
+	g.P("config ", goNatsExtPkg.Ident("ClientConfig"))
+	g.P("balancing ", goNatsExtPkg.Ident("Balancing"))
+	if hasConsensusMethods(service) {
+		g.P("leader ", goNatsExtPkg.Ident("LeaderCache"))
+	}
 	g.P("}")
 	g.P()
 
@@ -384,6 +634,48 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 	g.P("}")
 	g.P()
 
+	// Generate SetBalancer function and the discovery of the instances it picks from
+	g.P("func (c *", unexport(cliName), ") SetBalancer(balancer ", goNatsExtPkg.Ident("Balancer"), ") {")
+	g.P("c.balancing.Set(balancer)")
+	g.P("}")
+	g.P()
+	g.P("func (c *", unexport(cliName), ") instances(subject string) ([]", goNatsExtPkg.Ident("Instance"), ", error) {")
+	g.P("results, err := request(c.nc, c.timeout, c.config.Unencrypted(), ", strconv.Quote(fmt.Sprintf("%s.%s.%s", micro.APIPrefix, micro.InfoVerb, service.GoName)), ", nil, nil, func(data []byte, rtt ", timeDuration, ") (*", microPkg.Ident("Info"), ", error) {")
+	g.P("var obj ", microPkg.Ident("Info"))
+	g.P("if err := ", protogen.GoImportPath("encoding/json").Ident("Unmarshal"), "(data, &obj); err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P("return &obj, nil")
+	g.P("})")
+	g.P("return ", goNatsExtPkg.Ident("ServingInstances"), "(results, subject), err")
+	g.P("}")
+	g.P()
+
+	// Generate SetSigner function
+	g.P("func (c *", unexport(cliName), ") SetSigner(signer ", nkeysPkg.Ident("KeyPair"), ") {")
+	g.P("c.config.Signer = signer")
+	g.P("}")
+	g.P()
+
+	// Generate SetXKey and EnableEncryption functions
+	g.P("func (c *", unexport(cliName), ") SetXKey(xkey string) {")
+	g.P("c.config.XKey = xkey")
+	g.P("}")
+	g.P()
+	g.P("func (c *", unexport(cliName), ") EnableEncryption(opts ...", goNatsPkg.Ident("CallOption"), ") error {")
+	g.P("infos, err := c.Info(opts...)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("xkey, err := ", goNatsExtPkg.Ident("ServiceXKey"), "(infos)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("c.config.XKey = xkey")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+
 	// Generate ListInstances function
 	g.P("func (c *", unexport(cliName), ") ListInstances() ([]*", goNatsPkg.Ident("Ping"), ", error) {")
 	g.P("return c.Ping()")
@@ -395,15 +687,88 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 	generateReqFunc(g, cliName, service.GoName, "Info", microPkg.Ident("Info"), micro.InfoVerb)
 	generateReqFunc(g, cliName, service.GoName, "Ping", goNatsPkg.Ident("Ping"), micro.PingVerb)
 
+	// Generate Reflect function, unless the service declares a method of that name
+	if !declaresReflect(service) {
+		g.P("func (c *", unexport(cliName), ") Reflect(opts ...", goNatsPkg.Ident("CallOption"), ") (*", descriptorPkg.Ident("FileDescriptorSet"), ", error) {")
+		g.P("var response ", descriptorPkg.Ident("FileDescriptorSet"))
+		g.P("if err := c.handleWithRetry(c.config.Unencrypted(), nil, ", goNatsExtPkg.Ident("ReflectionSubject"), "(", strconv.Quote(service.GoName), "), false, &response, opts...); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return &response, nil")
+		g.P("}")
+		g.P()
+	}
+
+	// Generate Leader function
+	if hasConsensusMethods(service) {
+		g.P("func (c *", unexport(cliName), ") Leader(opts ...", goNatsPkg.Ident("CallOption"), ") (string, error) {")
+		g.P("results, err := request(c.nc, c.timeout, c.config.Unencrypted(), ", goNatsExtPkg.Ident("RoleSubject"), "(", strconv.Quote(service.GoName), "), nil, nil, func(data []byte, rtt ", timeDuration, ") (", goNatsExtPkg.Ident("Role"), ", error) {")
+		g.P("return ", goNatsExtPkg.Ident("Role"), "(data), nil")
+		g.P("}, append(opts[:len(opts):len(opts)], ", goNatsPkg.Ident("WithInstanceID"), "(", strconv.Quote(""), "))...)")
+		g.P("if err != nil {")
+		g.P("return \"\", err")
+		g.P("}")
+		g.P("id, err := ", goNatsExtPkg.Ident("LeaderOf"), "(results)")
+		g.P("if err != nil {")
+		g.P("return \"\", err")
+		g.P("}")
+		g.P("c.leader.Set(id)")
+		g.P("return id, nil")
+		g.P("}")
+		g.P()
+	}
+
+	// Generate resolveLeader function
+	g.P("// resolveLeader replaces the instance ID set by ", goNatsExtPkg.Ident("WithLeader"), " in opts with the ID of the current leader.")
+	g.P("func (c *", unexport(cliName), ") resolveLeader(opts []", goNatsPkg.Ident("CallOption"), ") ([]", goNatsPkg.Ident("CallOption"), ", error) {")
+	if hasConsensusMethods(service) {
+		g.P("return ", goNatsExtPkg.Ident("ResolveLeader"), "(opts, func() (string, error) { return c.Leader(opts...) })")
+	} else {
+		g.P("return ", goNatsExtPkg.Ident("ResolveLeader"), "(opts, nil)")
+	}
+	g.P("}")
+	g.P()
+
 	// Generate handle with retry function
-	g.P("func (c *", unexport(cliName), ") handleWithRetry(req ", protoMessage, ", subject string, out ", protoMessage, ", opts ...", goNatsPkg.Ident("CallOption"), ") (err error) {")
+	g.P("func (c *", unexport(cliName), ") handleWithRetry(config ", goNatsExtPkg.Ident("ClientConfig"), ", req ", protoMessage, ", subject string, balanced bool, out ", protoMessage, ", opts ...", goNatsPkg.Ident("CallOption"), ") (err error) {")
+	if !hasConsensusMethods(service) {
+		g.P("if opts, err = c.resolveLeader(opts); err != nil {")
+		g.P("return")
+		g.P("}")
+	}
 	g.P("options := ", goNatsImplPkg.Ident("ProcessCallOptions"), "(opts...)")
 	g.P("timeout := options.GetTimeoutOr(c.timeout)")
+	g.P("balanced = balanced && options.InstanceID == \"\"")
+	if hasConsensusMethods(service) {
+		g.P("pinned := options.InstanceID == ", goNatsExtPkg.Ident("LeaderInstance"))
+		g.P("var rediscovered bool")
+	}
 	g.P()
 	g.P("var tries int")
 	g.P("for {")
-	g.P("err = c.handle(options.Context, req, options.Subject(subject), out, timeout)")
-	g.P("if err == nil || !errors.Is(err, ", natsPkg.Ident("ErrNoResponders"), ") {")
+	if hasConsensusMethods(service) {
+		g.P("if pinned {")
+		g.P("if options.InstanceID, err = c.leader.Get(func() (string, error) { return c.Leader(opts...) }); err != nil {")
+		g.P("return")
+		g.P("}")
+		g.P("}")
+	}
+	g.P("if balanced {")
+	g.P("options.InstanceID = c.balancing.Pick(options.Subject(subject), c.instances)")
+	g.P("}")
+	g.P("err = c.handle(options.Context, config, req, options.Subject(subject), out, timeout)")
+	g.P("if balanced {")
+	g.P("c.balancing.Done(options.InstanceID, err)")
+	g.P("}")
+	if hasConsensusMethods(service) {
+		g.P("// A former leader is forgotten, and the call repeated once with the current one")
+		g.P("if pinned && c.leader.Failed(options.InstanceID, err) && !rediscovered && (options.Context == nil || options.Context.Err() == nil) {")
+		g.P("rediscovered = true")
+		g.P("continue")
+		g.P("}")
+	}
+	g.P("retryAfter, rateLimited := ", goNatsExtPkg.Ident("RetryAfter"), "(err)")
+	g.P("if err == nil || !rateLimited && !errors.Is(err, ", natsPkg.Ident("ErrNoResponders"), ") {")
 	g.P("return")
 	g.P("}")
 	g.P("tries++")
@@ -415,38 +780,46 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 	g.P("return")
 	g.P("}")
 	g.P("if tries >= options.Retries {")
-	g.P("err = ", errorsPkg.Ident("New"), "(", strconv.Quote("Failed to call service after max tries: "), "+ err.Error())")
+	g.P("err = ", fmtPkg.Ident("Errorf"), "(", strconv.Quote("Failed to call service after max tries: %w"), ", err)")
 	g.P("return")
 	g.P("}")
-	g.P("time.Sleep(options.RetryDelay)")
+	g.P("time.Sleep(max(options.RetryDelay, retryAfter))")
 	g.P("}")
 	g.P("}")
 
 	// Generate handle function
-	g.P("func (c *", unexport(cliName), ") handle(ctx ", contextPkg.Ident("Context"), ", req ", protoMessage, ", subject string, out ", protoMessage, ", timeout ", timeDuration, ") (err error) {")
+	g.P("func (c *", unexport(cliName), ") handle(ctx ", contextPkg.Ident("Context"), ", config ", goNatsExtPkg.Ident("ClientConfig"), ", req ", protoMessage, ", subject string, out ", protoMessage, ", timeout ", timeDuration, ") (err error) {")
 	g.P("var data []byte")
 	g.P("if req != nil {")
 	g.P("if data, err = ", protoMarshal, "(req); err != nil {")
 	g.P("return ", goNatsPkg.Ident("ErrMarshallingFailed"))
 	g.P("}")
 	g.P("}")
-	g.P("var msg *", natsPkg.Ident("Msg"))
+	g.P("sealer, err := ", goNatsExtPkg.Ident("NewSealer"), "(config.XKey)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("msg, err := ", goNatsExtPkg.Ident("NewRequest"), "(c.nc, subject, data, config.Signer, sealer)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P(goNatsExtPkg.Ident("SetTimeout"), "(msg, ctx, timeout)")
 	g.P("if ctx == nil {")
-	g.P("msg, err = c.nc.Request(subject, data, timeout)")
+	g.P("msg, err = c.nc.RequestMsg(msg, timeout)")
 	g.P("} else {")
-	g.P("msg, err = c.nc.RequestWithContext(ctx, subject, data)")
+	g.P("msg, err = c.nc.RequestMsgWithContext(ctx, msg)")
 	g.P("}")
 	g.P("if err != nil {")
 	g.P("return err")
 	g.P("}")
-	g.P("if errMsg, errCode := msg.Header.Get(", microPkg.Ident("ErrorHeader"), "), msg.Header.Get(", microPkg.Ident("ErrorCodeHeader"), "); len(errMsg) > 0 && len(errCode) > 0 {")
-	g.P("if len(msg.Data) == 0 {")
-	g.P("return ", goNatsPkg.Ident("ServiceError"), "{errCode, errMsg, \"\"}")
+	g.P("if data, err = sealer.Open(msg); err != nil {")
+	g.P("return err")
 	g.P("}")
-	g.P("return ", goNatsPkg.Ident("ServiceError"), "{errCode, errMsg, string(msg.Data)}")
+	g.P("if errMsg, errCode := msg.Header.Get(", microPkg.Ident("ErrorHeader"), "), msg.Header.Get(", microPkg.Ident("ErrorCodeHeader"), "); len(errMsg) > 0 && len(errCode) > 0 {")
+	g.P("return ", goNatsExtPkg.Ident("WithRetryAfter"), "(", goNatsPkg.Ident("ServiceError"), "{Code: errCode, Description: errMsg, Details: string(data)}, msg.Header)")
 	g.P("}")
 	g.P("if out != nil {")
-	g.P("if err = ", protoUnmarshal, "(msg.Data, out); err != nil {")
+	g.P("if err = ", protoUnmarshal, "(data, out); err != nil {")
 	g.P("return ", goNatsPkg.Ident("ErrUnmarshallingFailed"))
 	g.P("}")
 	g.P("}")
@@ -454,11 +827,61 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 	g.P("}")
 	g.P()
 
+	// Generate publish function for one-way methods
+	if slices.ContainsFunc(service.Methods, isOneWay) {
+		g.P("// publish sends req to subject without waiting for a response, returning once it is flushed to the server.")
+		g.P("func (c *", unexport(cliName), ") publish(req ", protoMessage, ", subject string, opts ...", goNatsPkg.Ident("CallOption"), ") (err error) {")
+		g.P("var data []byte")
+		g.P("if req != nil {")
+		g.P("if data, err = ", protoMarshal, "(req); err != nil {")
+		g.P("return ", goNatsPkg.Ident("ErrMarshallingFailed"))
+		g.P("}")
+		g.P("}")
+		g.P("if opts, err = c.resolveLeader(opts); err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("options := ", goNatsImplPkg.Ident("ProcessCallOptions"), "(opts...)")
+		g.P("sealer, err := ", goNatsExtPkg.Ident("NewSealer"), "(c.config.XKey)")
+		g.P("if err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("msg, err := ", goNatsExtPkg.Ident("NewRequest"), "(c.nc, options.Subject(subject), data, c.config.Signer, sealer)")
+		g.P("if err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("if err = c.nc.PublishMsg(msg); err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("ctx, cancel := ", contextPkg.Ident("WithTimeout"), "(options.Ctx(), options.GetTimeoutOr(c.timeout))")
+		g.P("defer cancel()")
+		g.P("return c.nc.FlushWithContext(ctx)")
+		g.P("}")
+		g.P()
+	}
+
 	// Generate request function
-	g.P("func request[T any](conn *", natsConn, ", timeout ", timeDuration, ", subject string, data []byte, collector func([]byte, ", timeDuration, ") (*T, error), opts ...", goNatsPkg.Ident("CallOption"), ") ([]*T, []", goNatsPkg.Ident("ServiceError"), ", error) {")
+	g.P("// request sends data to all instances listening on subject and collects their responses until the timeout or the finisher is hit.")
+	g.P("// If targets is not nil, data is only sent to the direct endpoints of the given instances instead.")
+	g.P("func request[T any](conn *", natsConn, ", timeout ", timeDuration, ", config ", goNatsExtPkg.Ident("ClientConfig"), ", subject string, targets []string, data []byte, collector func([]byte, ", timeDuration, ") (T, error), opts ...", goNatsPkg.Ident("CallOption"), ") ([]", goNatsExtPkg.Ident("BroadcastResult"), "[T], error) {")
 	g.P("options := ", goNatsImplPkg.Ident("ProcessCallOptions"), "(opts...)")
 	g.P("timeout = options.GetTimeoutOr(timeout)")
 	g.P()
+	g.P("subjects := []string{options.Subject(subject)}")
+	g.P("if targets != nil {")
+	g.P("if len(targets) == 0 {")
+	g.P("return []", goNatsExtPkg.Ident("BroadcastResult"), "[T]{}, nil")
+	g.P("}")
+	g.P("subjects = make([]string, len(targets))")
+	g.P("for i, id := range targets {")
+	g.P("subjects[i] = ", goNatsImplPkg.Ident("ProcessCallOptions"), "(append(opts[:len(opts):len(opts)], ", goNatsPkg.Ident("WithInstanceID"), "(id))...).Subject(subject)")
+	g.P("}")
+	g.P("}")
+	g.P()
+	g.P("sealer, err := ", goNatsExtPkg.Ident("NewSealer"), "(config.XKey)")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P()
 	g.P("ctx, cancel := ", protogen.GoImportPath("context").Ident("WithTimeout"), "(options.Ctx(), timeout)")
 	g.P("defer cancel()")
 	g.P()
@@ -473,10 +896,9 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 	g.P("}")
 	g.P("}()")
 	g.P("var start ", timePkg.Ident("Time"))
-	g.P("res := []*T{}")
+	g.P("results := []", goNatsExtPkg.Ident("BroadcastResult"), "[T]{}")
 	g.P("mu := ", protogen.GoImportPath("sync").Ident("Mutex"), "{}")
-	g.P("errCh := make(chan error)")
-	g.P("serviceErrs := []", goNatsPkg.Ident("ServiceError"), "{}")
+	g.P("errCh := make(chan error, 1)")
 	g.P("var finisher *", timePkg.Ident("Timer"))
 	g.P("if !options.DisableFinisher {")
 	g.P("finisher = ", timePkg.Ident("NewTimer"), "(timeout)")
@@ -489,13 +911,19 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 	g.P("}")
 	g.P("}()")
 	g.P("}")
+	g.P("fail := func(err error) {")
+	g.P("select {")
+	g.P("case errCh <- err:")
+	g.P("default:")
+	g.P("}")
+	g.P("}")
 	g.P("sub, err := conn.Subscribe(conn.NewRespInbox(), func(msg *", natsPkg.Ident("Msg"), ") {")
 	g.P("mu.Lock()")
 	g.P("defer mu.Unlock()")
 	g.P()
 	g.P("rtt := ", timePkg.Ident("Since"), "(start)")
 	g.P("if msg.Header.Get(\"Status\") == \"503\" {")
-	g.P("errCh <- ", natsPkg.Ident("ErrNoResponders"))
+	g.P("fail(", natsPkg.Ident("ErrNoResponders"), ")")
 	g.P("return")
 	g.P("}")
 	g.P()
@@ -503,38 +931,56 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 	g.P("finisher.Reset(250 * ", timePkg.Ident("Millisecond"), ")")
 	g.P("}")
 	g.P()
-	g.P("if errMsg, errCode := msg.Header.Get(", microPkg.Ident("ErrorHeader"), "), msg.Header.Get(", microPkg.Ident("ErrorCodeHeader"), "); len(errMsg) > 0 && len(errCode) > 0 {")
-	g.P("if len(msg.Data) == 0 {")
-	g.P("serviceErrs = append(serviceErrs, ", goNatsPkg.Ident("ServiceError"), "{Code: errCode, Description: errMsg})")
-	g.P("} else {")
-	g.P("serviceErrs = append(serviceErrs, ", goNatsPkg.Ident("ServiceError"), "{Code: errCode, Description: errMsg, Details: string(msg.Data)})")
+	g.P("data, err := sealer.Open(msg)")
+	g.P("if err != nil {")
+	g.P("fail(err)")
+	g.P("return")
 	g.P("}")
+	g.P("result := ", goNatsExtPkg.Ident("BroadcastResult"), "[T]{InstanceID: msg.Header.Get(", goNatsExtPkg.Ident("InstanceHeader"), "), RTT: rtt}")
+	g.P("if errMsg, errCode := msg.Header.Get(", microPkg.Ident("ErrorHeader"), "), msg.Header.Get(", microPkg.Ident("ErrorCodeHeader"), "); len(errMsg) > 0 && len(errCode) > 0 {")
+	g.P("result.Err = ", goNatsPkg.Ident("ServiceError"), "{Code: errCode, Description: errMsg, Details: string(data)}")
+	g.P("} else if collector != nil {")
+	g.P("col, err := collector(data, rtt)")
+	g.P("if err != nil {")
+	g.P("fail(err)")
 	g.P("return")
 	g.P("}")
-	g.P("if collector != nil {")
-	g.P("if col, err := collector(msg.Data, rtt); err != nil {")
-	g.P("errCh <- err")
-	g.P("} else {")
-	g.P("res = append(res, col)")
+	g.P("result.Response = col")
 	g.P("}")
+	g.P("results = append(results, result)")
+	g.P("if targets != nil && len(results) == len(targets) {")
+	g.P("cancel()")
 	g.P("}")
 	g.P("})")
 	g.P("if err != nil {")
-	g.P("return nil, nil, err")
+	g.P("return nil, err")
 	g.P("}")
 	g.P("defer sub.Unsubscribe()")
 	g.P()
+	g.P("msgs := make([]*", natsPkg.Ident("Msg"), ", len(subjects))")
+	g.P("for i, subject := range subjects {")
+	g.P("if msgs[i], err = ", goNatsExtPkg.Ident("NewRequest"), "(conn, subject, data, config.Signer, sealer); err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P(goNatsExtPkg.Ident("SetTimeout"), "(msgs[i], ctx, timeout)")
+	g.P("msgs[i].Reply = sub.Subject")
+	g.P("}")
 	g.P("start = ", timePkg.Ident("Now"), "()")
-	g.P("err = conn.PublishRequest(options.Subject(subject), sub.Subject, data)")
-	g.P("if err != nil {")
-	g.P("return nil, nil, err")
+	g.P("for _, msg := range msgs {")
+	g.P("if err = conn.PublishMsg(msg); err != nil {")
+	g.P("return nil, err")
+	g.P("}")
 	g.P("}")
 	g.P()
 	g.P("select {")
 	g.P("case err = <-errCh:")
-	g.P("return nil, serviceErrs, err")
+	g.P("mu.Lock()")
+	g.P("defer mu.Unlock()")
+	g.P("return results, err")
 	g.P("case <-ctx.Done():")
-	g.P("return res, serviceErrs, nil")
+	g.P("mu.Lock()")
+	g.P("defer mu.Unlock()")
+	g.P("return results, nil")
 	g.P("}")
 	g.P("}")
 	g.P()
@@ -561,11 +1007,7 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 			handleReq = "nil"
 		}
 		if method.Output.Location.SourceFile != emptyPb {
-			var prefix string
-			if broadcasting {
-				prefix = "[]"
-			}
-			resp = prefix + "*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", "
+			resp = "*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", "
 			handleResp = "&response"
 			returnResp = "&response, "
 		} else {
@@ -573,10 +1015,13 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 			returnResp = ""
 		}
 		if broadcasting {
-			g.P("func (c *", unexport(cliName), ") ", method.GoName, "(", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, "[]", goNatsPkg.Ident("ServiceError"), ", error) {")
-		} else {
-			g.P("func (c *", unexport(cliName), ") ", method.GoName, "(", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, "error) {")
+			resp = broadcastResultType(g, method) + ", "
+		}
+		if isDurable(method) {
+			generateDurableCall(g, cliName, service, method, req)
+			continue
 		}
+		g.P("func (c *", unexport(cliName), ") ", method.GoName, "(", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, "error) {")
 
 		if method.Output.Location.SourceFile != emptyPb && !broadcasting {
 			g.P("var response ", method.Output.GoIdent)
@@ -584,49 +1029,40 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 		}
 
 		if broadcasting {
-			var input string
-			if method.Input.Location.SourceFile != emptyPb {
-				var returnResp string
-				if method.Output.Location.SourceFile != emptyPb {
-					returnResp = "nil, "
-				}
-				g.P("var data []byte")
-				g.P("if req != nil {")
-				g.P("var err error")
-				g.P("if data, err = ", protoMarshal, "(req); err != nil {")
-				g.P("return ", returnResp, "nil, ", goNatsPkg.Ident("ErrMarshallingFailed"))
-				g.P("}")
-				g.P("}")
-				input = "data"
-			} else {
-				input = "nil"
-			}
-
-			if method.Output.Location.SourceFile != emptyPb {
-				g.P("objs, serviceErrs, err := request(c.nc, c.timeout, ", strconv.Quote(plugin.SubjectName(service, method)), ", ", input, ", func(data []byte, rtt ", timeDuration, ") (*", method.Output.GoIdent, ", error) {")
-				g.P("var obj ", method.Output.GoIdent)
-				g.P("if err := ", protoUnmarshal, "(data, &obj); err != nil {")
-				g.P("return nil, err")
-				g.P("}")
-				g.P("return &obj, nil")
-				g.P("}, opts...)")
-				g.P("return objs, serviceErrs, err")
-			} else {
-				g.P("_, serviceErrs, err := request[struct{}](c.nc, c.timeout, ", strconv.Quote(plugin.SubjectName(service, method)), ", ", input, ", nil, opts...)")
-				g.P("return serviceErrs, err")
-			}
+			g.P("opts, err := c.resolveLeader(opts)")
+			g.P("if err != nil {")
+			g.P("return nil, err")
+			g.P("}")
+			generateBroadcastCall(g, service, method, "nil")
+		} else if isOneWay(method) {
+			g.P("return c.publish(", handleReq, ", ", strconv.Quote(plugin.SubjectName(service, method)), ", opts...)")
 		} else {
 			var errReturn = "nil, "
 			if method.Output.Location.SourceFile == emptyPb {
 				errReturn = ""
 			}
-			g.P("if err := c.handleWithRetry("+handleReq+", ", strconv.Quote(plugin.SubjectName(service, method)), ", ", handleResp, ", opts...); err != nil {")
+			// Methods with a consensus_target are only served by some instances, which a Balancer can't tell apart
+			balanced := strconv.FormatBool(plugin.GetConsensusTarget(method) == nil)
+			g.P("if err := c.handleWithRetry(c.config, "+handleReq+", ", strconv.Quote(plugin.SubjectName(service, method)), ", ", balanced, ", ", handleResp, ", opts...); err != nil {")
 			g.P("return ", errReturn, "err")
 			g.P("}")
 			g.P("return ", returnResp, "nil")
 		}
 		g.P("}")
 		g.P()
+
+		if broadcasting {
+			g.P("func (c *", unexport(cliName), ") ", method.GoName, "To(target ", goNatsExtPkg.Ident("Target"), ", ", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, "error) {")
+			g.P("targets, err := target.Resolve(func() ([]*", microPkg.Ident("Info"), ", error) {")
+			g.P("return c.Info(", goNatsExtPkg.Ident("AllInstances"), "(opts)...)")
+			g.P("})")
+			g.P("if err != nil {")
+			g.P("return nil, err")
+			g.P("}")
+			generateBroadcastCall(g, service, method, "targets")
+			g.P("}")
+			g.P()
+		}
 	}
 
 	g.P("//endregion")
@@ -636,7 +1072,11 @@ func generateClient(g *protogen.GeneratedFile, service *protogen.Service) error
 
 func generateReqFunc(g *protogen.GeneratedFile, cliName, goName, method string, T any, verb micro.Verb) {
 	g.P("func (c *", unexport(cliName), ") ", method, "(opts ...", goNatsPkg.Ident("CallOption"), ") ([]*", T, ", error) {")
-	g.P("objs, _, err := request(c.nc, c.timeout, ", strconv.Quote(fmt.Sprintf("%s.%s.%s", micro.APIPrefix, verb, goName)), ", nil, func(data []byte, rtt ", timeDuration, ") (*", T, ", error) {")
+	g.P("opts, err := c.resolveLeader(opts)")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P("results, err := request(c.nc, c.timeout, c.config.Unencrypted(), ", strconv.Quote(fmt.Sprintf("%s.%s.%s", micro.APIPrefix, verb, goName)), ", nil, nil, func(data []byte, rtt ", timeDuration, ") (*", T, ", error) {")
 	g.P("var obj ", T)
 	g.P("if err := ", protogen.GoImportPath("encoding/json").Ident("Unmarshal"), "(data, &obj); err != nil {")
 	g.P("return nil, err")
@@ -646,11 +1086,132 @@ func generateReqFunc(g *protogen.GeneratedFile, cliName, goName, method string,
 	}
 	g.P("return &obj, nil")
 	g.P("}, opts...)")
-	g.P("return objs, err")
+	g.P("return ", goNatsExtPkg.Ident("Responses"), "(results), err")
 	g.P("}")
 	g.P()
 }
 
+// generateBroadcastCall generates the body of a broadcasting client method, sending the request to targets.
+func generateBroadcastCall(g *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method, targets string) {
+	var input string
+	if method.Input.Location.SourceFile != emptyPb {
+		g.P("var data []byte")
+		g.P("if req != nil {")
+		g.P("var err error")
+		g.P("if data, err = ", protoMarshal, "(req); err != nil {")
+		g.P("return nil, ", goNatsPkg.Ident("ErrMarshallingFailed"))
+		g.P("}")
+		g.P("}")
+		input = "data"
+	} else {
+		input = "nil"
+	}
+
+	if method.Output.Location.SourceFile != emptyPb {
+		g.P("return request(c.nc, c.timeout, c.config, ", strconv.Quote(plugin.SubjectName(service, method)), ", ", targets, ", ", input, ", func(data []byte, rtt ", timeDuration, ") (*", method.Output.GoIdent, ", error) {")
+		g.P("var obj ", method.Output.GoIdent)
+		g.P("if err := ", protoUnmarshal, "(data, &obj); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return &obj, nil")
+		g.P("}, opts...)")
+	} else {
+		g.P("return request[struct{}](c.nc, c.timeout, c.config, ", strconv.Quote(plugin.SubjectName(service, method)), ", ", targets, ", ", input, ", nil, opts...)")
+	}
+}
+
+// generateDurableCall generates the client methods of a durable method, publishing the request to the stream of the service.
+func generateDurableCall(g *protogen.GeneratedFile, cliName string, service *protogen.Service, method *protogen.Method, req string) {
+	resp := durableCallType(g, method)
+	var handleReq string
+	if req != "" {
+		handleReq = "req, "
+	}
+	g.P("func (c *", unexport(cliName), ") ", method.GoName, "(", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, ", error) {")
+	g.P("return c.", method.GoName, "ReplyTo(", strconv.Quote(""), ", ", handleReq, "opts...)")
+	g.P("}")
+	g.P()
+
+	g.P("func (c *", unexport(cliName), ") ", method.GoName, "ReplyTo(reply string, ", req, "opts ...", goNatsPkg.Ident("CallOption"), ") (", resp, ", error) {")
+	input := "nil"
+	if req != "" {
+		g.P("var data []byte")
+		g.P("if req != nil {")
+		g.P("var err error")
+		g.P("if data, err = ", protoMarshal, "(req); err != nil {")
+		g.P("return nil, ", goNatsPkg.Ident("ErrMarshallingFailed"))
+		g.P("}")
+		g.P("}")
+		input = "data"
+	}
+	g.P("if _, err := ", goNatsExtPkg.Ident("ResolveLeader"), "(opts, nil); err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P("options := ", goNatsImplPkg.Ident("ProcessCallOptions"), "(opts...)")
+	g.P("ctx, cancel := ", contextPkg.Ident("WithTimeout"), "(options.Ctx(), options.GetTimeoutOr(c.timeout))")
+	g.P("defer cancel()")
+	publish := []any{"(ctx, c.nc, c.config, ", strconv.Quote(service.GoName), ", ", strconv.Quote(method.GoName), ", options.ExtraSubject, reply, ", input, ", "}
+	if method.Output.Location.SourceFile != emptyPb {
+		g.P(append(append([]any{"return ", goNatsExtPkg.Ident("PublishDurable")}, publish...), "func(data []byte) (*", method.Output.GoIdent, ", error) {")...)
+		g.P("var obj ", method.Output.GoIdent)
+		g.P("if err := ", protoUnmarshal, "(data, &obj); err != nil {")
+		g.P("return nil, ", goNatsPkg.Ident("ErrUnmarshallingFailed"))
+		g.P("}")
+		g.P("return &obj, nil")
+		g.P("})")
+	} else {
+		g.P(append(append([]any{"return ", goNatsExtPkg.Ident("PublishDurable"), "[struct{}]"}, publish...), "nil)")...)
+	}
+	g.P("}")
+	g.P()
+}
+
+// durableCallType returns the result type of a durable client method.
+func durableCallType(g *protogen.GeneratedFile, method *protogen.Method) string {
+	if method.Output.Location.SourceFile == emptyPb {
+		return "*" + g.QualifiedGoIdent(goNatsExtPkg.Ident("DurableCall")) + "[struct{}]"
+	}
+	return "*" + g.QualifiedGoIdent(goNatsExtPkg.Ident("DurableCall")) + "[*" + g.QualifiedGoIdent(method.Output.GoIdent) + "]"
+}
+
+// broadcastResultType returns the result type of a broadcasting client method.
+func broadcastResultType(g *protogen.GeneratedFile, method *protogen.Method) string {
+	if method.Output.Location.SourceFile == emptyPb {
+		return "[]" + g.QualifiedGoIdent(goNatsExtPkg.Ident("BroadcastAck"))
+	}
+	return "[]" + g.QualifiedGoIdent(goNatsExtPkg.Ident("BroadcastResult")) + "[*" + g.QualifiedGoIdent(method.Output.GoIdent) + "]"
+}
+
+// generateUnimplementedServer generates a struct implementing every method of the server, including the leader and follower methods,
+// so that implementations embedding it keep compiling when methods are added.
+func generateUnimplementedServer(g *protogen.GeneratedFile, service *protogen.Service) {
+	name := "Unimplemented" + service.GoName + "NATSServer"
+	g.P("// ", name, " can be embedded to have forward compatible implementations of ", service.GoName, "NATSServer.")
+	g.P("// Every method returns a ServerError with code ", strconv.Quote("501"), ".")
+	g.P("type ", name, " struct{}")
+	g.P()
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			continue
+		}
+		if _, ok := reservedKeywords[strings.ToLower(method.GoName)]; ok {
+			continue
+		}
+		var req, resp, ret string
+		if method.Input.Location.SourceFile != emptyPb {
+			req = "*" + g.QualifiedGoIdent(method.Input.GoIdent)
+		}
+		if method.Output.Location.SourceFile != emptyPb {
+			resp = "*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", "
+			ret = "nil, "
+		}
+		g.P("func (", name, ") ", method.GoName, "(", req, ") (", resp, "error) {")
+		g.P("return ", ret, goNatsExtPkg.Ident("Unimplemented"), "(", strconv.Quote(method.GoName), ")")
+		g.P("}")
+		g.P()
+	}
+}
+
 func generateService(g *protogen.GeneratedFile, service *protogen.Service) error {
 	if err := generateClient(g, service); err != nil {
 		return err
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"xiam.li/protonats/go/plugin"
+)
+
+func generateGRPCFile(gen *protogen.Plugin, file *protogen.File) error {
+	if len(file.Services) == 0 {
+		return nil
+	}
+	filename := file.GeneratedFilenamePrefix + "_nats_grpc.pb.go"
+	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-nats. DO NOT EDIT.")
+	g.P("// Versions:")
+	g.P("// - protoc-gen-go-nats ", version)
+	g.P("// - protoc        v", plugin.ProtocVersion(gen))
+	if file.Proto.GetOptions().GetDeprecated() {
+		g.P("// ", file.Desc.Path(), " is a deprecated file.")
+	} else {
+		g.P("// source: ", file.Desc.Path())
+	}
+	g.P()
+	g.P("package ", file.GoPackageName)
+	g.P()
+	for _, service := range file.Services {
+		generateGRPCAdapter(g, service)
+		generateGRPCBridge(g, service)
+	}
+	return nil
+}
+
+func generateGRPCAdapter(g *protogen.GeneratedFile, service *protogen.Service) {
+	grpcName := service.GoName + "Server"
+
+	g.P("//region gRPC Adapter")
+	g.P("// New", service.GoName, "NATSServerFromGRPC serves a gRPC implementation of ", service.GoName, ", as generated by protoc-gen-go-grpc, over NATS.")
+	g.P("// The NATS headers of a request are passed to the implementation as incoming metadata, and the metadata it sets")
+	g.P("// using grpc.SetHeader or grpc.SetTrailer is sent as NATS headers of the response.")
+	g.P("// The context passed to the implementation ends once the client stopped waiting for the response.")
+	g.P("// gRPC status errors are translated into the matching ServerError codes.")
+	g.P("func New", service.GoName, "NATSServerFromGRPC(nc *", natsConn, ", server ", grpcName, ", opts ...", goNatsPkg.Ident("ServerOption"), ") (", microPkg.Ident("Service"), ", error) {")
+	generateNewService(g, service)
+	generateRegistration(g, "_new"+service.GoName+"GRPCServer(service, server, options, inFlight)")
+	g.P("return service, nil")
+	g.P("}")
+	g.P()
+
+	g.P("func _new", service.GoName, "GRPCServer(service micro.Service, server ", grpcName, ", opts *", goNatsImplPkg.Ident("ServerOpts"), ", inFlight *", goNatsExtPkg.Ident("InFlight"), ") error {")
+	g.P("var err error")
+	g.P("_ = err") // In case there are no methods so that err isn't unused
+	g.P("idHeader := ", goNatsExtPkg.Ident("WithInstanceHeader"), "(service.Info().ID)")
+	g.P()
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			// TODO: Skipping currently unsupported streaming methods for now
+			continue
+		}
+		switch {
+		case plugin.IsConsensusLeader(method):
+			g.P("if !opts.WithoutLeaderFunctions {")
+			generateGRPCEndpointHandler(g, service, method)
+			g.P("}")
+		case plugin.IsConsensusFollower(method):
+			g.P("if !opts.WithoutFollowerFunctions {")
+			generateGRPCEndpointHandler(g, service, method)
+			g.P("}")
+		default:
+			generateGRPCEndpointHandler(g, service, method)
+		}
+	}
+	if hasForwardedMethods(service) {
+		g.P("if opts.WithoutLeaderFunctions && inFlight.ForwardsToLeader() {")
+		g.P("return _forward", service.GoName, "LeaderServer(service, opts, inFlight)")
+		g.P("}")
+	}
+	g.P("return nil")
+	g.P("}")
+	g.P("//endregion")
+	g.P()
+}
+
+func generateGRPCEndpointHandler(g *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method) {
+	fullMethod := "/" + string(service.Desc.FullName()) + "/" + string(method.Desc.Name())
+
+	g.P(method.GoName, "Handler := ", microPkg.Ident("HandlerFunc"), "(func(request ", microRequest, ") {")
+	g.P("var req ", method.Input.GoIdent)
+	g.P("if err := ", protoUnmarshal, "(request.Data(), &req); err != nil {")
+	g.P("request.Error(", strconv.Quote("560"), ", ", strconv.Quote("Failed to unmarshal proto message"), ", []byte(err.Error()), idHeader)")
+	g.P("return")
+	g.P("}")
+	g.P()
+	g.P("ctx, call := ", grpcNatsPkg.Ident("NewCall"), "(request, ", strconv.Quote(fullMethod), ")")
+	g.P("defer call.Cancel()")
+	g.P("response, err := server.", method.GoName, "(ctx, &req)")
+	g.P("if err != nil {")
+	g.P(grpcNatsPkg.Ident("RespondError"), "(request, err, call.Headers(), idHeader)")
+	g.P("return")
+	g.P("}")
+	g.P()
+	g.P("data, err := ", protoMarshal, "(response)")
+	g.P("if err != nil {")
+	g.P("request.Error(", strconv.Quote("560"), ", ", strconv.Quote("Failed to marshal proto message"), ", []byte(err.Error()), idHeader)")
+	g.P("return")
+	g.P("}")
+	g.P("request.Respond(data, call.Headers(), idHeader)")
+	g.P("})")
+	generateEndpointRegistration(g, service, method)
+}
+
+func generateGRPCBridge(g *protogen.GeneratedFile, service *protogen.Service) {
+	bridgeName := "_" + service.GoName + "GRPCBridge"
+
+	g.P("//region gRPC Bridge")
+	g.P("type ", bridgeName, " struct {")
+	g.P("Unimplemented", service.GoName, "Server")
+	g.P("client ", service.GoName, "NATSClient")
+	g.P("}")
+	g.P()
+	g.P("// New", service.GoName, "GRPCBridge returns a gRPC implementation of ", service.GoName, ", to be registered with Register", service.GoName, "Server,")
+	g.P("// that forwards every unary call to the NATS service through client.")
+	g.P("// The deadline of the call is used as timeout, and a single instance can be selected with the ", strconv.Quote("protonats-instance"), " metadata.")
+	g.P("// Broadcasting methods return the first response and send the IDs of all responding instances as ", strconv.Quote("protonats-instance"), " header.")
+	g.P("// Errors are translated into the matching gRPC status codes. Streaming methods are not supported.")
+	g.P("func New", service.GoName, "GRPCBridge(client ", service.GoName, "NATSClient) ", service.GoName, "Server {")
+	g.P("return &", bridgeName, "{client: client}")
+	g.P("}")
+	g.P()
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			// TODO: Skipping currently unsupported streaming methods for now
+			continue
+		}
+		generateGRPCBridgeMethod(g, bridgeName, service, method)
+	}
+	g.P("//endregion")
+	g.P()
+}
+
+func generateGRPCBridgeMethod(g *protogen.GeneratedFile, bridgeName string, service *protogen.Service, method *protogen.Method) {
+	g.P("func (b *", bridgeName, ") ", method.GoName, "(ctx ", contextPkg.Ident("Context"), ", req *", method.Input.GoIdent, ") (*", method.Output.GoIdent, ", error) {")
+	var req string
+	if method.Input.Location.SourceFile != emptyPb {
+		req = "req, "
+	}
+	opts := g.QualifiedGoIdent(grpcNatsPkg.Ident("CallOptions")) + "(ctx)..."
+
+	if isDurable(method) {
+		g.P("call, err := b.client.", method.GoName, "(", req, opts, ")")
+		g.P("if err != nil {")
+		g.P("return nil, ", grpcNatsPkg.Ident("Status"), "(err)")
+		g.P("}")
+		if method.Output.Location.SourceFile != emptyPb {
+			g.P("resp, err := call.Wait(ctx)")
+			g.P("if err != nil {")
+			g.P("return nil, ", grpcNatsPkg.Ident("Status"), "(err)")
+			g.P("}")
+			g.P("return resp, nil")
+		} else {
+			g.P("if _, err := call.Wait(ctx); err != nil {")
+			g.P("return nil, ", grpcNatsPkg.Ident("Status"), "(err)")
+			g.P("}")
+			g.P("return new(", method.Output.GoIdent, "), nil")
+		}
+	} else if plugin.IsUsingBroadcasting(method) {
+		g.P("var results ", broadcastResultType(g, method))
+		g.P("var err error")
+		g.P("if target, ok := ", grpcNatsPkg.Ident("Target"), "(ctx); ok {")
+		g.P("results, err = b.client.", method.GoName, "To(target, ", req, opts, ")")
+		g.P("} else {")
+		g.P("results, err = b.client.", method.GoName, "(", req, opts, ")")
+		g.P("}")
+		if method.Output.Location.SourceFile != emptyPb {
+			g.P("return ", grpcNatsPkg.Ident("BroadcastResponse"), "(ctx, results, err)")
+		} else {
+			g.P("if _, err := ", grpcNatsPkg.Ident("BroadcastResponse"), "(ctx, results, err); err != nil {")
+			g.P("return nil, err")
+			g.P("}")
+			g.P("return new(", method.Output.GoIdent, "), nil")
+		}
+	} else if method.Output.Location.SourceFile != emptyPb {
+		g.P("resp, err := b.client.", method.GoName, "(", req, opts, ")")
+		g.P("if err != nil {")
+		g.P("return nil, ", grpcNatsPkg.Ident("Status"), "(err)")
+		g.P("}")
+		g.P("return resp, nil")
+	} else {
+		g.P("if err := b.client.", method.GoName, "(", req, opts, "); err != nil {")
+		g.P("return nil, ", grpcNatsPkg.Ident("Status"), "(err)")
+		g.P("}")
+		g.P("return new(", method.Output.GoIdent, "), nil")
+	}
+	g.P("}")
+	g.P()
+}
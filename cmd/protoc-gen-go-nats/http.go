@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"xiam.li/protonats/go/plugin"
+)
+
+var httpPkg = protogen.GoImportPath("net/http")
+
+// httpRoute is a single route of a method in the generated HTTP gateway.
+type httpRoute struct {
+	// pattern is the http.ServeMux pattern of the route
+	pattern string
+	// pathFields maps the wildcards of pattern to the field paths of the request
+	pathFields map[string]string
+	body       string
+}
+
+func generateHTTPFile(gen *protogen.Plugin, file *protogen.File) error {
+	if len(file.Services) == 0 {
+		return nil
+	}
+	filename := file.GeneratedFilenamePrefix + "_nats_http.pb.go"
+	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-nats. DO NOT EDIT.")
+	g.P("// Versions:")
+	g.P("// - protoc-gen-go-nats ", version)
+	g.P("// - protoc        v", plugin.ProtocVersion(gen))
+	if file.Proto.GetOptions().GetDeprecated() {
+		g.P("// ", file.Desc.Path(), " is a deprecated file.")
+	} else {
+		g.P("// source: ", file.Desc.Path())
+	}
+	g.P()
+	g.P("package ", file.GoPackageName)
+	g.P()
+	for _, service := range file.Services {
+		if err := generateHTTPGateway(g, service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateHTTPGateway(g *protogen.GeneratedFile, service *protogen.Service) error {
+	g.P("//region HTTP Gateway")
+	g.P("// New", service.GoName, "NATSHTTPHandler returns an http.Handler calling the methods of ", service.GoName, " through client.")
+	g.P("// Every method is served on POST /", service.GoName, "/<Method>, unless it declares google.api.http rules.")
+	g.P("// Request bodies are limited to gonats.DefaultHTTPMaxBodySize and instances can't be selected, unless changed with opts.")
+	g.P("func New", service.GoName, "NATSHTTPHandler(client ", service.GoName, "NATSClient, opts ...", goNatsExtPkg.Ident("HTTPOption"), ") ", httpPkg.Ident("Handler"), " {")
+	g.P("cfg := ", goNatsExtPkg.Ident("NewHTTPConfig"), "(opts...)")
+	g.P("mux := ", httpPkg.Ident("NewServeMux"), "()")
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			// TODO: Skipping currently unsupported streaming methods for now
+			continue
+		}
+		routes, err := httpRoutes(service, method)
+		if err != nil {
+			return err
+		}
+		for _, route := range routes {
+			g.P("mux.Handle(", strconv.Quote(route.pattern), ", _", service.GoName, "_", method.GoName, "_HTTPHandler(client, cfg, ", goNatsExtPkg.Ident("HTTPBinding"), "{")
+			if len(route.pathFields) > 0 {
+				g.P("PathFields: map[string]string{")
+				for i := range len(route.pathFields) {
+					wildcard := "p" + strconv.Itoa(i)
+					g.P(strconv.Quote(wildcard), ": ", strconv.Quote(route.pathFields[wildcard]), ",")
+				}
+				g.P("},")
+			}
+			if route.body != "" {
+				g.P("Body: ", strconv.Quote(route.body), ",")
+			}
+			g.P("}))")
+		}
+	}
+	g.P("return mux")
+	g.P("}")
+	g.P()
+
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			continue
+		}
+		generateHTTPMethodHandler(g, service, method)
+	}
+	g.P("//endregion")
+	g.P()
+	return nil
+}
+
+func generateHTTPMethodHandler(g *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method) {
+	g.P("func _", service.GoName, "_", method.GoName, "_HTTPHandler(client ", service.GoName, "NATSClient, cfg *", goNatsExtPkg.Ident("HTTPConfig"), ", binding ", goNatsExtPkg.Ident("HTTPBinding"), ") ", httpPkg.Ident("HandlerFunc"), " {")
+	g.P("return func(w ", httpPkg.Ident("ResponseWriter"), ", r *", httpPkg.Ident("Request"), ") {")
+	var req string
+	if method.Input.Location.SourceFile != emptyPb {
+		g.P("var req ", method.Input.GoIdent)
+		g.P("if err := cfg.DecodeRequest(w, r, binding, &req); err != nil {")
+		g.P(goNatsExtPkg.Ident("WriteHTTPError"), "(w, err)")
+		g.P("return")
+		g.P("}")
+		req = "&req, "
+	} else {
+		g.P("_ = binding")
+	}
+	g.P("opts, cancel := cfg.CallOptions(r)")
+	g.P("defer cancel()")
+
+	if isDurable(method) {
+		g.P("call, err := client.", method.GoName, "(", req, "opts...)")
+		g.P("if err != nil {")
+		g.P(goNatsExtPkg.Ident("WriteHTTPError"), "(w, err)")
+		g.P("return")
+		g.P("}")
+		g.P(goNatsExtPkg.Ident("WriteHTTPAccepted"), "(w, call.DurableReceipt)")
+	} else if plugin.IsUsingBroadcasting(method) {
+		g.P("var results ", broadcastResultType(g, method))
+		g.P("var err error")
+		g.P("if target, ok := cfg.Target(r); ok {")
+		g.P("results, err = client.", method.GoName, "To(target, ", req, "opts...)")
+		g.P("} else {")
+		g.P("results, err = client.", method.GoName, "(", req, "opts...)")
+		g.P("}")
+		g.P("if err != nil {")
+		g.P(goNatsExtPkg.Ident("WriteHTTPError"), "(w, err)")
+		g.P("return")
+		g.P("}")
+		g.P(goNatsExtPkg.Ident("WriteHTTPResults"), "(w, results)")
+	} else if method.Output.Location.SourceFile != emptyPb {
+		g.P("resp, err := client.", method.GoName, "(", req, "opts...)")
+		g.P("if err != nil {")
+		g.P(goNatsExtPkg.Ident("WriteHTTPError"), "(w, err)")
+		g.P("return")
+		g.P("}")
+		g.P(goNatsExtPkg.Ident("WriteHTTPResponse"), "(w, resp)")
+	} else {
+		g.P("if err := client.", method.GoName, "(", req, "opts...); err != nil {")
+		g.P(goNatsExtPkg.Ident("WriteHTTPError"), "(w, err)")
+		g.P("return")
+		g.P("}")
+		g.P(goNatsExtPkg.Ident("WriteHTTPResponse"), "(w, nil)")
+	}
+	g.P("}")
+	g.P("}")
+	g.P()
+}
+
+// httpRoutes returns the routes of the method, either from its google.api.http rules or the default POST /<Service>/<Method>.
+func httpRoutes(service *protogen.Service, method *protogen.Method) ([]httpRoute, error) {
+	rule, ok := proto.GetExtension(method.Desc.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if !ok || rule == nil {
+		return []httpRoute{{pattern: "POST /" + service.GoName + "/" + method.GoName, body: "*"}}, nil
+	}
+	var routes []httpRoute
+	for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+		route, err := httpRouteFromRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", service.Desc.FullName(), method.GoName, err)
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// httpRouteFromRule converts a google.api.http rule to a http.ServeMux route.
+// Only whole path segments can be bound to fields, either as {field}, {field=*} or, as the last segment, {field=**}.
+func httpRouteFromRule(rule *annotations.HttpRule) (httpRoute, error) {
+	var method, template string
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		method, template = "GET", pattern.Get
+	case *annotations.HttpRule_Put:
+		method, template = "PUT", pattern.Put
+	case *annotations.HttpRule_Post:
+		method, template = "POST", pattern.Post
+	case *annotations.HttpRule_Delete:
+		method, template = "DELETE", pattern.Delete
+	case *annotations.HttpRule_Patch:
+		method, template = "PATCH", pattern.Patch
+	case *annotations.HttpRule_Custom:
+		method, template = pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return httpRoute{}, fmt.Errorf("google.api.http rule without pattern")
+	}
+	if !strings.HasPrefix(template, "/") {
+		return httpRoute{}, fmt.Errorf("path template %q has to start with /", template)
+	}
+
+	route := httpRoute{pathFields: make(map[string]string), body: rule.GetBody()}
+	segments := strings.Split(template[1:], "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") {
+			if strings.ContainsAny(segment, "{}*:") {
+				return httpRoute{}, fmt.Errorf("unsupported path template %q", template)
+			}
+			continue
+		}
+		if !strings.HasSuffix(segment, "}") {
+			return httpRoute{}, fmt.Errorf("unsupported path template %q", template)
+		}
+		field, match, _ := strings.Cut(segment[1:len(segment)-1], "=")
+		wildcard := "p" + strconv.Itoa(len(route.pathFields))
+		switch {
+		case match == "" || match == "*":
+			segments[i] = "{" + wildcard + "}"
+		case match == "**" && i == len(segments)-1:
+			segments[i] = "{" + wildcard + "...}"
+		default:
+			return httpRoute{}, fmt.Errorf("unsupported path template %q", template)
+		}
+		route.pathFields[wildcard] = field
+	}
+	route.pattern = method + " /" + strings.Join(segments, "/")
+	return route, nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"xiam.li/go-protonats/gonats"
+	"xiam.li/protonats/go/plugin"
+)
+
+// hasServiceConfig reports whether any of the service level options are set on the service.
+func hasServiceConfig(service *protogen.Service) bool {
+	opts := service.Desc.Options()
+	return proto.HasExtension(opts, gonats.E_ServiceVersion) ||
+		proto.HasExtension(opts, gonats.E_ServiceDescription) ||
+		proto.HasExtension(opts, gonats.E_ServiceMetadata)
+}
+
+// generateServiceConfig generates the gonats.ServiceConfig variable for the service options.
+func generateServiceConfig(g *protogen.GeneratedFile, service *protogen.Service) {
+	opts := service.Desc.Options()
+	g.P("// _", service.GoName, "ServiceConfig contains the service options declared for ", service.GoName)
+	g.P("var _", service.GoName, "ServiceConfig = ", goNatsExtPkg.Ident("ServiceConfig"), "{")
+	if version := proto.GetExtension(opts, gonats.E_ServiceVersion).(string); version != "" {
+		g.P("Version: ", strconv.Quote(version), ",")
+	}
+	if description := proto.GetExtension(opts, gonats.E_ServiceDescription).(string); description != "" {
+		g.P("Description: ", strconv.Quote(description), ",")
+	}
+	if metadata := proto.GetExtension(opts, gonats.E_ServiceMetadata).([]*gonats.MetadataEntry); len(metadata) > 0 {
+		g.P("Metadata: ", metadataLiteral(metadata), ",")
+	}
+	g.P("}")
+	g.P()
+}
+
+// endpointMetadata returns the micro.WithEndpointMetadata option for the method options,
+// prefixed with a comma, or an empty string if the method doesn't declare any.
+func endpointMetadata(g *protogen.GeneratedFile, method *protogen.Method) string {
+	opts := method.Desc.Options()
+	metadata := proto.GetExtension(opts, gonats.E_MethodMetadata).([]*gonats.MetadataEntry)
+	if description := proto.GetExtension(opts, gonats.E_MethodDescription).(string); description != "" {
+		metadata = append(metadata[:len(metadata):len(metadata)], &gonats.MetadataEntry{Key: gonats.EndpointDescriptionMetadata, Value: description})
+	}
+	if len(metadata) == 0 {
+		return ""
+	}
+	return ", " + g.QualifiedGoIdent(microPkg.Ident("WithEndpointMetadata")) + "(" + metadataLiteral(metadata) + ")"
+}
+
+// generateEndpointConfig generates the variable name containing the gonats.EndpointConfig for the method options.
+func generateEndpointConfig(g *protogen.GeneratedFile, name string, method *protogen.Method) {
+	opts := method.Desc.Options()
+	g.P(name, " := ", goNatsExtPkg.Ident("EndpointConfig"), "{")
+	g.P("Method: ", strconv.Quote(method.GoName), ",")
+	if proto.HasExtension(opts, gonats.E_WorkerPool) {
+		pool := proto.GetExtension(opts, gonats.E_WorkerPool).(*gonats.WorkerPool)
+		g.P("Pool: &", goNatsExtPkg.Ident("WorkerPool"), "{Workers: ", pool.GetWorkers(), ", Queue: ", pool.GetQueue(), "},")
+	}
+	if proto.HasExtension(opts, gonats.E_RateLimit) {
+		limit := proto.GetExtension(opts, gonats.E_RateLimit).(*gonats.RateLimit)
+		g.P("RateLimit: &", goNatsExtPkg.Ident("RateLimit"), "{Rps: ", strconv.FormatFloat(limit.GetRps(), 'g', -1, 64), ", Burst: ", limit.GetBurst(), "},")
+	}
+	if roles := proto.GetExtension(opts, gonats.E_RequiredRoles).([]string); len(roles) > 0 {
+		quoted := make([]string, len(roles))
+		for i, role := range roles {
+			quoted[i] = strconv.Quote(role)
+		}
+		g.P("Roles: []string{", strings.Join(quoted, ", "), "},")
+	}
+	if isOneWay(method) {
+		g.P("OneWay: true,")
+	}
+	g.P("}")
+}
+
+// isDurable reports whether the method uses the durable option.
+func isDurable(method *protogen.Method) bool {
+	return proto.GetExtension(method.Desc.Options(), gonats.E_Durable).(bool)
+}
+
+// isOneWay reports whether the method uses the one_way option.
+func isOneWay(method *protogen.Method) bool {
+	return proto.GetExtension(method.Desc.Options(), gonats.E_OneWay).(bool)
+}
+
+// hasConsensusMethods reports whether any method of the service has a consensus_target.
+func hasConsensusMethods(service *protogen.Service) bool {
+	return slices.ContainsFunc(service.Methods, func(method *protogen.Method) bool {
+		return plugin.GetConsensusTarget(method) != nil
+	})
+}
+
+// consensusRole returns the gonats.Role serving the method, or nil if it has no consensus_target.
+func consensusRole(method *protogen.Method) any {
+	switch {
+	case plugin.IsConsensusLeader(method):
+		return goNatsExtPkg.Ident("RoleLeader")
+	case plugin.IsConsensusFollower(method):
+		return goNatsExtPkg.Ident("RoleFollower")
+	}
+	return nil
+}
+
+// isForwarded reports whether followers forward requests to the method to the leader with gonats.WithLeaderForwarding,
+// which applies to the unary methods with consensus_target LEADER that neither use broadcasting nor durable delivery.
+func isForwarded(method *protogen.Method) bool {
+	return plugin.IsConsensusLeader(method) && !plugin.IsUsingBroadcasting(method) && !isDurable(method) &&
+		!method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer()
+}
+
+// hasForwardedMethods reports whether any method of the service is forwarded to the leader.
+func hasForwardedMethods(service *protogen.Service) bool {
+	return slices.ContainsFunc(service.Methods, isForwarded)
+}
+
+// declaresReflect reports whether the service declares a method named Reflect, which replaces the Reflect method of its client.
+func declaresReflect(service *protogen.Service) bool {
+	return slices.ContainsFunc(service.Methods, func(method *protogen.Method) bool {
+		return method.GoName == "Reflect"
+	})
+}
+
+// metadataLiteral returns a map[string]string literal of the entries, later entries overwriting earlier ones.
+func metadataLiteral(entries []*gonats.MetadataEntry) string {
+	metadata := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		metadata[entry.GetKey()] = entry.GetValue()
+	}
+	var b strings.Builder
+	b.WriteString("map[string]string{")
+	for i, k := range slices.Sorted(maps.Keys(metadata)) {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Quote(k) + ": " + strconv.Quote(metadata[k]))
+	}
+	b.WriteString("}")
+	return b.String()
+}
@@ -23,6 +23,9 @@ func main() {
 
 	var (
 		flags flag.FlagSet
+		cli   = flags.Bool("cli", false, "generate a command line interface for every service")
+		gw    = flags.Bool("http", false, "generate an HTTP/JSON gateway for every service")
+		grpc  = flags.Bool("grpc", false, "generate adapters between gRPC and NATS for every service, requires protoc-gen-go-grpc")
 	)
 	protogen.Options{
 		ParamFunc: flags.Set,
@@ -37,6 +40,21 @@ func main() {
 				if err := generateFile(gen, f); err != nil {
 					return err
 				}
+				if *cli {
+					if err := generateCLIFile(gen, f); err != nil {
+						return err
+					}
+				}
+				if *gw {
+					if err := generateHTTPFile(gen, f); err != nil {
+						return err
+					}
+				}
+				if *grpc {
+					if err := generateGRPCFile(gen, f); err != nil {
+						return err
+					}
+				}
 			}
 		}
 		return nil
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"xiam.li/go-protonats/gonats"
+	"xiam.li/protonats/go/impl"
+	"xiam.li/protonats/go/protonats"
+)
+
+// callArgs are the arguments of the call command.
+type callArgs struct {
+	service      string
+	method       string
+	data         string
+	broadcast    bool
+	instances    []string
+	extraSubject string
+}
+
+// parseCall parses the arguments of the call command, the method followed by its flags.
+// The extra subject defaults to the one given as global flag.
+func parseCall(args []string, extraSubject string) (callArgs, error) {
+	if len(args) == 0 {
+		return callArgs{}, errors.New("call expects a method")
+	}
+	var a callArgs
+	var err error
+	if a.service, a.method, err = splitMethod(args[0]); err != nil {
+		return callArgs{}, err
+	}
+
+	fs := flag.NewFlagSet("call", flag.ContinueOnError)
+	fs.StringVar(&a.data, "d", "", "the request as JSON, read from stdin if omitted")
+	fs.BoolVar(&a.broadcast, "broadcast", false, "send the request to all instances of a broadcasting method")
+	fs.Var((*stringsFlag)(&a.instances), "instance", "only send the request to the instance with the given `id` (can be repeated)")
+	fs.StringVar(&a.extraSubject, "extra-subject", extraSubject, "the extra `subject` the service was registered with")
+	if err := fs.Parse(args[1:]); err != nil {
+		return callArgs{}, err
+	}
+	if fs.NArg() > 0 {
+		return callArgs{}, fmt.Errorf("unexpected arguments %v", fs.Args())
+	}
+	return a, nil
+}
+
+// call invokes a method with a JSON request and prints the JSON response(s).
+func call(nc *nats.Conn, g globalFlags, args []string) error {
+	a, err := parseCall(args, g.extraSubject)
+	if err != nil {
+		return err
+	}
+	g.extraSubject = a.extraSubject
+	methodName, broadcast, instances := a.method, a.broadcast, a.instances
+
+	service, method, err := resolveSymbol(nc, g, symbol{service: a.service, method: methodName})
+	if err != nil {
+		return err
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return fmt.Errorf("method %s is a streaming method and is currently not supported", methodName)
+	}
+	if broadcast && !isBroadcast(method) {
+		return fmt.Errorf("method %s is not a broadcasting method, use -instance to call multiple instances", methodName)
+	}
+
+	req, err := decodeRequest(method.Input(), a.data)
+	if err != nil {
+		return err
+	}
+
+	if isDurable(method) {
+		if broadcast || len(instances) > 0 {
+			return fmt.Errorf("method %s is a durable method and can't be sent to specific instances", methodName)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+		defer cancel()
+		call, err := gonats.PublishDurable[struct{}](ctx, nc, gonats.ClientConfig{}, string(service.Name()), methodName, g.extraSubject, "", req, nil)
+		if err != nil {
+			return err
+		}
+		return gonats.PrintDurableReceipt(os.Stdout, call.DurableReceipt)
+	}
+
+	subjects := callSubjects(string(service.Name()), methodName, g.extraSubject, instances)
+	if isOneWay(method) {
+		if broadcast || len(instances) > 1 {
+			return fmt.Errorf("method %s is a one-way method and can only be sent to a single instance", methodName)
+		}
+		if err := nc.Publish(subjects[0], req); err != nil {
+			return err
+		}
+		if err := nc.FlushTimeout(g.timeout); err != nil {
+			return err
+		}
+		fmt.Println("{}")
+		return nil
+	}
+	if !broadcast && len(instances) <= 1 {
+		msg, err := nc.Request(subjects[0], req, g.timeout)
+		if err != nil {
+			return err
+		}
+		if serviceErr, ok := serviceError(msg); ok {
+			return serviceErr
+		}
+		return printResponse(method.Output(), msg.Data)
+	}
+
+	replies, err := collect(nc, subjects, req, g.timeout, len(instances))
+	if err != nil {
+		return err
+	}
+	var failed int
+	for i, r := range replies {
+		if serviceErr, ok := r.serviceError(); ok {
+			failed++
+			printServiceError(os.Stderr, fmt.Sprintf("%s (%d/%d, %v)", r.instanceID(), i+1, len(replies), r.rtt), serviceErr)
+			continue
+		}
+		// The instance is written to stderr, so that stdout only contains the responses, e.g. to be piped to jq
+		fmt.Fprintf(os.Stderr, "// Instance %s (%d/%d, %v)\n", r.instanceID(), i+1, len(replies), r.rtt)
+		if err := printResponse(method.Output(), r.msg.Data); err != nil {
+			return err
+		}
+	}
+	if len(instances) > 0 && len(replies) < len(instances) {
+		return fmt.Errorf("only %d of %d instances responded", len(replies), len(instances))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d instances returned an error", failed, len(replies))
+	}
+	return nil
+}
+
+// callSubjects returns the subjects a request to the method of the service with the given Go name is sent to:
+// the subject of its shared or broadcast endpoint if no instances are given, or the direct endpoint of every instance.
+func callSubjects(service, method, extraSubject string, instances []string) []string {
+	subject := "service." + service + "." + method
+	var opts []protonats.CallOption
+	if extraSubject != "" {
+		opts = append(opts, protonats.WithExtraSubject(extraSubject))
+	}
+	if len(instances) == 0 {
+		return []string{impl.ProcessCallOptions(opts...).Subject(subject)}
+	}
+	subjects := make([]string, 0, len(instances))
+	for _, id := range instances {
+		subjects = append(subjects, impl.ProcessCallOptions(append(opts[:len(opts):len(opts)], protonats.WithInstanceID(id))...).Subject(subject))
+	}
+	return subjects
+}
+
+// decodeRequest converts the JSON request into the serialized proto message.
+// The JSON is read from stdin if data is empty and the message has any fields.
+func decodeRequest(desc protoreflect.MessageDescriptor, data string) ([]byte, error) {
+	if data == "" {
+		if desc.Fields().Len() == 0 {
+			return nil, nil
+		}
+		in, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		data = string(in)
+	}
+	msg := dynamicpb.NewMessage(desc)
+	if err := protojson.Unmarshal([]byte(data), msg); err != nil {
+		return nil, fmt.Errorf("failed to decode request as %s: %w", desc.FullName(), err)
+	}
+	return proto.Marshal(msg)
+}
+
+// printResponse prints the serialized proto message as JSON.
+func printResponse(desc protoreflect.MessageDescriptor, data []byte) error {
+	msg := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("failed to decode response as %s: %w", desc.FullName(), err)
+	}
+	out, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
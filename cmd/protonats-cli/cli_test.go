@@ -0,0 +1,195 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestSubjects(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		extraSubject string
+		instances    []string
+		want         []string
+	}{
+		{name: "Shared", want: []string{"service.Greeter.Hello"}},
+		{name: "ExtraSubject", extraSubject: "eu", want: []string{"service.Greeter.Hello.eu"}},
+		{name: "Instance", instances: []string{"a"}, want: []string{"service.Greeter.Hello.a"}},
+		{name: "Instances", instances: []string{"a", "b"}, want: []string{"service.Greeter.Hello.a", "service.Greeter.Hello.b"}},
+		{name: "InstanceExtraSubject", extraSubject: "eu", instances: []string{"a", "b"}, want: []string{"service.Greeter.Hello.a.eu", "service.Greeter.Hello.b.eu"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := callSubjects("Greeter", "Hello", tt.extraSubject, tt.instances); !slices.Equal(got, tt.want) {
+				t.Fatalf("callSubjects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReflectionSubject(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		service      string
+		extraSubject string
+		want         string
+	}{
+		{service: "Greeter", want: "service.Greeter.$REFLECT"},
+		{service: "pkg.v1.Greeter", want: "service.Greeter.$REFLECT"},
+		{service: "Greeter", extraSubject: "eu", want: "service.Greeter.$REFLECT.eu"},
+		{service: "pkg.v1.Greeter", extraSubject: "eu", want: "service.Greeter.$REFLECT.eu"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.service+"/"+tt.extraSubject, func(t *testing.T) {
+			t.Parallel()
+			if got := reflectionSubject(tt.service, tt.extraSubject); got != tt.want {
+				t.Fatalf("reflectionSubject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindSymbol(t *testing.T) {
+	t.Parallel()
+	files := testFiles(t)
+	tests := []struct {
+		name        string
+		sym         symbol
+		wantService string
+		wantMethod  string
+		wantErr     bool
+	}{
+		{name: "ShortName", sym: symbol{service: "Unique"}, wantService: "a.Unique"},
+		{name: "FullName", sym: symbol{service: "b.Greeter"}, wantService: "b.Greeter"},
+		{name: "Method", sym: symbol{service: "a.Greeter", method: "Hello"}, wantService: "a.Greeter", wantMethod: "a.Greeter.Hello"},
+		{name: "Ambiguous", sym: symbol{service: "Greeter"}, wantErr: true},
+		{name: "MissingService", sym: symbol{service: "Missing"}, wantErr: true},
+		{name: "MissingMethod", sym: symbol{service: "Unique", method: "Missing"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			service, method, err := findSymbol(files, tt.sym)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("findSymbol() found %v, want error", service.FullName())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(service.FullName()) != tt.wantService {
+				t.Fatalf("service = %s, want %s", service.FullName(), tt.wantService)
+			}
+			if tt.wantMethod == "" {
+				if method != nil {
+					t.Fatalf("method = %s, want none", method.FullName())
+				}
+			} else if method == nil || string(method.FullName()) != tt.wantMethod {
+				t.Fatalf("method = %v, want %s", method, tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestDescribeSymbols(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		arg  string
+		want []symbol
+	}{
+		{arg: "Greeter", want: []symbol{{service: "Greeter"}}},
+		{arg: "Greeter.Hello", want: []symbol{{service: "Greeter", method: "Hello"}, {service: "Greeter.Hello"}}},
+		{arg: "pkg.Greeter.Hello", want: []symbol{{service: "pkg.Greeter", method: "Hello"}, {service: "pkg.Greeter.Hello"}}},
+		{arg: "Greeter.", want: []symbol{{service: "Greeter."}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			t.Parallel()
+			if got := describeSymbols(tt.arg); !slices.Equal(got, tt.want) {
+				t.Fatalf("describeSymbols() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCall(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		global  string
+		want    callArgs
+		wantErr bool
+	}{
+		{name: "Method", args: []string{"Greeter.Hello"}, want: callArgs{service: "Greeter", method: "Hello"}},
+		{name: "FullName", args: []string{"pkg.Greeter.Hello", "-d", "{}"}, want: callArgs{service: "pkg.Greeter", method: "Hello", data: "{}"}},
+		{name: "Broadcast", args: []string{"Greeter.Hello", "-broadcast"}, want: callArgs{service: "Greeter", method: "Hello", broadcast: true}},
+		{name: "Instances", args: []string{"Greeter.Hello", "-instance", "a", "-instance", "b"}, want: callArgs{service: "Greeter", method: "Hello", instances: []string{"a", "b"}}},
+		{name: "GlobalExtraSubject", args: []string{"Greeter.Hello"}, global: "eu", want: callArgs{service: "Greeter", method: "Hello", extraSubject: "eu"}},
+		{name: "ExtraSubject", args: []string{"Greeter.Hello", "-extra-subject", "us"}, global: "eu", want: callArgs{service: "Greeter", method: "Hello", extraSubject: "us"}},
+		{name: "NoMethod", wantErr: true},
+		{name: "InvalidMethod", args: []string{"Greeter"}, wantErr: true},
+		{name: "UnknownFlag", args: []string{"Greeter.Hello", "-unknown"}, wantErr: true},
+		{name: "ExtraArgument", args: []string{"Greeter.Hello", "-d", "{}", "extra"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseCall(tt.args, tt.global)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseCall() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.service != tt.want.service || got.method != tt.want.method || got.data != tt.want.data ||
+				got.broadcast != tt.want.broadcast || got.extraSubject != tt.want.extraSubject || !slices.Equal(got.instances, tt.want.instances) {
+				t.Fatalf("parseCall() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testFiles returns files with the services a.Greeter, a.Unique and b.Greeter, all with a method Hello.
+func testFiles(t *testing.T) *protoregistry.Files {
+	t.Helper()
+	file := func(pkg string, services ...string) *descriptorpb.FileDescriptorProto {
+		fd := &descriptorpb.FileDescriptorProto{
+			Name:        proto.String(pkg + ".proto"),
+			Package:     proto.String(pkg),
+			Syntax:      proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Message")}},
+		}
+		for _, service := range services {
+			fd.Service = append(fd.Service, &descriptorpb.ServiceDescriptorProto{
+				Name: proto.String(service),
+				Method: []*descriptorpb.MethodDescriptorProto{{
+					Name:       proto.String("Hello"),
+					InputType:  proto.String("." + pkg + ".Message"),
+					OutputType: proto.String("." + pkg + ".Message"),
+				}},
+			})
+		}
+		return fd
+	}
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		file("a", "Greeter", "Unique"),
+		file("b", "Greeter"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
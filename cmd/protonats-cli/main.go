@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"xiam.li/protonats/go/protonats"
+)
+
+const usage = `Usage: %[1]s [flags] <command> [args]
+
+Commands:
+  list [service]                 list all instances of all or a specific service
+  describe <service>[.<method>]  describe a service or method
+  call <service>.<method>        call a method with a JSON request
+
+Examples:
+  %[1]s list
+  %[1]s describe HelloWorldService
+  %[1]s call HelloWorldService.HelloWorld -d '{"name": "John Doe"}'
+  %[1]s call HelloWorldService.HelloWorld -broadcast -d '{"name": "John Doe"}'
+  %[1]s call HelloWorldService.HelloWorld -instance <id> -instance <id> < request.json
+
+Flags:
+`
+
+type globalFlags struct {
+	server       string
+	creds        string
+	timeout      time.Duration
+	descriptors  stringsFlag
+	extraSubject string
+}
+
+// stringsFlag is a flag that can be passed multiple times.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string { return strings.Join(*s, ",") }
+
+func (s *stringsFlag) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func main() {
+	var g globalFlags
+	var showVersion bool
+	flag.StringVar(&g.server, "server", nats.DefaultURL, "the NATS server `url` to connect to")
+	flag.StringVar(&g.creds, "creds", "", "the NATS credentials `file` to use")
+	flag.DurationVar(&g.timeout, "timeout", 5*time.Second, "the timeout of requests")
+	flag.StringVar(&g.extraSubject, "extra-subject", "", "the extra `subject` the services were registered with")
+	flag.Var(&g.descriptors, "descriptor-set", "a FileDescriptorSet `file` created with protoc --descriptor_set_out --include_imports, used instead of reflection (can be repeated)")
+	flag.BoolVar(&showVersion, "version", false, "print the version and exit")
+	flag.BoolVar(&showVersion, "v", false, "print the version and exit")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), usage, filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if showVersion {
+		version := "(unknown)"
+		if info, ok := debug.ReadBuildInfo(); ok {
+			version = info.Main.Version
+		}
+		fmt.Printf("%s %s\n", filepath.Base(os.Args[0]), version)
+		return
+	}
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(g, flag.Arg(0), flag.Args()[1:]); err != nil {
+		if serviceErr, ok := protonats.AsServiceError(err); ok {
+			printServiceError(os.Stderr, "", serviceErr)
+		} else {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(g globalFlags, command string, args []string) error {
+	var opts []nats.Option
+	if g.creds != "" {
+		opts = append(opts, nats.UserCredentials(g.creds))
+	}
+	nc, err := nats.Connect(g.server, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	defer nc.Close()
+
+	switch command {
+	case "list":
+		return list(nc, g, args)
+	case "describe":
+		return describe(nc, g, args)
+	case "call":
+		return call(nc, g, args)
+	default:
+		return errors.New("unknown command " + command)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"xiam.li/go-protonats/gonats"
+	"xiam.li/protonats/go/protonats"
+)
+
+// finisherDelay is the time to wait for further responses after the last one, the same the generated clients use.
+const finisherDelay = 250 * time.Millisecond
+
+// reply is a single response to a request sent to multiple instances.
+type reply struct {
+	msg *nats.Msg
+	rtt time.Duration
+}
+
+// instanceID returns the ID of the instance that sent the reply.
+func (r reply) instanceID() string {
+	return r.msg.Header.Get(gonats.InstanceHeader)
+}
+
+// serviceError returns the ServiceError contained in the reply, if any.
+func (r reply) serviceError() (protonats.ServiceError, bool) {
+	return serviceError(r.msg)
+}
+
+func serviceError(msg *nats.Msg) (protonats.ServiceError, bool) {
+	errMsg, errCode := msg.Header.Get(micro.ErrorHeader), msg.Header.Get(micro.ErrorCodeHeader)
+	if len(errMsg) == 0 || len(errCode) == 0 {
+		return protonats.ServiceError{}, false
+	}
+	return protonats.ServiceError{Code: errCode, Description: errMsg, Details: string(msg.Data)}, true
+}
+
+// collect sends data to all subjects and collects the replies until the timeout is hit,
+// no reply arrived for finisherDelay or, if expected is greater than zero, expected replies arrived.
+func collect(nc *nats.Conn, subjects []string, data []byte, timeout time.Duration, expected int) ([]reply, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		replies []reply
+		start   time.Time
+	)
+	finisher := time.AfterFunc(timeout, cancel)
+	defer finisher.Stop()
+	sub, err := nc.Subscribe(nc.NewRespInbox(), func(msg *nats.Msg) {
+		mu.Lock()
+		defer mu.Unlock()
+		if msg.Header.Get("Status") == "503" {
+			return
+		}
+		replies = append(replies, reply{msg: msg, rtt: time.Since(start)})
+		finisher.Reset(finisherDelay)
+		if expected > 0 && len(replies) == expected {
+			cancel()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	mu.Lock()
+	start = time.Now()
+	mu.Unlock()
+	for _, subject := range subjects {
+		if err := nc.PublishRequest(subject, sub.Subject, data); err != nil {
+			return nil, err
+		}
+	}
+	<-ctx.Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	return replies, nil
+}
+
+// list prints all instances of all or a specific service, discovered via $SRV.PING.
+func list(nc *nats.Conn, g globalFlags, args []string) error {
+	subject := fmt.Sprintf("%s.%s", micro.APIPrefix, micro.PingVerb)
+	if len(args) > 0 {
+		subject += "." + args[0]
+	}
+	replies, err := collect(nc, []string{subject}, nil, g.timeout, 0)
+	if err != nil {
+		return err
+	}
+	pings := make([]micro.Ping, 0, len(replies))
+	for _, r := range replies {
+		var ping micro.Ping
+		if err := json.Unmarshal(r.msg.Data, &ping); err != nil {
+			return fmt.Errorf("failed to decode ping response: %w", err)
+		}
+		pings = append(pings, ping)
+	}
+	sort.Slice(pings, func(i, j int) bool {
+		if pings[i].Name != pings[j].Name {
+			return pings[i].Name < pings[j].Name
+		}
+		return pings[i].ID < pings[j].ID
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SERVICE\tID\tVERSION\tMETADATA")
+	for _, ping := range pings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ping.Name, ping.ID, ping.Version, formatMetadata(ping.Metadata))
+	}
+	return w.Flush()
+}
+
+// formatMetadata formats metadata as a sorted list of key=value pairs.
+func formatMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0, len(metadata))
+	for k, v := range metadata {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// printServiceError prints a ServiceError in a readable format, prefixed with the instance if it is known.
+func printServiceError(w io.Writer, instance string, err protonats.ServiceError) {
+	if instance != "" {
+		fmt.Fprintf(w, "Instance %s returned an error\n", instance)
+	}
+	fmt.Fprintf(w, "  Code:        %s\n", err.Code)
+	fmt.Fprintf(w, "  Description: %s\n", err.Description)
+	if err.Details != "" {
+		fmt.Fprintf(w, "  Details:     %s\n", err.Details)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"xiam.li/go-protonats/gonats"
+	"xiam.li/protonats/go/protonats"
+)
+
+// shortName returns the last element of a possibly fully qualified name.
+func shortName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// splitMethod splits service.method into the service and method name.
+func splitMethod(name string) (string, string, error) {
+	i := strings.LastIndex(name, ".")
+	if i <= 0 || i == len(name)-1 {
+		return "", "", fmt.Errorf("invalid method %q, expected <service>.<method>", name)
+	}
+	return name[:i], name[i+1:], nil
+}
+
+// symbol is a service, or a method of it if method is set, named on the command line.
+type symbol struct {
+	service string
+	method  string
+}
+
+// describeSymbols returns the possible meanings of the argument of describe, in the order they are tried:
+// a method of the service named by everything before the last dot, followed by a service named by the whole argument.
+func describeSymbols(arg string) []symbol {
+	var symbols []symbol
+	if service, method, err := splitMethod(arg); err == nil {
+		symbols = append(symbols, symbol{service: service, method: method})
+	}
+	return append(symbols, symbol{service: arg})
+}
+
+// reflectionSubject returns the subject of the reflection endpoint of the service with the given short or fully qualified name.
+func reflectionSubject(service, extraSubject string) string {
+	subject := gonats.ReflectionSubject(shortName(service))
+	if extraSubject != "" {
+		subject += "." + extraSubject
+	}
+	return subject
+}
+
+// loadFiles loads the descriptors of the service from the descriptor sets passed via flags
+// or, if there are none, from the reflection endpoint of the service.
+func loadFiles(nc *nats.Conn, g globalFlags, service string) (*protoregistry.Files, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	if len(g.descriptors) > 0 {
+		for _, path := range g.descriptors {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			var s descriptorpb.FileDescriptorSet
+			if err := proto.Unmarshal(data, &s); err != nil {
+				return nil, fmt.Errorf("failed to decode descriptor set %s: %w", path, err)
+			}
+			set.File = append(set.File, s.File...)
+		}
+	} else {
+		msg, err := nc.Request(reflectionSubject(service, g.extraSubject), nil, g.timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reflect service %s: %w", service, err)
+		}
+		if serviceErr, ok := serviceError(msg); ok {
+			return nil, serviceErr
+		}
+		if err := proto.Unmarshal(msg.Data, set); err != nil {
+			return nil, fmt.Errorf("failed to decode reflection response: %w", err)
+		}
+	}
+	// Deduplicate files contained in multiple descriptor sets
+	seen := make(map[string]struct{}, len(set.File))
+	files := set.File[:0]
+	for _, file := range set.File {
+		if _, ok := seen[file.GetName()]; !ok {
+			seen[file.GetName()] = struct{}{}
+			files = append(files, file)
+		}
+	}
+	set.File = files
+	return protodesc.NewFiles(set)
+}
+
+// findService returns the service with the given short or fully qualified name.
+func findService(files *protoregistry.Files, name string) (protoreflect.ServiceDescriptor, error) {
+	var found []protoreflect.ServiceDescriptor
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		services := file.Services()
+		for i := range services.Len() {
+			service := services.Get(i)
+			if string(service.FullName()) == name || string(service.Name()) == name {
+				found = append(found, service)
+			}
+		}
+		return true
+	})
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("service %s not found", name)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("service name %s is ambiguous, use the fully qualified name", name)
+	}
+}
+
+// findSymbol returns the service of sym and, if sym names a method, the method.
+func findSymbol(files *protoregistry.Files, sym symbol) (protoreflect.ServiceDescriptor, protoreflect.MethodDescriptor, error) {
+	service, err := findService(files, sym.service)
+	if err != nil || sym.method == "" {
+		return service, nil, err
+	}
+	method := service.Methods().ByName(protoreflect.Name(sym.method))
+	if method == nil {
+		return nil, nil, fmt.Errorf("method %s not found in service %s", sym.method, service.FullName())
+	}
+	return service, method, nil
+}
+
+// resolveSymbol loads the descriptors of the service of sym and returns the service and, if sym names a method, the method.
+func resolveSymbol(nc *nats.Conn, g globalFlags, sym symbol) (protoreflect.ServiceDescriptor, protoreflect.MethodDescriptor, error) {
+	files, err := loadFiles(nc, g, sym.service)
+	if err != nil {
+		return nil, nil, err
+	}
+	return findSymbol(files, sym)
+}
+
+// isBroadcast reports whether the method uses the protonats.broadcast option.
+func isBroadcast(method protoreflect.MethodDescriptor) bool {
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	return ok && opts != nil && proto.GetExtension(opts, protonats.E_Broadcast).(bool)
+}
+
+// isDurable reports whether the method uses the protonats.durable option.
+func isDurable(method protoreflect.MethodDescriptor) bool {
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	return ok && opts != nil && proto.GetExtension(opts, gonats.E_Durable).(bool)
+}
+
+// isOneWay reports whether the method uses the protonats.one_way option.
+func isOneWay(method protoreflect.MethodDescriptor) bool {
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	return ok && opts != nil && proto.GetExtension(opts, gonats.E_OneWay).(bool)
+}
+
+// consensusTarget returns the consensus target of the method, or an empty string if it isn't set.
+func consensusTarget(method protoreflect.MethodDescriptor) string {
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	if !ok || opts == nil || !proto.HasExtension(opts, protonats.E_ConsensusTarget) {
+		return ""
+	}
+	return proto.GetExtension(opts, protonats.E_ConsensusTarget).(protonats.ConsensusTarget).String()
+}
+
+// describe prints a service or a method with its request and response messages.
+func describe(nc *nats.Conn, g globalFlags, args []string) error {
+	if len(args) != 1 {
+		return errors.New("describe expects exactly one service or method")
+	}
+	var firstErr error
+	for _, sym := range describeSymbols(args[0]) {
+		service, method, err := resolveSymbol(nc, g, sym)
+		if err != nil {
+			// Report why the first, most specific meaning failed
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if method == nil {
+			printService(service)
+			return nil
+		}
+		fmt.Println(methodSignature(method))
+		fmt.Println()
+		printMessage(method.Input())
+		if method.Output() != method.Input() {
+			fmt.Println()
+			printMessage(method.Output())
+		}
+		return nil
+	}
+	return firstErr
+}
+
+// methodSignature returns the rpc definition of the method, including the protonats options.
+func methodSignature(method protoreflect.MethodDescriptor) string {
+	var opts []string
+	if isBroadcast(method) {
+		opts = append(opts, "broadcast")
+	}
+	if target := consensusTarget(method); target != "" {
+		opts = append(opts, "consensus_target="+target)
+	}
+	if isDurable(method) {
+		opts = append(opts, "durable")
+	}
+	if isOneWay(method) {
+		opts = append(opts, "one_way")
+	}
+	signature := fmt.Sprintf("rpc %s(%s) returns (%s);", method.Name(), method.Input().FullName(), method.Output().FullName())
+	if len(opts) > 0 {
+		signature += " // " + strings.Join(opts, ", ")
+	}
+	return signature
+}
+
+func printService(service protoreflect.ServiceDescriptor) {
+	fmt.Printf("service %s {\n", service.FullName())
+	methods := service.Methods()
+	for i := range methods.Len() {
+		method := methods.Get(i)
+		if method.IsStreamingClient() || method.IsStreamingServer() {
+			fmt.Printf("  // %s is a streaming method and is currently not supported\n", method.Name())
+			continue
+		}
+		fmt.Printf("  %s\n", methodSignature(method))
+	}
+	fmt.Println("}")
+}
+
+func printMessage(message protoreflect.MessageDescriptor) {
+	fmt.Printf("message %s {\n", message.FullName())
+	fields := message.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		fmt.Printf("  %s%s %s = %d;\n", fieldLabel(field), fieldType(field), field.Name(), field.Number())
+	}
+	fmt.Println("}")
+}
+
+func fieldLabel(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap():
+		return ""
+	case field.IsList():
+		return "repeated "
+	case field.HasOptionalKeyword():
+		return "optional "
+	default:
+		return ""
+	}
+}
+
+func fieldType(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", fieldType(field.MapKey()), fieldType(field.MapValue()))
+	}
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(field.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(field.Enum().FullName())
+	default:
+		return field.Kind().String()
+	}
+}
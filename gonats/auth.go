@@ -0,0 +1,195 @@
+package gonats
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/nats-io/nkeys"
+)
+
+const (
+	// ForbiddenCode is the code of the error returned for requests rejected by the Authorizer.
+	ForbiddenCode = "403"
+	// JWTHeader contains the user JWT of the connection of the caller, set by generated clients if the connection uses one.
+	JWTHeader = "Protonats-Jwt"
+	// RequestInfoHeader contains the client info the NATS server adds to requests crossing accounts through a service import that shares it.
+	RequestInfoHeader = "Nats-Request-Info"
+)
+
+// CallerInfo is the client info the NATS server adds in the RequestInfoHeader.
+type CallerInfo struct {
+	Account   string   `json:"acc,omitempty"`
+	User      string   `json:"user,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	NameTag   string   `json:"name_tag,omitempty"`
+	IssuerKey string   `json:"issuer_key,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Server    string   `json:"server,omitempty"`
+}
+
+// Caller is the identity of the caller of a request.
+type Caller struct {
+	// Info is the client info in the RequestInfoHeader, nil if the request doesn't have one.
+	// The NATS server sets it for requests crossing accounts through a sharing service import,
+	// but any client can send it on other requests, so it must not be used for authorization.
+	Info *CallerInfo
+	// Claims are the claims of the JWT in the JWTHeader, nil if the caller didn't send one,
+	// or if it is invalid, expired or not issued by an issuer set with WithTrustedIssuers.
+	// They can be replayed by anyone who has seen the JWT, unless the request is signed with its key, see Authenticated.
+	Claims *jwt.UserClaims
+	// Signer is the public key of the NKey that signed the request, empty if it isn't signed or the signature is invalid.
+	Signer string
+}
+
+// Authenticated reports whether the caller holds the key of its Claims, i.e. the request is signed with the key the JWT was issued to.
+func (c Caller) Authenticated() bool {
+	return c.Claims != nil && c.Signer != "" && c.Signer == c.Claims.Subject
+}
+
+// Tags returns the tags of the Claims of the caller, if it is Authenticated.
+func (c Caller) Tags() []string {
+	if !c.Authenticated() {
+		return nil
+	}
+	return c.Claims.Tags
+}
+
+// Authorization is passed to the Authorizer for every request.
+type Authorization struct {
+	// Method is the name of the called method.
+	Method string
+	// Roles are the roles required by the required_roles option of the method.
+	Roles []string
+	// Caller is the identity of the caller.
+	Caller Caller
+	// Request is the request to authorize. With WithEncryption, its data is already decrypted.
+	Request micro.Request
+}
+
+// Authorizer decides whether a request may call a method, by returning nil, or rejects it with ForbiddenCode and the error as description.
+type Authorizer func(Authorization) error
+
+// WithAuthorizer sets the Authorizer called for every request before it is passed to the implementation.
+// Without an Authorizer, the required_roles option of methods is not enforced.
+func WithAuthorizer(authorizer Authorizer) ServerOption {
+	return configure(func(cfg *ServerConfig) {
+		cfg.Authorizer = authorizer
+	})
+}
+
+// WithTrustedIssuers sets the public keys of the accounts, or their signing keys, whose user JWTs are passed to the Authorizer as Caller.Claims.
+// JWTs issued with a signing key are only trusted if both the signing key and its account are trusted.
+// Without trusted issuers, the JWTHeader of requests is ignored.
+func WithTrustedIssuers(keys ...string) ServerOption {
+	return configure(func(cfg *ServerConfig) {
+		cfg.TrustedIssuers = append(cfg.TrustedIssuers, keys...)
+	})
+}
+
+// TagAuthorizer is an Authorizer requiring the caller to have all roles of the method as tags in its Claims.
+// As only the Tags of an authenticated caller count, the request must carry a JWT of a trusted issuer and be signed with its key.
+func TagAuthorizer(a Authorization) error {
+	tags := a.Caller.Tags()
+	for _, role := range a.Roles {
+		if !slices.Contains(tags, role) {
+			return fmt.Errorf("missing role %s", role)
+		}
+	}
+	return nil
+}
+
+// CallerOf returns the identity of the caller of request.
+// The claims of its JWT are only returned if they are valid and issued by one of trustedIssuers, invalid request info is ignored.
+func CallerOf(request micro.Request, trustedIssuers ...string) Caller {
+	var caller Caller
+	headers := request.Headers()
+	if data := headers.Get(RequestInfoHeader); data != "" {
+		var info CallerInfo
+		if err := json.Unmarshal([]byte(data), &info); err == nil {
+			caller.Info = &info
+		}
+	}
+	if token := headers.Get(JWTHeader); token != "" {
+		caller.Claims = trustedClaims(token, trustedIssuers)
+	}
+	if signer, err := VerifyRequest(request.Subject(), nats.Header(headers), signedPayload(request)); err == nil {
+		caller.Signer = signer
+	}
+	return caller
+}
+
+// trustedClaims returns the claims of the user JWT token, or nil if it is invalid, expired or not issued by one of trusted.
+// The signature of the JWT only proves that it was issued by its issuer, so the issuer must be trusted as well.
+func trustedClaims(token string, trusted []string) *jwt.UserClaims {
+	if len(trusted) == 0 {
+		return nil
+	}
+	claims, err := jwt.DecodeUserClaims(token)
+	if err != nil {
+		return nil
+	}
+	if !slices.Contains(trusted, claims.Issuer) || claims.IssuerAccount != "" && !slices.Contains(trusted, claims.IssuerAccount) {
+		return nil
+	}
+	vr := jwt.CreateValidationResults()
+	claims.Validate(vr)
+	if vr.IsBlocking(true) {
+		return nil
+	}
+	return claims
+}
+
+// authorized returns handler only calling the next handler if authorizer allows the request, or handler if there is no authorizer.
+// The Caller passed to authorizer only contains the claims of JWTs issued by one of trustedIssuers.
+func authorized(authorizer Authorizer, trustedIssuers []string, method string, roles []string, handler micro.Handler, opts ...micro.RespondOpt) micro.Handler {
+	if authorizer == nil {
+		return handler
+	}
+	return micro.HandlerFunc(func(request micro.Request) {
+		err := authorizer(Authorization{Method: method, Roles: roles, Caller: CallerOf(request, trustedIssuers...), Request: request})
+		if err != nil {
+			_ = request.Error(ForbiddenCode, "Forbidden: "+err.Error(), nil, opts...)
+			return
+		}
+		handler.Handle(request)
+	})
+}
+
+// ClientConfig contains the settings of a generated client applied to every request it sends.
+type ClientConfig struct {
+	// Signer is the NKey requests are signed with, nil to send them unsigned.
+	Signer nkeys.KeyPair
+	// XKey is the public xkey of the service requests are encrypted to, empty to send them unencrypted.
+	XKey string
+}
+
+// Unencrypted returns c without the XKey, for requests to the endpoints of the micro framework, which can't decrypt them.
+func (c ClientConfig) Unencrypted() ClientConfig {
+	c.XKey = ""
+	return c
+}
+
+// NewRequest returns the request generated clients send on nc.
+// It contains the user JWT of the connection in the JWTHeader, if it uses one, is encrypted with sealer, unless it is nil,
+// and signed with signer, unless it is nil.
+func NewRequest(nc *nats.Conn, subject string, data []byte, signer nkeys.KeyPair, sealer *Sealer) (*nats.Msg, error) {
+	msg := &nats.Msg{Subject: subject, Data: data}
+	if nc.Opts.UserJWT != nil {
+		if token, err := nc.Opts.UserJWT(); err == nil && token != "" {
+			msg.Header = nats.Header{JWTHeader: []string{token}}
+		}
+	}
+	if err := sealer.Seal(msg); err != nil {
+		return nil, err
+	}
+	if signer != nil {
+		if err := SignRequest(msg, signer); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
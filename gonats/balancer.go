@@ -0,0 +1,203 @@
+package gonats
+
+import (
+	"cmp"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"xiam.li/protonats/go/protonats"
+)
+
+// InstanceRefresh is the interval in which a generated client with a Balancer discovers the instances of the service again.
+var InstanceRefresh = 10 * time.Second
+
+// Instance is an instance of a service a Balancer picks from.
+type Instance struct {
+	// ID is the instance ID.
+	ID string
+	// RTT is the round trip time of the ping the instance was discovered with.
+	RTT time.Duration
+}
+
+// PingInstances returns the instances that responded with pings.
+func PingInstances(pings []*protonats.Ping) []Instance {
+	instances := make([]Instance, 0, len(pings))
+	for _, ping := range pings {
+		instances = append(instances, Instance{ID: ping.ID, RTT: ping.RTT})
+	}
+	return instances
+}
+
+// ServingInstances returns the instances among the responses to $SRV.INFO that have an endpoint on subject,
+// so that instances serving the service with another extra subject are not picked.
+func ServingInstances(results []BroadcastResult[*micro.Info], subject string) []Instance {
+	var instances []Instance
+	for _, result := range results {
+		if result.Err != nil || result.Response == nil {
+			continue
+		}
+		if slices.ContainsFunc(result.Response.Endpoints, func(endpoint micro.EndpointInfo) bool { return endpoint.Subject == subject }) {
+			instances = append(instances, Instance{ID: result.Response.ID, RTT: result.RTT})
+		}
+	}
+	return instances
+}
+
+// Balancer picks the instance a call of a generated client is sent to, through the direct endpoint of the method,
+// instead of leaving it to the queue group of the service, which picks a random instance.
+// Set it with the SetBalancer method of the client. Implementations must be safe for concurrent use.
+type Balancer interface {
+	// Pick returns the ID of one of instances, which is never empty.
+	Pick(instances []Instance) string
+	// Done is called with the result of every call to an instance returned by Pick.
+	Done(id string, err error)
+}
+
+// LeastLatency returns a Balancer picking the instance with the lowest RTT, measured whenever the instances are discovered.
+func LeastLatency() Balancer {
+	return leastLatency{}
+}
+
+type leastLatency struct{}
+
+func (leastLatency) Pick(instances []Instance) string {
+	return slices.MinFunc(instances, func(a, b Instance) int {
+		return cmp.Compare(a.RTT, b.RTT)
+	}).ID
+}
+
+func (leastLatency) Done(string, error) {}
+
+// RoundRobin returns a Balancer picking the instances in turn, ordered by their ID.
+func RoundRobin() Balancer {
+	return new(roundRobin)
+}
+
+type roundRobin struct {
+	next atomic.Uint64
+}
+
+func (b *roundRobin) Pick(instances []Instance) string {
+	return instances[(b.next.Add(1)-1)%uint64(len(instances))].ID
+}
+
+func (b *roundRobin) Done(string, error) {}
+
+// PowerOfTwoChoices returns a Balancer picking the instance with fewer calls in flight of two random instances,
+// counting the calls of the client it is set on.
+func PowerOfTwoChoices() Balancer {
+	return &powerOfTwo{inFlight: make(map[string]int)}
+}
+
+type powerOfTwo struct {
+	mu       sync.Mutex
+	inFlight map[string]int
+}
+
+func (b *powerOfTwo) Pick(instances []Instance) string {
+	first := instances[rand.IntN(len(instances))].ID
+	second := first
+	if len(instances) > 1 {
+		i := rand.IntN(len(instances) - 1)
+		if instances[i].ID == first {
+			i = len(instances) - 1
+		}
+		second = instances[i].ID
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := first
+	if b.inFlight[second] < b.inFlight[first] {
+		id = second
+	}
+	b.inFlight[id]++
+	return id
+}
+
+func (b *powerOfTwo) Done(id string, _ error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.inFlight[id] <= 1 {
+		delete(b.inFlight, id)
+	} else {
+		b.inFlight[id]--
+	}
+}
+
+// Balancing holds the Balancer of a generated client and caches the instances of the service it picks from,
+// separately for every subject, as instances with different extra subjects serve different subjects.
+type Balancing struct {
+	mu        sync.Mutex
+	balancer  Balancer
+	instances map[string]discoveredInstances
+}
+
+type discoveredInstances struct {
+	instances []Instance
+	at        time.Time
+}
+
+// Set replaces the Balancer, or removes it if balancer is nil, so that calls are sent to the queue group again.
+func (b *Balancing) Set(balancer Balancer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.balancer = balancer
+	b.instances = nil
+}
+
+// Pick returns the instance picked by the Balancer for a call to the shared endpoint subject, discovering the instances
+// serving subject with discover if none are cached or they are older than InstanceRefresh.
+// It returns an empty ID, sending the call to the queue group, if no Balancer is set or no instances were discovered.
+func (b *Balancing) Pick(subject string, discover func(subject string) ([]Instance, error)) string {
+	b.mu.Lock()
+	balancer, cached := b.balancer, b.instances[subject]
+	if balancer == nil {
+		b.mu.Unlock()
+		return ""
+	}
+	instances := cached.instances
+	stale := len(instances) == 0 || time.Since(cached.at) > InstanceRefresh
+	b.mu.Unlock()
+	if stale {
+		// The error is ignored, as the instances that did respond can still be picked
+		instances, _ = discover(subject)
+		slices.SortFunc(instances, func(a, b Instance) int {
+			return strings.Compare(a.ID, b.ID)
+		})
+		b.mu.Lock()
+		if b.instances == nil {
+			b.instances = make(map[string]discoveredInstances)
+		}
+		b.instances[subject] = discoveredInstances{instances: instances, at: time.Now()}
+		b.mu.Unlock()
+	}
+	if len(instances) == 0 {
+		return ""
+	}
+	return balancer.Pick(instances)
+}
+
+// Done passes the result of a call to the instance id to the Balancer, and forgets the cached instances
+// if err shows that the instance is gone, so that they are discovered again for the next call.
+func (b *Balancing) Done(id string, err error) {
+	if id == "" {
+		return
+	}
+	b.mu.Lock()
+	balancer := b.balancer
+	var serviceErr protonats.ServiceError
+	if errors.Is(err, nats.ErrNoResponders) || errors.As(err, &serviceErr) && serviceErr.Code == ShuttingDownCode {
+		b.instances = nil
+	}
+	b.mu.Unlock()
+	if balancer != nil {
+		balancer.Done(id, err)
+	}
+}
@@ -0,0 +1,65 @@
+package gonats
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+)
+
+// InstanceHeader is set by generated handlers on every response and contains the ID of the responding instance.
+const InstanceHeader = "Protonats-Instance"
+
+// BroadcastResult is a single response to a broadcast call.
+// Either Response or Err is set, depending on whether the instance returned an error.
+type BroadcastResult[T any] struct {
+	// InstanceID is the ID of the instance that sent this response.
+	// It is empty if the instance does not set the InstanceHeader.
+	InstanceID string
+	// RTT is the time between sending the request and receiving this response.
+	RTT time.Duration
+	// Response is the response of the instance, if it didn't return an error.
+	Response T
+	// Err is the protonats.ServiceError returned by the instance, if any.
+	Err error
+}
+
+// BroadcastAck is the result of a broadcast call to a method that returns google.protobuf.Empty.
+type BroadcastAck = BroadcastResult[struct{}]
+
+// Responses returns the responses of all results that did not fail.
+func Responses[T any](results []BroadcastResult[T]) []T {
+	responses := make([]T, 0, len(results))
+	for _, result := range results {
+		if result.Err == nil {
+			responses = append(responses, result.Response)
+		}
+	}
+	return responses
+}
+
+// Errors returns the errors of all results that failed.
+func Errors[T any](results []BroadcastResult[T]) []error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errs
+}
+
+// WithInstanceHeader adds the InstanceHeader with the given id to a response.
+func WithInstanceHeader(id string) micro.RespondOpt {
+	return withHeader(InstanceHeader, id)
+}
+
+// withHeader sets the header key to value on a response, without sharing the header map like micro.WithHeaders.
+func withHeader(key, value string) micro.RespondOpt {
+	return func(m *nats.Msg) {
+		if m.Header == nil {
+			m.Header = nats.Header{}
+		}
+		m.Header.Set(key, value)
+	}
+}
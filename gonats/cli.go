@@ -0,0 +1,172 @@
+package gonats
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"xiam.li/protonats/go/protonats"
+)
+
+// CallFlags are the flags shared by all commands of a generated CLI.
+type CallFlags struct {
+	Instances    []string
+	Timeout      time.Duration
+	ExtraSubject string
+}
+
+// NewCallFlags registers the -instance, -timeout and -extra-subject flags on fs.
+func NewCallFlags(fs *flag.FlagSet) *CallFlags {
+	f := &CallFlags{}
+	fs.Func("instance", "only call the instance with the given `id` (can be repeated for broadcasting methods)", func(id string) error {
+		f.Instances = append(f.Instances, id)
+		return nil
+	})
+	fs.DurationVar(&f.Timeout, "timeout", 0, "the timeout of the call")
+	fs.StringVar(&f.ExtraSubject, "extra-subject", "", "the extra `subject` the service was registered with")
+	return f
+}
+
+// Options returns the call options selected by the flags.
+// A single instance is selected with protonats.WithInstanceID, multiple instances are only supported by Target.
+func (f *CallFlags) Options() []protonats.CallOption {
+	var opts []protonats.CallOption
+	if f.Timeout > 0 {
+		opts = append(opts, protonats.WithTimeout(f.Timeout))
+	}
+	if f.ExtraSubject != "" {
+		opts = append(opts, protonats.WithExtraSubject(f.ExtraSubject))
+	}
+	if len(f.Instances) == 1 {
+		opts = append(opts, protonats.WithInstanceID(f.Instances[0]))
+	}
+	return opts
+}
+
+// Target returns the instances selected by the flags for a targeted broadcast, if any are selected.
+func (f *CallFlags) Target() (Target, bool) {
+	return Instances(f.Instances...), len(f.Instances) > 0
+}
+
+// FieldFlagPrefix prefixes the names of the flags registered by RequestFlags for the fields of the request,
+// so that they can't collide with the flags registered by NewCallFlags or the -json flag.
+const FieldFlagPrefix = "f."
+
+// RequestFlags registers a -json flag and a flag for every scalar field of msg on fs, named like the proto field prefixed with FieldFlagPrefix.
+// The returned function has to be called after fs was parsed and fills msg, with the field flags taking precedence over the JSON.
+func RequestFlags(fs *flag.FlagSet, msg proto.Message) func() error {
+	var data string
+	fs.StringVar(&data, "json", "", "the request as `JSON`")
+
+	m := msg.ProtoReflect()
+	values := make(map[protoreflect.FieldDescriptor]string)
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if field.IsList() || field.IsMap() || field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+			continue
+		}
+		fs.Func(FieldFlagPrefix+string(field.Name()), fmt.Sprintf("sets the %s field (%s)", field.Name(), field.Kind()), func(v string) error {
+			values[field] = v
+			return nil
+		})
+	}
+
+	return func() error {
+		if data != "" {
+			if err := protojson.Unmarshal([]byte(data), msg); err != nil {
+				return fmt.Errorf("failed to decode request: %w", err)
+			}
+		}
+		for field, v := range values {
+			value, err := parseScalar(field, v)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s: %w", field.Name(), err)
+			}
+			m.Set(field, value)
+		}
+		return nil
+	}
+}
+
+// parseScalar parses the string representation of a value of a scalar field.
+func parseScalar(field protoreflect.FieldDescriptor, v string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(v), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(v)), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(v)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(v, 10, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(v, 10, 64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		i, err := strconv.ParseUint(v, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(i)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		i, err := strconv.ParseUint(v, 10, 64)
+		return protoreflect.ValueOfUint64(i), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(v, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(v, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.EnumKind:
+		if value := field.Enum().Values().ByName(protoreflect.Name(v)); value != nil {
+			return protoreflect.ValueOfEnum(value.Number()), nil
+		}
+		i, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown %s value %q", field.Enum().FullName(), v)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported kind %s", field.Kind())
+	}
+}
+
+// PrintMessage writes msg as indented JSON to w.
+func PrintMessage(w io.Writer, msg proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, strings.TrimSpace(string(data)))
+	return err
+}
+
+// PrintResults writes the results of a broadcast to w, returning an error if any instance returned one.
+func PrintResults[T any](w io.Writer, results []BroadcastResult[T]) error {
+	var failed int
+	for i, r := range results {
+		fmt.Fprintf(w, "// Instance %s (%d/%d, %v)\n", r.InstanceID, i+1, len(results), r.RTT)
+		if r.Err != nil {
+			failed++
+			fmt.Fprintf(w, "// Error: %v\n", r.Err)
+			continue
+		}
+		if msg, ok := any(r.Response).(proto.Message); ok {
+			if err := PrintMessage(w, msg); err != nil {
+				return err
+			}
+		} else {
+			fmt.Fprintln(w, "{}")
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d instances returned an error", failed, len(results))
+	}
+	return nil
+}
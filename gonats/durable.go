@@ -0,0 +1,397 @@
+package gonats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/nats-io/nuid"
+	"xiam.li/protonats/go/protonats"
+)
+
+// DurableReplyHeader contains the subject the result of a durable request is published to, in addition to being stored.
+// Servers only publish to inboxes, i.e. subjects starting with nats.InboxPrefix, so that clients can't make them publish to other services.
+const DurableReplyHeader = "Protonats-Reply-To"
+
+// ErrDurableReply is returned for durable calls whose reply subject is not an inbox.
+var ErrDurableReply = errors.New("reply subject of durable requests must start with " + nats.InboxPrefix)
+
+// durableSetupTimeout is the timeout of creating the stream, bucket and consumers of durable methods and of storing results.
+const durableSetupTimeout = 10 * time.Second
+
+var (
+	// DurableMaxDeliver is the number of times a durable request is delivered before a retryable error becomes its result.
+	DurableMaxDeliver = 10
+	// DurableRetryDelay is the delay before a durable request that failed with a retryable error is delivered again,
+	// unless the error carries a RetryAfterHeader.
+	DurableRetryDelay = time.Second
+	// DurableResultTTL is the time the results of durable requests are kept in the results bucket of the service.
+	DurableResultTTL = 24 * time.Hour
+)
+
+// DurableStream returns the name of the JetStream stream the durable requests of service with extraSubject are published to.
+// Servers with different extra subjects have their own streams, so that they don't consume each other's requests.
+func DurableStream(service, extraSubject string) string {
+	return "PROTONATS_" + durableName(service, extraSubject)
+}
+
+// DurableBucket returns the name of the JetStream key-value bucket the results of the durable requests of service with extraSubject are stored in.
+func DurableBucket(service, extraSubject string) string {
+	return "PROTONATS_RESULTS_" + durableName(service, extraSubject)
+}
+
+// DurableSubject returns the subject the durable requests of method are published to, followed by extraSubject like other subjects.
+func DurableSubject(service, method, extraSubject string) string {
+	subject := "durable." + service + "." + method
+	if extraSubject != "" {
+		subject += "." + extraSubject
+	}
+	return subject
+}
+
+// durableName returns service followed by extraSubject, which may contain dots, unlike the names of streams and buckets.
+func durableName(service, extraSubject string) string {
+	if extraSubject == "" {
+		return service
+	}
+	return service + "_" + strings.ReplaceAll(extraSubject, ".", "_")
+}
+
+// durableConsumer returns the name of the durable consumer of method, shared by all instances of service consuming the same stream.
+func durableConsumer(service, method string) string {
+	return service + "_" + method
+}
+
+// durableResult is a response to a durable request as stored in the results bucket.
+type durableResult struct {
+	Header nats.Header `json:"header,omitempty"`
+	Data   []byte      `json:"data,omitempty"`
+}
+
+// DurableReceipt identifies a durable request accepted by the stream of the service.
+type DurableReceipt struct {
+	// ID is the key of the result in the results bucket, and deduplicates the request within the duplicate window of the stream.
+	ID string `json:"id"`
+	// Sequence is the sequence of the request in the stream.
+	Sequence uint64 `json:"sequence"`
+}
+
+// DurableCall is a durable request published by a generated client, whose result can be awaited with Wait.
+type DurableCall[T any] struct {
+	DurableReceipt
+	js     jetstream.JetStream
+	bucket string
+	sealer *Sealer
+	decode func([]byte) (T, error)
+}
+
+// PublishDurable publishes a durable request to method of service, served with extraSubject, and returns once the stream accepted it.
+// If reply is not empty, the result is published to it in addition to being stored in the results bucket.
+// The request is signed and encrypted according to config, and decode unmarshals the data of the result, unless it is nil.
+func PublishDurable[T any](ctx context.Context, nc *nats.Conn, config ClientConfig, service, method, extraSubject, reply string, data []byte, decode func([]byte) (T, error)) (*DurableCall[T], error) {
+	sealer, err := NewSealer(config.XKey)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := NewRequest(nc, DurableSubject(service, method, extraSubject), data, config.Signer, sealer)
+	if err != nil {
+		return nil, err
+	}
+	if reply != "" {
+		if !isInbox(reply) {
+			return nil, ErrDurableReply
+		}
+		if msg.Header == nil {
+			msg.Header = nats.Header{}
+		}
+		msg.Header.Set(DurableReplyHeader, reply)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, err
+	}
+	id := nuid.Next()
+	ack, err := js.PublishMsg(ctx, msg, jetstream.WithMsgID(id))
+	if err != nil {
+		return nil, err
+	}
+	return &DurableCall[T]{
+		DurableReceipt: DurableReceipt{ID: id, Sequence: ack.Sequence},
+		js:             js,
+		bucket:         DurableBucket(service, extraSubject),
+		sealer:         sealer,
+		decode:         decode,
+	}, nil
+}
+
+// Wait waits until the result of the call is stored in the results bucket, or ctx is done.
+func (c *DurableCall[T]) Wait(ctx context.Context) (T, error) {
+	var zero T
+	kv, err := c.js.KeyValue(ctx, c.bucket)
+	if err != nil {
+		return zero, err
+	}
+	watcher, err := kv.Watch(ctx, c.ID)
+	if err != nil {
+		return zero, err
+	}
+	defer watcher.Stop()
+	for {
+		select {
+		case entry, ok := <-watcher.Updates():
+			if !ok {
+				return zero, ctx.Err()
+			}
+			if entry == nil || entry.Operation() != jetstream.KeyValuePut {
+				continue
+			}
+			var result durableResult
+			if err := json.Unmarshal(entry.Value(), &result); err != nil {
+				return zero, err
+			}
+			return c.Decode(&nats.Msg{Header: result.Header, Data: result.Data})
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Decode returns the response or error contained in msg, a result of the call received on the reply subject.
+func (c *DurableCall[T]) Decode(msg *nats.Msg) (T, error) {
+	var zero T
+	data, err := c.sealer.Open(msg)
+	if err != nil {
+		return zero, err
+	}
+	if errMsg, errCode := msg.Header.Get(micro.ErrorHeader), msg.Header.Get(micro.ErrorCodeHeader); len(errMsg) > 0 && len(errCode) > 0 {
+		return zero, protonats.ServiceError{Code: errCode, Description: errMsg, Details: string(data)}
+	}
+	if c.decode == nil {
+		return zero, nil
+	}
+	return c.decode(data)
+}
+
+// PrintDurableReceipt writes the receipt of a durable request as JSON to w.
+func PrintDurableReceipt(w io.Writer, receipt DurableReceipt) error {
+	data, err := json.MarshalIndent(receipt, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// WriteHTTPAccepted writes the receipt of a durable request as JSON with status 202.
+func WriteHTTPAccepted(w http.ResponseWriter, receipt DurableReceipt) {
+	writeJSON(w, http.StatusAccepted, receipt)
+}
+
+// durables contains the JetStream state of the durable methods of a server.
+type durables struct {
+	mu        sync.Mutex
+	js        jetstream.JetStream
+	results   jetstream.KeyValue
+	consumers []jetstream.ConsumeContext
+}
+
+// durable returns the stream and results bucket of the durable requests of service, creating or updating them on first use.
+// They are set up without holding the lock, as that takes round trips to JetStream, so concurrent first uses set them up both.
+func (f *InFlight) durable(ctx context.Context, service string) (jetstream.JetStream, jetstream.KeyValue, error) {
+	f.durables.mu.Lock()
+	js, results := f.durables.js, f.durables.results
+	f.durables.mu.Unlock()
+	if results != nil {
+		return js, results, nil
+	}
+	if f.nc == nil {
+		return nil, nil, errors.New("durable methods require the connection passed to Register")
+	}
+	js, err := jetstream.New(f.nc)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      DurableStream(service, f.cfg.ExtraSubject),
+		Subjects:  []string{DurableSubject(service, "*", f.cfg.ExtraSubject)},
+		Retention: jetstream.WorkQueuePolicy,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	results, err = js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{Bucket: DurableBucket(service, f.cfg.ExtraSubject), TTL: DurableResultTTL})
+	if err != nil {
+		return nil, nil, err
+	}
+	f.durables.mu.Lock()
+	defer f.durables.mu.Unlock()
+	if f.durables.results == nil {
+		f.durables.js, f.durables.results = js, results
+	}
+	return f.durables.js, f.durables.results, nil
+}
+
+// Durable consumes the durable requests of method from the stream of service through a durable consumer shared by all instances
+// with the same extra subject, passing them to handler wrapped like Handler.
+// Requests are acknowledged once they are responded to, and their result is stored in the results bucket and published to their reply subject.
+// Requests failing with a retryable error (RateLimitedCode or a code from 500 to 559) are delivered again
+// after DurableRetryDelay or the RetryAfterHeader, until DurableMaxDeliver is reached.
+// If JetStream is not enabled, a warning is logged and the method is not served.
+func (f *InFlight) Durable(service, method string, handler micro.Handler, cfg EndpointConfig) error {
+	ctx, cancel := context.WithTimeout(context.Background(), durableSetupTimeout)
+	defer cancel()
+	js, results, err := f.durable(ctx, service)
+	if errors.Is(err, jetstream.ErrJetStreamNotEnabled) || errors.Is(err, jetstream.ErrJetStreamNotEnabledForAccount) || errors.Is(err, nats.ErrNoResponders) {
+		slog.Warn("JetStream is not enabled, durable method is not served", "service", service, "method", method)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	consumer, err := js.CreateOrUpdateConsumer(ctx, DurableStream(service, f.cfg.ExtraSubject), jetstream.ConsumerConfig{
+		Durable:       durableConsumer(service, method),
+		FilterSubject: DurableSubject(service, method, f.cfg.ExtraSubject),
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		MaxDeliver:    DurableMaxDeliver,
+	})
+	if err != nil {
+		return err
+	}
+	handler = f.Handler(method+"-Durable", handler, cfg)
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		handler.Handle(&durableRequest{msg: msg, nc: f.nc, results: results})
+	})
+	if err != nil {
+		return err
+	}
+	f.durables.mu.Lock()
+	f.durables.consumers = append(f.durables.consumers, consumeCtx)
+	f.durables.mu.Unlock()
+	return nil
+}
+
+// stopConsumers stops the consumers of all durable methods, so that their pending requests are delivered to other instances.
+func (f *InFlight) stopConsumers() {
+	f.durables.mu.Lock()
+	consumers := f.durables.consumers
+	f.durables.consumers = nil
+	f.durables.mu.Unlock()
+	for _, c := range consumers {
+		c.Stop()
+	}
+}
+
+// durableRequest is a durable request consumed from JetStream, passed to the handlers of the endpoints as micro.Request.
+type durableRequest struct {
+	msg     jetstream.Msg
+	nc      *nats.Conn
+	results jetstream.KeyValue
+}
+
+func (r *durableRequest) Data() []byte {
+	return r.msg.Data()
+}
+
+func (r *durableRequest) Headers() micro.Headers {
+	return micro.Headers(r.msg.Headers())
+}
+
+func (r *durableRequest) Subject() string {
+	return r.msg.Subject()
+}
+
+// Reply returns the subject of the DurableReplyHeader, unless it is not an inbox.
+func (r *durableRequest) Reply() string {
+	if reply := r.msg.Headers().Get(DurableReplyHeader); isInbox(reply) {
+		return reply
+	}
+	return ""
+}
+
+func (r *durableRequest) Respond(data []byte, opts ...micro.RespondOpt) error {
+	result := &nats.Msg{Header: nats.Header{}, Data: data}
+	for _, opt := range opts {
+		opt(result)
+	}
+	if err := r.store(result); err != nil {
+		_ = r.msg.Nak()
+		return err
+	}
+	return r.msg.Ack()
+}
+
+func (r *durableRequest) RespondJSON(response any, opts ...micro.RespondOpt) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return micro.ErrMarshalResponse
+	}
+	return r.Respond(data, opts...)
+}
+
+func (r *durableRequest) Error(code, description string, data []byte, opts ...micro.RespondOpt) error {
+	result := &nats.Msg{Header: nats.Header{}, Data: data}
+	result.Header.Set(micro.ErrorHeader, description)
+	result.Header.Set(micro.ErrorCodeHeader, code)
+	for _, opt := range opts {
+		opt(result)
+	}
+	if retryableCode(code) {
+		md, err := r.msg.Metadata()
+		if err != nil || md.NumDelivered < uint64(DurableMaxDeliver) {
+			delay := DurableRetryDelay
+			if d, err := time.ParseDuration(result.Header.Get(RetryAfterHeader)); err == nil {
+				delay = d
+			}
+			return r.msg.NakWithDelay(delay)
+		}
+	}
+	if err := r.store(result); err != nil {
+		_ = r.msg.Nak()
+		return err
+	}
+	return r.msg.Term()
+}
+
+// store stores result in the results bucket under the ID of the request and publishes it to the reply subject of the request, if it has one.
+func (r *durableRequest) store(result *nats.Msg) error {
+	value, err := json.Marshal(durableResult{Header: result.Header, Data: result.Data})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), durableSetupTimeout)
+	defer cancel()
+	if id := r.msg.Headers().Get(nats.MsgIdHdr); id != "" {
+		if _, err := r.results.Put(ctx, id, value); err != nil {
+			return err
+		}
+	}
+	if reply := r.Reply(); reply != "" {
+		result.Subject = reply
+		return r.nc.PublishMsg(result)
+	}
+	return nil
+}
+
+// isInbox reports whether subject is a subject below nats.InboxPrefix without wildcards.
+func isInbox(subject string) bool {
+	return len(subject) > len(nats.InboxPrefix) && strings.HasPrefix(subject, nats.InboxPrefix) && !strings.ContainsAny(subject, "*> \t")
+}
+
+// retryableCode reports whether a request failing with code may succeed when it is delivered again,
+// which are rate limits, unavailable or overloaded instances and timeouts.
+// Other errors, like internal errors or unimplemented methods, are not expected to change.
+func retryableCode(code string) bool {
+	return slices.Contains([]string{RateLimitedCode, ShuttingDownCode, NoLeaderCode, OverloadedCode, strconv.Itoa(http.StatusGatewayTimeout)}, code)
+}
@@ -0,0 +1,129 @@
+package gonats
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// DefaultElectionTTL is the TTL of the leader key used by WithLeaderElection if none is given.
+const DefaultElectionTTL = 10 * time.Second
+
+// leaderKey is the key of the leader bucket containing the instance ID of the current leader.
+const leaderKey = "leader"
+
+// LeaderBucket returns the name of the JetStream key-value bucket the leader of service is elected in.
+func LeaderBucket(service string) string {
+	return "PROTONATS_LEADER_" + service
+}
+
+// WithLeaderElection elects a leader among the instances of the service through a JetStream key-value bucket,
+// whose key expires after ttl, or DefaultElectionTTL if ttl is zero.
+// The methods with consensus_target LEADER are only served by the leader, and those with FOLLOWER only by the other instances.
+// Their endpoints are switched whenever the instance wins or loses leadership, and a RoleChanged handler of the implementation is notified.
+// The leader refreshes the key every third of ttl and steps down if it fails to, followers try to take over as often.
+func WithLeaderElection(ttl time.Duration) ServerOption {
+	if ttl <= 0 {
+		ttl = DefaultElectionTTL
+	}
+	return configure(func(cfg *ServerConfig) {
+		cfg.ElectionTTL = ttl
+	})
+}
+
+// election campaigns for the leadership of a service.
+type election struct {
+	nc       *nats.Conn
+	id       string
+	bucket   string
+	ttl      time.Duration
+	kv       jetstream.KeyValue
+	revision uint64
+}
+
+// campaign refreshes the leader key if the instance is the leader, or tries to create it otherwise, and returns the resulting role.
+// Each campaign is bounded by a third of the TTL, so that the leader steps down before its key expires.
+func (e *election) campaign(ctx context.Context) (Role, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.ttl/3)
+	defer cancel()
+	if e.kv == nil {
+		js, err := jetstream.New(e.nc)
+		if err != nil {
+			return RoleFollower, err
+		}
+		kv, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{Bucket: e.bucket, TTL: e.ttl})
+		if err != nil {
+			return RoleFollower, err
+		}
+		e.kv = kv
+	}
+	var err error
+	if e.revision != 0 {
+		e.revision, err = e.kv.Update(ctx, leaderKey, []byte(e.id), e.revision)
+	} else {
+		e.revision, err = e.kv.Create(ctx, leaderKey, []byte(e.id))
+	}
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		return RoleFollower, nil
+	}
+	if err != nil {
+		e.revision = 0
+		return RoleFollower, err
+	}
+	return RoleLeader, nil
+}
+
+// resign deletes the leader key if the instance is the leader, so that another instance takes over without waiting for it to expire.
+func (e *election) resign(ctx context.Context) {
+	if e.kv == nil || e.revision == 0 {
+		return
+	}
+	_ = e.kv.Delete(ctx, leaderKey, jetstream.LastRevision(e.revision))
+	e.revision = 0
+}
+
+// elect campaigns for the leadership of service until ctx is done, switching the role of the instance with the outcome.
+func (f *InFlight) elect(ctx context.Context, service string, done chan<- struct{}) {
+	defer close(done)
+	e := &election{nc: f.nc, id: f.id, bucket: LeaderBucket(service), ttl: f.cfg.ElectionTTL}
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+	for {
+		role, err := e.campaign(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Warn("Leader election failed", "service", service, "error", err)
+		}
+		if ctx.Err() == nil {
+			if err := f.setRole(role); err != nil {
+				slog.Warn("Failed to switch role endpoints", "service", service, "role", role, "error", err)
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			resignCtx, cancel := context.WithTimeout(context.Background(), durableSetupTimeout)
+			e.resign(resignCtx)
+			cancel()
+			return
+		}
+	}
+}
+
+// stopRoles stops campaigning, resigning the leadership if the instance holds it, and drains all role endpoints.
+func (f *InFlight) stopRoles() {
+	f.roles.mu.Lock()
+	cancel, done := f.roles.cancelElection, f.roles.electionDone
+	f.roles.cancelElection, f.roles.electionDone = nil, nil
+	f.roles.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	if _, err := f.switchRole(""); err != nil {
+		slog.Warn("Failed to drain role endpoints", "error", err)
+	}
+}
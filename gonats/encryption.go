@@ -0,0 +1,201 @@
+package gonats
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/nats-io/nkeys"
+)
+
+const (
+	// EncryptionRequiredCode is the code of the error returned for plaintext requests to a service using encryption.
+	EncryptionRequiredCode = "400"
+	// XKeyMetadata is the metadata key the public xkey of a service using encryption is advertised under in $SRV.INFO.
+	XKeyMetadata = "protonats.xkey"
+	// XKeyHeader contains the public xkey of the sender of an encrypted request or response.
+	XKeyHeader = "Protonats-Xkey"
+)
+
+var (
+	// ErrNoXKey is returned by ServiceXKey if no instance advertises an xkey.
+	ErrNoXKey = errors.New("service does not advertise an xkey")
+	// ErrAmbiguousXKey is returned by ServiceXKey if the instances advertise different xkeys.
+	ErrAmbiguousXKey = errors.New("instances of the service advertise different xkeys")
+	// ErrUnencryptedResponse is returned by Sealer.Open for responses with data that isn't encrypted.
+	ErrUnencryptedResponse = errors.New("response is not encrypted")
+	// ErrUnexpectedXKey is returned by Sealer.Open for responses encrypted by another xkey than the one of the service.
+	ErrUnexpectedXKey = errors.New("response is not encrypted by the xkey of the service")
+)
+
+// WithEncryption encrypts request and response bodies end to end with the curve key pair kp.
+// Its public key is advertised under XKeyMetadata, to which clients encrypt their requests.
+// Plaintext requests are rejected with EncryptionRequiredCode, and responses are encrypted to the ephemeral xkey of the client.
+// All instances of a service must use the same key pair, as clients encrypt requests before they know which instance will receive them.
+func WithEncryption(kp nkeys.KeyPair) ServerOption {
+	return configure(func(cfg *ServerConfig) {
+		cfg.XKey = kp
+	})
+}
+
+// ServiceXKey returns the xkey advertised by the instances of a service in their info.
+func ServiceXKey(infos []*micro.Info) (string, error) {
+	var xkey string
+	for _, info := range infos {
+		key := info.Metadata[XKeyMetadata]
+		if key == "" {
+			continue
+		}
+		if xkey != "" && key != xkey {
+			return "", ErrAmbiguousXKey
+		}
+		xkey = key
+	}
+	if xkey == "" {
+		return "", ErrNoXKey
+	}
+	return xkey, nil
+}
+
+// Sealer encrypts the requests of a single call of a generated client and decrypts their responses.
+// A nil Sealer leaves requests and responses unencrypted.
+type Sealer struct {
+	xkey      string
+	ephemeral nkeys.KeyPair
+}
+
+// NewSealer returns a Sealer encrypting to the service xkey with a new ephemeral key pair, or nil if xkey is empty.
+func NewSealer(xkey string) (*Sealer, error) {
+	if xkey == "" {
+		return nil, nil
+	}
+	ephemeral, err := nkeys.CreateCurveKeys()
+	if err != nil {
+		return nil, err
+	}
+	return &Sealer{xkey: xkey, ephemeral: ephemeral}, nil
+}
+
+// Seal encrypts the data of msg and adds the XKeyHeader the response is encrypted to.
+func (s *Sealer) Seal(msg *nats.Msg) error {
+	if s == nil {
+		return nil
+	}
+	pub, err := s.ephemeral.PublicKey()
+	if err != nil {
+		return err
+	}
+	if msg.Data, err = s.ephemeral.Seal(msg.Data, s.xkey); err != nil {
+		return err
+	}
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	msg.Header.Set(XKeyHeader, pub)
+	return nil
+}
+
+// Open returns the decrypted data of a response, or its data if s is nil.
+// Responses with data must be encrypted by the xkey of the service, otherwise ErrUnencryptedResponse or ErrUnexpectedXKey is returned.
+// Responses without data are accepted unencrypted, as the errors of requests rejected before decryption have none.
+func (s *Sealer) Open(msg *nats.Msg) ([]byte, error) {
+	if s == nil {
+		return msg.Data, nil
+	}
+	xkey := msg.Header.Get(XKeyHeader)
+	if xkey != "" && xkey != s.xkey {
+		return nil, ErrUnexpectedXKey
+	}
+	if len(msg.Data) == 0 {
+		return msg.Data, nil
+	}
+	if xkey == "" {
+		return nil, ErrUnencryptedResponse
+	}
+	return s.ephemeral.Open(msg.Data, s.xkey)
+}
+
+// sealedRequest decrypts the data of a request and encrypts the data of its response to the xkey of the client.
+type sealedRequest struct {
+	micro.Request
+	kp     nkeys.KeyPair
+	client string
+	data   []byte
+}
+
+func (r *sealedRequest) Data() []byte {
+	return r.data
+}
+
+func (r *sealedRequest) unwrap() micro.Request {
+	return r.Request
+}
+
+func (r *sealedRequest) seal(data []byte, opts []micro.RespondOpt) ([]byte, []micro.RespondOpt, error) {
+	if len(data) == 0 {
+		return data, opts, nil
+	}
+	pub, err := r.kp.PublicKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed, err := r.kp.Seal(data, r.client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sealed, append(slices.Clone(opts), withHeader(XKeyHeader, pub)), nil
+}
+
+func (r *sealedRequest) Respond(data []byte, opts ...micro.RespondOpt) error {
+	data, opts, err := r.seal(data, opts)
+	if err != nil {
+		return r.Request.Error("500", "Failed to encrypt response", nil, opts...)
+	}
+	return r.Request.Respond(data, opts...)
+}
+
+func (r *sealedRequest) RespondJSON(response any, opts ...micro.RespondOpt) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return micro.ErrMarshalResponse
+	}
+	return r.Respond(data, opts...)
+}
+
+func (r *sealedRequest) Error(code, description string, data []byte, opts ...micro.RespondOpt) error {
+	data, opts, err := r.seal(data, opts)
+	if err != nil {
+		return r.Request.Error("500", "Failed to encrypt response", nil, opts...)
+	}
+	return r.Request.Error(code, description, data, opts...)
+}
+
+// signedPayload returns the data the signature of request covers, which is the encrypted data for encrypted requests.
+func signedPayload(request micro.Request) []byte {
+	if r, ok := request.(*sealedRequest); ok {
+		return r.Request.Data()
+	}
+	return request.Data()
+}
+
+// sealed returns handler decrypting requests encrypted to kp and encrypting their responses, or handler if kp is nil.
+func sealed(kp nkeys.KeyPair, handler micro.Handler, opts ...micro.RespondOpt) micro.Handler {
+	if kp == nil {
+		return handler
+	}
+	return micro.HandlerFunc(func(request micro.Request) {
+		client := request.Headers().Get(XKeyHeader)
+		if client == "" {
+			_ = request.Error(EncryptionRequiredCode, "Request must be encrypted", nil, opts...)
+			return
+		}
+		data, err := kp.Open(request.Data(), client)
+		if err != nil {
+			_ = request.Error(EncryptionRequiredCode, "Failed to decrypt request", nil, opts...)
+			return
+		}
+		handler.Handle(&sealedRequest{Request: request, kp: kp, client: client, data: data})
+	})
+}
@@ -0,0 +1,167 @@
+package gonats
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+	"xiam.li/protonats/go/protonats"
+)
+
+// ErrInvalidToken is returned for subject tokens that are empty or contain '.', '*', '>' or whitespace.
+var ErrInvalidToken = errors.New("invalid subject token")
+
+// DecodeError is passed to the decode error handler of a subscription for events that can't be unmarshalled.
+type DecodeError struct {
+	// Subject is the subject the event was received on.
+	Subject string
+	// Err is the error returned by proto.Unmarshal.
+	Err error
+}
+
+func (e DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode event on %s: %v", e.Subject, e.Err)
+}
+
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// EventTokens returns the names of the fields referenced by the {field} tokens of pattern, in order.
+func EventTokens(pattern string) []string {
+	var names []string
+	for _, part := range strings.Split(pattern, ".") {
+		if name, ok := tokenName(part); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// EventSubject returns the subject an event of pattern is published to, filling its {field} tokens with values.
+func EventSubject(pattern string, values map[string]string) (string, error) {
+	return fillSubject(pattern, func(name string) (string, error) {
+		value := values[name]
+		if !validToken(value) {
+			return "", fmt.Errorf("%w: %s=%q", ErrInvalidToken, name, value)
+		}
+		return value, nil
+	})
+}
+
+// EventFilter returns the subject to subscribe to for events of pattern, filling the {field} tokens set in values
+// and replacing all others with wildcards.
+func EventFilter(pattern string, values map[string]string) (string, error) {
+	return fillSubject(pattern, func(name string) (string, error) {
+		value, ok := values[name]
+		if !ok {
+			return "*", nil
+		}
+		if !validToken(value) {
+			return "", fmt.Errorf("%w: %s=%q", ErrInvalidToken, name, value)
+		}
+		return value, nil
+	})
+}
+
+// fillSubject replaces every {field} token of pattern with the result of fill.
+func fillSubject(pattern string, fill func(name string) (string, error)) (string, error) {
+	parts := strings.Split(pattern, ".")
+	for i, part := range parts {
+		name, ok := tokenName(part)
+		if !ok {
+			continue
+		}
+		value, err := fill(name)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = value
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// tokenName returns the name of the field referenced by part, if it is a {field} token.
+func tokenName(part string) (string, bool) {
+	if len(part) < 3 || part[0] != '{' || part[len(part)-1] != '}' {
+		return "", false
+	}
+	return part[1 : len(part)-1], true
+}
+
+// validToken reports whether value can be used as a single token of a subject.
+func validToken(value string) bool {
+	return value != "" && !strings.ContainsAny(value, ".*> \t\r\n")
+}
+
+// PublishEvent publishes event to subject.
+func PublishEvent(nc *nats.Conn, subject string, event proto.Message) error {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return protonats.ErrMarshallingFailed
+	}
+	return nc.Publish(subject, data)
+}
+
+// SubscribeOption configures a subscription to events created by SubscribeEvents.
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	queue   string
+	values  map[string]string
+	onError func(*nats.Msg, error)
+}
+
+// WithQueueGroup subscribes in the queue group queue, so that every event is handled by only one of its subscribers.
+func WithQueueGroup(queue string) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.queue = queue
+	}
+}
+
+// WithToken only receives events whose field token has value, instead of all values.
+func WithToken(field, value string) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.values[field] = value
+	}
+}
+
+// WithDecodeErrorHandler calls handler with a DecodeError for events that can't be unmarshalled, instead of logging a warning.
+func WithDecodeErrorHandler(handler func(msg *nats.Msg, err error)) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.onError = handler
+	}
+}
+
+// SubscribeEvents calls handler for every event of pattern, unmarshalled into a message returned by newEvent.
+// Events that can't be unmarshalled are passed to the handler set with WithDecodeErrorHandler, or logged.
+func SubscribeEvents[T proto.Message](nc *nats.Conn, pattern string, newEvent func() T, handler func(event T, msg *nats.Msg), opts ...SubscribeOption) (*nats.Subscription, error) {
+	o := subscribeOptions{
+		values: make(map[string]string),
+		onError: func(msg *nats.Msg, err error) {
+			slog.Warn("Failed to decode event", "subject", msg.Subject, "error", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	subject, err := EventFilter(pattern, o.values)
+	if err != nil {
+		return nil, err
+	}
+	cb := func(msg *nats.Msg) {
+		event := newEvent()
+		if err := proto.Unmarshal(msg.Data, event); err != nil {
+			o.onError(msg, DecodeError{Subject: msg.Subject, Err: err})
+			return
+		}
+		handler(event, msg)
+	}
+	if o.queue != "" {
+		return nc.QueueSubscribe(subject, o.queue, cb)
+	}
+	return nc.Subscribe(subject, cb)
+}
@@ -0,0 +1,239 @@
+package gonats
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/nats-io/nkeys"
+	"xiam.li/protonats/go/protonats"
+)
+
+const (
+	// NoLeaderCode is the code of the error returned by forwarding endpoints if no leader is known or the leader didn't respond.
+	// Like ShuttingDownCode, it tells generated clients pinned with WithLeader to discover the leader again.
+	NoLeaderCode = "503"
+	// ForwarderHeader contains the instance ID of the follower that forwarded a request to the leader.
+	ForwarderHeader = "Protonats-Forwarder"
+	// ForwardedHeader contains the subject a request was originally sent to, set by followers forwarding it to the leader
+	// if they sign forwarded requests with WithForwarderSigner.
+	ForwardedHeader = "Protonats-Forwarded"
+	// ForwarderKeyHeader contains the public key of the NKey the follower signed a forwarded request with.
+	ForwarderKeyHeader = "Protonats-Forwarder-Key"
+	// ForwarderSignatureHeader contains the base64 encoded signature of the follower over the ForwardedHeader,
+	// the subject the request was forwarded to and its payload.
+	ForwarderSignatureHeader = "Protonats-Forwarder-Signature"
+)
+
+// ErrUntrustedForwarder is returned for requests with a ForwardedHeader that weren't signed by a trusted signer.
+var ErrUntrustedForwarder = errors.New("request was not forwarded by a trusted follower")
+
+// ForwardTimeout is the time a follower waits for the response of the leader to a forwarded request.
+var ForwardTimeout = 5 * time.Second
+
+// leaderDiscoveryTimeout is the time a follower waits for the leader to respond to the RoleEndpoint.
+const leaderDiscoveryTimeout = time.Second
+
+// WithLeaderForwarding makes followers forward requests to methods with consensus_target LEADER to the current leader,
+// responding with its response unchanged, instead of leaving them unanswered.
+// It applies to instances switching roles with WithLeaderElection or WithRole while they are followers,
+// and to instances created with WithoutLeaderFns. Methods using broadcasting or durable delivery are not forwarded.
+// The leader is discovered through the RoleEndpoint and cached until a forwarded request to it fails.
+// As the subject of forwarded requests changes, the leader can only verify their signatures, see WithTrustedSigners,
+// if the follower signs them with WithForwarderSigner.
+func WithLeaderForwarding() ServerOption {
+	return configure(func(cfg *ServerConfig) {
+		cfg.ForwardToLeader = true
+	})
+}
+
+// WithForwarderSigner makes followers sign the requests they forward to the leader with kp, along with the subject they were
+// originally sent to, which the leader verifies the signature of the request against, instead of the subject it was forwarded to.
+// The leader only trusts forwarded requests signed with one of its TrustedSigners, set with WithTrustedSigners,
+// and rejects all other requests with a ForwardedHeader with UnauthenticatedCode.
+func WithForwarderSigner(kp nkeys.KeyPair) ServerOption {
+	return configure(func(cfg *ServerConfig) {
+		cfg.ForwarderSigner = kp
+	})
+}
+
+// ForwardsToLeader reports whether the server was created with WithLeaderForwarding.
+func (f *InFlight) ForwardsToLeader() bool {
+	return f.cfg.ForwardToLeader
+}
+
+// Forward returns the handler of a forwarding endpoint, passing requests to the direct endpoint of the leader at subject.
+// Requests are forwarded with their data and headers unchanged, so that the leader verifies, decrypts and authorizes them itself.
+// Requests that were already forwarded are rejected with NoLeaderCode, as the instance they were forwarded to is no longer the leader.
+// Each request is forwarded on its own goroutine, tracked like the requests handled by the instance,
+// so that waiting for the leader doesn't hold up the following requests to the endpoint.
+func (f *InFlight) Forward(subject string) micro.Handler {
+	return micro.HandlerFunc(func(request micro.Request) {
+		if request.Headers().Get(ForwarderHeader) != "" {
+			_ = request.Error(NoLeaderCode, "Instance is no longer the leader", nil, WithInstanceHeader(f.id))
+			return
+		}
+		if !f.requests.acquire() {
+			_ = request.Error(ShuttingDownCode, "Service is shutting down", nil, WithInstanceHeader(f.id))
+			return
+		}
+		go func() {
+			defer f.requests.release()
+			f.forward(subject, request)
+		}()
+	})
+}
+
+// forward passes request to the direct endpoint of the leader at subject and responds with its response.
+func (f *InFlight) forward(subject string, request micro.Request) {
+	leader, err := f.leader.Get(f.discoverLeader)
+	if err != nil {
+		_ = request.Error(NoLeaderCode, "No leader to forward the request to: "+err.Error(), nil, WithInstanceHeader(f.id))
+		return
+	}
+	msg := &nats.Msg{Subject: f.subject(subject, leader), Header: nats.Header{}, Data: request.Data()}
+	for key, values := range request.Headers() {
+		msg.Header[key] = values
+	}
+	msg.Header.Del(ForwardedHeader)
+	msg.Header.Del(ForwarderKeyHeader)
+	msg.Header.Del(ForwarderSignatureHeader)
+	msg.Header.Set(ForwarderHeader, f.id)
+	if err := f.signForwarded(msg, request.Subject()); err != nil {
+		_ = request.Error("500", "Failed to sign the forwarded request", nil, WithInstanceHeader(f.id))
+		return
+	}
+	if request.Reply() == "" {
+		// Requests to one-way methods are published without waiting for a response
+		if err := f.nc.PublishMsg(msg); err != nil {
+			slog.Warn("Failed to forward request to the leader", "subject", msg.Subject, "error", err)
+		}
+		return
+	}
+	response, err := f.nc.RequestMsg(msg, ForwardTimeout)
+	if err != nil {
+		f.leader.Failed(leader, err)
+		_ = request.Error(NoLeaderCode, "Failed to forward the request to the leader: "+err.Error(), nil, WithInstanceHeader(f.id))
+		return
+	}
+	if code := response.Header.Get(micro.ErrorCodeHeader); code != "" {
+		f.leader.Failed(leader, protonats.ServiceError{Code: code})
+	}
+	_ = request.Respond(response.Data, func(m *nats.Msg) {
+		m.Header = response.Header
+	})
+}
+
+// forwardedData returns the data covered by the signature of a forwarding follower:
+// the subject the request was originally sent to, the subject it was forwarded to and the payload.
+func forwardedData(original, subject string, data []byte) []byte {
+	hash := sha256.Sum256(data)
+	return []byte(strings.Join([]string{original, subject, hex.EncodeToString(hash[:])}, "\n"))
+}
+
+// signForwarded sets the ForwardedHeader of msg to original and signs it with the ForwarderSigner, if the instance has one.
+func (f *InFlight) signForwarded(msg *nats.Msg, original string) error {
+	if f.cfg.ForwarderSigner == nil {
+		return nil
+	}
+	pub, err := f.cfg.ForwarderSigner.PublicKey()
+	if err != nil {
+		return err
+	}
+	sig, err := f.cfg.ForwarderSigner.Sign(forwardedData(original, msg.Subject, msg.Data))
+	if err != nil {
+		return err
+	}
+	msg.Header.Set(ForwardedHeader, original)
+	msg.Header.Set(ForwarderKeyHeader, pub)
+	msg.Header.Set(ForwarderSignatureHeader, base64.RawURLEncoding.EncodeToString(sig))
+	return nil
+}
+
+// verifyForwarded verifies that request was forwarded from original, addressing the same method,
+// by a follower signing it with one of the TrustedSigners.
+func (f *InFlight) verifyForwarded(original string, request micro.Request) error {
+	key := request.Headers().Get(ForwarderKeyHeader)
+	if methodSubject(original) != methodSubject(request.Subject()) || !slices.Contains(f.cfg.TrustedSigners, key) {
+		return ErrUntrustedForwarder
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(request.Headers().Get(ForwarderSignatureHeader))
+	if err != nil || len(sig) == 0 {
+		return ErrUntrustedForwarder
+	}
+	kp, err := nkeys.FromPublicKey(key)
+	if err != nil {
+		return ErrUntrustedForwarder
+	}
+	if err := kp.Verify(forwardedData(original, request.Subject(), request.Data()), sig); err != nil {
+		return ErrUntrustedForwarder
+	}
+	return nil
+}
+
+// discoverLeader sends a request to the RoleEndpoint of all instances and returns the ID of the first one reporting RoleLeader.
+func (f *InFlight) discoverLeader() (string, error) {
+	inbox := nats.NewInbox()
+	sub, err := f.nc.SubscribeSync(inbox)
+	if err != nil {
+		return "", err
+	}
+	defer sub.Unsubscribe()
+	if err := f.nc.PublishRequest(f.subject(RoleSubject(f.service), ""), inbox, nil); err != nil {
+		return "", err
+	}
+	deadline := time.Now().Add(leaderDiscoveryTimeout)
+	for {
+		msg, err := sub.NextMsg(time.Until(deadline))
+		if err != nil {
+			return "", ErrNoLeader
+		}
+		if id := msg.Header.Get(InstanceHeader); Role(msg.Data) == RoleLeader && id != "" && id != f.id {
+			f.leader.Set(id)
+			return id, nil
+		}
+	}
+}
+
+// forwarded returns handler receiving requests forwarded by a follower with the subject they were originally sent to,
+// so that their signature, which covers the subject, can be verified.
+// Requests with a ForwardedHeader are rejected with UnauthenticatedCode, unless it addresses the same method as the subject
+// the request was forwarded to and the request was signed by a follower with one of the TrustedSigners.
+func (f *InFlight) forwarded(handler micro.Handler) micro.Handler {
+	return micro.HandlerFunc(func(request micro.Request) {
+		if original := request.Headers().Get(ForwardedHeader); original != "" {
+			if err := f.verifyForwarded(original, request); err != nil {
+				_ = request.Error(UnauthenticatedCode, "Unauthenticated: "+err.Error(), nil, WithInstanceHeader(f.id))
+				return
+			}
+			request = forwardedRequest{Request: request, subject: original}
+		}
+		handler.Handle(request)
+	})
+}
+
+// methodSubject returns the first three tokens of subject, "service.<Service>.<Method>", shared by all endpoints of a method.
+func methodSubject(subject string) string {
+	tokens := strings.SplitN(subject, ".", 4)
+	if len(tokens) < 3 {
+		return ""
+	}
+	return strings.Join(tokens[:3], ".")
+}
+
+// forwardedRequest is a request forwarded by a follower, reporting the subject it was originally sent to.
+type forwardedRequest struct {
+	micro.Request
+	subject string
+}
+
+func (r forwardedRequest) Subject() string {
+	return r.subject
+}
@@ -0,0 +1,7 @@
+// Package gonats contains the Go specific runtime additions used by code generated by protoc-gen-go-nats.
+//
+// The shared runtime lives in the [protonats] module, this package only holds the parts
+// that are specific to the Go generator and therefore versioned alongside it.
+//
+// [protonats]: https://github.com/d0x7/protonats
+package gonats
@@ -0,0 +1,282 @@
+// Package grpcnats contains the runtime of the gRPC adapters generated by protoc-gen-go-nats with grpc=true,
+// so that only code serving or bridging gRPC depends on google.golang.org/grpc.
+package grpcnats
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"xiam.li/go-protonats/gonats"
+	"xiam.li/protonats/go/protonats"
+)
+
+// StatusHeader contains the numeric gRPC status code of errors returned by gRPC implementations served over NATS.
+const StatusHeader = "Grpc-Status"
+
+// grpcCodes maps gRPC status codes to the HTTP like codes used by ServerError.
+var grpcCodes = map[codes.Code]int{
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// Code returns the ServerError code used for a gRPC status code.
+func Code(code codes.Code) string {
+	if c, ok := grpcCodes[code]; ok {
+		return strconv.Itoa(c)
+	}
+	return strconv.Itoa(http.StatusInternalServerError)
+}
+
+// InstanceMetadata is the incoming gRPC metadata key selecting the instance a bridged call is sent to.
+const InstanceMetadata = "protonats-instance"
+
+// StatusCode returns the gRPC status code for the code of a ServiceError, the inverse of Code.
+// gonats.OverloadedCode results in ResourceExhausted, like the 429 of a rate limit.
+func StatusCode(code string) codes.Code {
+	if code == gonats.OverloadedCode {
+		return codes.ResourceExhausted
+	}
+	c, err := strconv.Atoi(code)
+	if err != nil {
+		return codes.Unknown
+	}
+	switch c {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if c >= 500 && c <= 599 {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// Status converts an error returned by a generated client into a gRPC status error.
+func Status(err error) error {
+	if err == nil {
+		return nil
+	}
+	if serviceErr, ok := protonats.AsServiceError(err); ok {
+		return status.Error(StatusCode(serviceErr.Code), serviceErr.Description)
+	}
+	switch {
+	case errors.Is(err, nats.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, nats.ErrNoResponders):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// CallOptions returns the call options for a call bridged from gRPC.
+// The context is only passed on if it has a deadline, as it would otherwise replace the timeout of the client.
+// A single instance can be selected with the InstanceMetadata.
+func CallOptions(ctx context.Context) []protonats.CallOption {
+	var opts []protonats.CallOption
+	if _, ok := ctx.Deadline(); ok {
+		opts = append(opts, protonats.WithContext(ctx))
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(InstanceMetadata); len(ids) == 1 {
+			opts = append(opts, protonats.WithInstanceID(ids[0]))
+		}
+	}
+	return opts
+}
+
+// Target returns the instances selected by the InstanceMetadata of ctx for a targeted broadcast, if any are selected.
+func Target(ctx context.Context) (gonats.Target, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(InstanceMetadata)) == 0 {
+		return gonats.Target{}, false
+	}
+	return gonats.Instances(md.Get(InstanceMetadata)...), true
+}
+
+// BroadcastResponse returns the first response of a broadcast bridged to gRPC, which can only return a single response.
+// The IDs of all responding instances are sent as InstanceMetadata header, and the first error of any instance is returned as status error.
+func BroadcastResponse[T any](ctx context.Context, results []gonats.BroadcastResult[T], err error) (T, error) {
+	var zero T
+	if err != nil {
+		return zero, Status(err)
+	}
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.InstanceID)
+	}
+	_ = grpc.SetHeader(ctx, metadata.MD{InstanceMetadata: ids})
+	if errs := gonats.Errors(results); len(errs) > 0 {
+		return zero, Status(errs[0])
+	}
+	if len(results) == 0 {
+		return zero, status.Error(codes.Unavailable, "no instance responded")
+	}
+	return results[0].Response, nil
+}
+
+// Call carries the state of a NATS request handled by a gRPC implementation.
+// It implements grpc.ServerTransportStream, so that implementations can use grpc.SetHeader and grpc.SetTrailer.
+type Call struct {
+	method  string
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+// NewCall returns the context passed to the gRPC implementation for request, which contains the NATS headers
+// of the request as incoming metadata, and the call collecting the metadata set by the implementation.
+// The context ends once the client stopped waiting for the response, see gonats.RequestContext, or when Cancel is called.
+// method is the full gRPC method name, in the form /package.Service/Method.
+func NewCall(request micro.Request, method string) (context.Context, *Call) {
+	ctx, cancel := gonats.RequestContext(request)
+	call := &Call{method: method, cancel: cancel}
+	md := metadata.MD{}
+	for k, values := range request.Headers() {
+		k = strings.ToLower(k)
+		for _, v := range values {
+			if strings.HasSuffix(k, "-bin") {
+				if decoded, err := base64.StdEncoding.DecodeString(v); err == nil {
+					v = string(decoded)
+				}
+			}
+			md.Append(k, v)
+		}
+	}
+	// Only pass on the signer if the signature is valid
+	signerKey := strings.ToLower(gonats.SignerHeader)
+	md.Delete(signerKey)
+	if signer := gonats.SignerOf(request); signer != "" {
+		md.Set(signerKey, signer)
+	}
+	ctx = metadata.NewIncomingContext(ctx, md)
+	return grpc.NewContextWithServerTransportStream(ctx, call), call
+}
+
+// Cancel ends the context of the call, once the implementation returned.
+func (c *Call) Cancel() {
+	c.cancel()
+}
+
+// Method returns the full gRPC method name of the call.
+func (c *Call) Method() string {
+	return c.method
+}
+
+// SetHeader adds md to the headers of the response.
+func (c *Call) SetHeader(md metadata.MD) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.header = metadata.Join(c.header, md)
+	return nil
+}
+
+// SendHeader adds md to the headers of the response, as NATS responses can't send headers ahead of the response.
+func (c *Call) SendHeader(md metadata.MD) error {
+	return c.SetHeader(md)
+}
+
+// SetTrailer adds md to the headers of the response, as NATS responses don't have trailers.
+func (c *Call) SetTrailer(md metadata.MD) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.trailer = metadata.Join(c.trailer, md)
+	return nil
+}
+
+// Headers returns a micro.RespondOpt adding the header and trailer metadata set by the implementation to the response.
+// Binary metadata (with a -bin suffix) is base64 encoded.
+func (c *Call) Headers() micro.RespondOpt {
+	return func(m *nats.Msg) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, md := range []metadata.MD{c.header, c.trailer} {
+			for k, values := range md {
+				if m.Header == nil {
+					m.Header = nats.Header{}
+				}
+				for _, v := range values {
+					if strings.HasSuffix(k, "-bin") {
+						v = base64.StdEncoding.EncodeToString([]byte(v))
+					}
+					m.Header.Add(k, v)
+				}
+			}
+		}
+	}
+}
+
+// RespondError responds to request with the error returned by a gRPC implementation.
+// gRPC status errors are translated to ServerError codes and carry their original code in the StatusHeader,
+// ServerErrors are sent as they are and all other errors are sent as internal server error.
+func RespondError(request micro.Request, err error, opts ...micro.RespondOpt) {
+	var serverErr protonats.ServerError
+	if errors.As(err, &serverErr) {
+		_ = request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), append([]micro.RespondOpt{serverErr.GetOptHeaders()}, opts...)...)
+		return
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		if protonats.IsServiceError(err) {
+			slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
+		}
+		_ = request.Error(strconv.Itoa(http.StatusInternalServerError), "Internal server error", []byte(err.Error()), opts...)
+		return
+	}
+	opts = append(slices.Clone(opts), func(m *nats.Msg) {
+		if m.Header == nil {
+			m.Header = nats.Header{}
+		}
+		m.Header.Set(StatusHeader, strconv.Itoa(int(st.Code())))
+	})
+	_ = request.Error(Code(st.Code()), st.Message(), nil, opts...)
+}
@@ -0,0 +1,292 @@
+package gonats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"xiam.li/protonats/go/protonats"
+)
+
+// DefaultHTTPMaxBodySize is the maximum size of request bodies accepted by a generated HTTP gateway, unless set with WithHTTPMaxBodySize.
+const DefaultHTTPMaxBodySize = 4 << 20
+
+// HTTPConfig is the configuration of a generated HTTP gateway, created from its options with NewHTTPConfig.
+type HTTPConfig struct {
+	// MaxBodySize is the maximum size of request bodies in bytes, larger bodies are rejected with 413
+	MaxBodySize int64
+	// Timeout is the timeout of calls, which also end when the HTTP request is canceled
+	Timeout time.Duration
+	// InstanceSelection allows requests to select instances with the InstanceHeader
+	InstanceSelection bool
+}
+
+// HTTPOption configures a generated HTTP gateway.
+type HTTPOption func(*HTTPConfig)
+
+// NewHTTPConfig returns the configuration of a generated HTTP gateway with opts applied.
+func NewHTTPConfig(opts ...HTTPOption) *HTTPConfig {
+	cfg := &HTTPConfig{MaxBodySize: DefaultHTTPMaxBodySize, Timeout: 5 * time.Second}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithHTTPMaxBodySize sets the maximum size of request bodies in bytes, replacing DefaultHTTPMaxBodySize.
+func WithHTTPMaxBodySize(size int64) HTTPOption {
+	return func(cfg *HTTPConfig) {
+		cfg.MaxBodySize = size
+	}
+}
+
+// WithHTTPTimeout sets the timeout of calls made by the gateway, which is five seconds by default like that of generated clients.
+func WithHTTPTimeout(timeout time.Duration) HTTPOption {
+	return func(cfg *HTTPConfig) {
+		cfg.Timeout = timeout
+	}
+}
+
+// WithHTTPInstanceSelection allows HTTP clients to select the instances a call is sent to with the InstanceHeader.
+// Without it, the header is ignored, as the gateway may be exposed to clients that shouldn't be able to pick instances.
+func WithHTTPInstanceSelection() HTTPOption {
+	return func(cfg *HTTPConfig) {
+		cfg.InstanceSelection = true
+	}
+}
+
+// HTTPBinding describes how an HTTP request is mapped onto the request message of a method.
+type HTTPBinding struct {
+	// PathFields maps the wildcards of the route pattern to the (dot separated) field paths they are stored in
+	PathFields map[string]string
+	// Body is the field the request body is decoded into, "*" for the whole message or empty if the body is ignored
+	Body string
+}
+
+// DecodeRequest fills msg from the path wildcards, body and query parameters of r according to binding.
+// Query parameters are only used if the body isn't decoded into the whole message.
+// Errors are returned as ServiceError with code 400, or 413 if the body is larger than MaxBodySize.
+func (cfg *HTTPConfig) DecodeRequest(w http.ResponseWriter, r *http.Request, binding HTTPBinding, msg proto.Message) error {
+	if binding.Body != "" {
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, cfg.MaxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return protonats.ServiceError{Code: strconv.Itoa(http.StatusRequestEntityTooLarge), Description: "Request body too large", Details: err.Error()}
+			}
+			return badRequest(err)
+		}
+		if len(data) > 0 {
+			target := msg.ProtoReflect()
+			if binding.Body != "*" {
+				field, parent, err := resolveFieldPath(target, binding.Body)
+				if err != nil {
+					return badRequest(err)
+				}
+				if field.Message() == nil {
+					return badRequest(errors.New("body field " + binding.Body + " is not a message"))
+				}
+				target = parent.Mutable(field).Message()
+			}
+			if err := protojson.Unmarshal(data, target.Interface()); err != nil {
+				return badRequest(err)
+			}
+		}
+	}
+	for wildcard, path := range binding.PathFields {
+		if err := setFieldPath(msg.ProtoReflect(), path, r.PathValue(wildcard)); err != nil {
+			return badRequest(err)
+		}
+	}
+	if binding.Body != "*" {
+		for key, values := range r.URL.Query() {
+			for _, v := range values {
+				if err := setFieldPath(msg.ProtoReflect(), key, v); err != nil {
+					return badRequest(err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func badRequest(err error) error {
+	return protonats.ServiceError{Code: strconv.Itoa(http.StatusBadRequest), Description: "Invalid request", Details: err.Error()}
+}
+
+// resolveFieldPath returns the field a dot separated path points to, along with the message containing it.
+func resolveFieldPath(msg protoreflect.Message, path string) (protoreflect.FieldDescriptor, protoreflect.Message, error) {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			field = msg.Descriptor().Fields().ByJSONName(name)
+		}
+		if field == nil {
+			return nil, nil, errors.New("unknown field " + path)
+		}
+		if i == len(names)-1 {
+			return field, msg, nil
+		}
+		if field.Message() == nil || field.IsList() || field.IsMap() {
+			return nil, nil, errors.New(name + " in " + path + " is not a message")
+		}
+		msg = msg.Mutable(field).Message()
+	}
+	return nil, nil, errors.New("empty field path")
+}
+
+// setFieldPath parses v and stores it in the scalar field path points to, appending to repeated fields.
+func setFieldPath(msg protoreflect.Message, path, v string) error {
+	field, parent, err := resolveFieldPath(msg, path)
+	if err != nil {
+		return err
+	}
+	if field.IsMap() || field.Message() != nil {
+		return errors.New(path + " is not a scalar field")
+	}
+	value, err := parseScalar(field, v)
+	if err != nil {
+		return errors.New("invalid value for " + path + ": " + err.Error())
+	}
+	if field.IsList() {
+		parent.Mutable(field).List().Append(value)
+	} else {
+		parent.Set(field, value)
+	}
+	return nil
+}
+
+// CallOptions returns the options of the call for r, passing on its context limited to Timeout,
+// so that the call ends when the HTTP request is canceled. The returned function has to be called once the call is done.
+// With InstanceSelection, a single instance can be selected with the InstanceHeader.
+func (cfg *HTTPConfig) CallOptions(r *http.Request) ([]protonats.CallOption, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(r.Context(), cfg.Timeout)
+	opts := []protonats.CallOption{protonats.WithContext(ctx), protonats.WithTimeout(cfg.Timeout)}
+	if id := r.Header.Get(InstanceHeader); cfg.InstanceSelection && id != "" && !strings.Contains(id, ",") {
+		opts = append(opts, protonats.WithInstanceID(id))
+	}
+	return opts, cancel
+}
+
+// Target returns the instances selected by the comma separated InstanceHeader of r for a targeted broadcast,
+// if any are selected and InstanceSelection is enabled.
+func (cfg *HTTPConfig) Target(r *http.Request) (Target, bool) {
+	header := r.Header.Get(InstanceHeader)
+	if !cfg.InstanceSelection || header == "" {
+		return Target{}, false
+	}
+	var ids []string
+	for _, id := range strings.Split(header, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return Instances(ids...), true
+}
+
+// HTTPStatus returns the HTTP status for the code of a ServiceError.
+// Codes which are valid HTTP error statuses are used as they are, OverloadedCode results in 503 Service Unavailable,
+// and all others in 502 Bad Gateway.
+func HTTPStatus(code string) int {
+	if code == OverloadedCode {
+		return http.StatusServiceUnavailable
+	}
+	status, err := strconv.Atoi(code)
+	if err != nil || status < 400 || status > 599 || http.StatusText(status) == "" {
+		return http.StatusBadGateway
+	}
+	return status
+}
+
+// httpError is the JSON representation of an error returned by the gateway.
+type httpError struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Details     string `json:"details,omitempty"`
+}
+
+func toHTTPError(err error) (int, httpError) {
+	if serviceErr, ok := protonats.AsServiceError(err); ok {
+		return HTTPStatus(serviceErr.Code), httpError{Code: serviceErr.Code, Description: serviceErr.Description, Details: serviceErr.Details}
+	}
+	switch {
+	case errors.Is(err, nats.ErrNoResponders):
+		return http.StatusServiceUnavailable, httpError{Code: strconv.Itoa(http.StatusServiceUnavailable), Description: "No instance available", Details: err.Error()}
+	case errors.Is(err, nats.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, httpError{Code: strconv.Itoa(http.StatusGatewayTimeout), Description: "Request timed out", Details: err.Error()}
+	default:
+		return http.StatusBadGateway, httpError{Code: strconv.Itoa(http.StatusBadGateway), Description: "Failed to call service", Details: err.Error()}
+	}
+}
+
+// WriteHTTPError writes err as JSON with the matching HTTP status.
+func WriteHTTPError(w http.ResponseWriter, err error) {
+	status, body := toHTTPError(err)
+	if retryAfter, ok := RetryAfter(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+	}
+	writeJSON(w, status, body)
+}
+
+// WriteHTTPResponse writes msg as JSON, or an empty object if msg is nil.
+func WriteHTTPResponse(w http.ResponseWriter, msg proto.Message) {
+	data := []byte("{}")
+	if msg != nil {
+		var err error
+		if data, err = protojson.Marshal(msg); err != nil {
+			WriteHTTPError(w, err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// httpResult is the JSON representation of a single BroadcastResult.
+type httpResult struct {
+	InstanceID string          `json:"instance_id"`
+	RTT        string          `json:"rtt"`
+	Response   json.RawMessage `json:"response,omitempty"`
+	Error      *httpError      `json:"error,omitempty"`
+}
+
+// WriteHTTPResults writes the results of a broadcast as JSON array.
+// The status is always 200, errors of single instances are contained in their result.
+func WriteHTTPResults[T any](w http.ResponseWriter, results []BroadcastResult[T]) {
+	out := make([]httpResult, 0, len(results))
+	for _, r := range results {
+		result := httpResult{InstanceID: r.InstanceID, RTT: r.RTT.String()}
+		if r.Err != nil {
+			_, body := toHTTPError(r.Err)
+			result.Error = &body
+		} else if msg, ok := any(r.Response).(proto.Message); ok {
+			data, err := protojson.Marshal(msg)
+			if err != nil {
+				WriteHTTPError(w, err)
+				return
+			}
+			result.Response = data
+		} else {
+			result.Response = json.RawMessage("{}")
+		}
+		out = append(out, result)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,141 @@
+package gonats
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nats-io/nats.go/micro"
+	"xiam.li/protonats/go/impl"
+	"xiam.li/protonats/go/protonats"
+)
+
+const (
+	// RoleEndpoint is the name of the endpoint reporting the current role of an instance,
+	// registered by generated servers of services with consensus_target methods.
+	RoleEndpoint = "Role"
+	// RoleMetadata is the metadata key the role of an instance is advertised under in $SRV.INFO,
+	// if it is fixed at creation with WithoutLeaderFns or WithoutFollowerFns.
+	// micro can't update the metadata of a running service, so the $SRV.INFO of instances switching roles doesn't contain it,
+	// and only the Info method of their TrackedService reports their current role. Other processes have to ask the RoleEndpoint,
+	// e.g. with the Leader method of generated clients.
+	RoleMetadata = "protonats.role"
+	// LeaderInstance is the instance ID set by WithLeader, which generated clients replace with the ID of the current leader.
+	LeaderInstance = "$LEADER"
+)
+
+var (
+	// ErrNoLeader is returned by the Leader method of generated clients if no instance reports to be the leader.
+	ErrNoLeader = errors.New("no instance of the service is the leader")
+	// ErrLeaderUnsupported is returned by generated clients for calls with WithLeader that can't be pinned to the leader,
+	// which are durable calls and calls to services without consensus_target methods.
+	ErrLeaderUnsupported = errors.New("the call can't be pinned to the leader of the service")
+)
+
+// RoleSubject returns the subject of the role endpoint of the service with the given Go name.
+func RoleSubject(service string) string {
+	return "service." + service + ".$ROLE"
+}
+
+// WithLeader pins a call of a generated client to the direct endpoint of the current leader of the service.
+// The leader is discovered with the Leader method of the client. For unary calls it is cached until a call to it fails,
+// in which case it is discovered again and the call repeated once,
+// while one-way, broadcasting, Ping, Stats and Info calls, which can't tell a former leader from the current one, discover it every time.
+func WithLeader() protonats.CallOption {
+	return protonats.WithInstanceID(LeaderInstance)
+}
+
+// ResolveLeader replaces the instance ID set by WithLeader in opts with the ID returned by leader,
+// or returns ErrLeaderUnsupported if leader is nil. opts without WithLeader are returned as they are.
+func ResolveLeader(opts []protonats.CallOption, leader func() (string, error)) ([]protonats.CallOption, error) {
+	if impl.ProcessCallOptions(opts...).InstanceID != LeaderInstance {
+		return opts, nil
+	}
+	if leader == nil {
+		return nil, ErrLeaderUnsupported
+	}
+	id, err := leader()
+	if err != nil {
+		return nil, err
+	}
+	return append(opts[:len(opts):len(opts)], protonats.WithInstanceID(id)), nil
+}
+
+// RoleHandler returns the handler of the RoleEndpoint, responding with the current role of the instance.
+func (f *InFlight) RoleHandler() micro.Handler {
+	return micro.HandlerFunc(func(request micro.Request) {
+		_ = request.Respond([]byte(f.Role()), WithInstanceHeader(f.id))
+	})
+}
+
+// staticRole returns the role of an instance whose role endpoints were registered once according to opts,
+// or an empty role if it serves both or neither of them.
+func staticRole(opts *protonats.ServerOptions) Role {
+	switch {
+	case opts.WithoutFollowerFunctions && !opts.WithoutLeaderFunctions:
+		return RoleLeader
+	case opts.WithoutLeaderFunctions && !opts.WithoutFollowerFunctions:
+		return RoleFollower
+	}
+	return ""
+}
+
+// LeaderOf returns the ID of the instance reporting RoleLeader among the responses of the RoleEndpoint.
+// During a change of leadership, two instances may report to be the leader, of which the fastest to respond is returned.
+func LeaderOf(results []BroadcastResult[Role]) (string, error) {
+	var leader *BroadcastResult[Role]
+	for i, result := range results {
+		if result.Err != nil || result.Response != RoleLeader || result.InstanceID == "" {
+			continue
+		}
+		if leader == nil || result.RTT < leader.RTT {
+			leader = &results[i]
+		}
+	}
+	if leader == nil {
+		return "", ErrNoLeader
+	}
+	return leader.InstanceID, nil
+}
+
+// LeaderCache caches the ID of the leader of a service for a generated client.
+type LeaderCache struct {
+	mu sync.Mutex
+	id string
+}
+
+// Get returns the cached leader, or discovers it with discover if none is cached.
+func (c *LeaderCache) Get(discover func() (string, error)) (string, error) {
+	c.mu.Lock()
+	id := c.id
+	c.mu.Unlock()
+	if id != "" {
+		return id, nil
+	}
+	return discover()
+}
+
+// Set caches id as the leader.
+func (c *LeaderCache) Set(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.id = id
+}
+
+// Failed forgets the cached leader id if err shows that it may no longer be the leader, and reports whether it did.
+// These are all errors except those returned by the implementation, apart from ShuttingDownCode and NoLeaderCode,
+// like nats.ErrNoResponders once the leader stopped or nats.ErrTimeout if it is unreachable.
+func (c *LeaderCache) Failed(id string, err error) bool {
+	if err == nil {
+		return false
+	}
+	var serviceErr protonats.ServiceError
+	if errors.As(err, &serviceErr) && serviceErr.Code != ShuttingDownCode && serviceErr.Code != NoLeaderCode {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.id == id {
+		c.id = ""
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package gonats
+
+import (
+	"log/slog"
+
+	"github.com/nats-io/nats.go/micro"
+)
+
+// oneWay returns handler discarding all responses to the requests of a one-way method, logging errors instead.
+func oneWay(method string, handler micro.Handler) micro.Handler {
+	return micro.HandlerFunc(func(request micro.Request) {
+		handler.Handle(oneWayRequest{Request: request, method: method})
+	})
+}
+
+// oneWayRequest is a request to a one-way method, which is never responded to.
+type oneWayRequest struct {
+	micro.Request
+	method string
+}
+
+func (r oneWayRequest) Respond([]byte, ...micro.RespondOpt) error {
+	return nil
+}
+
+func (r oneWayRequest) RespondJSON(any, ...micro.RespondOpt) error {
+	return nil
+}
+
+func (r oneWayRequest) Error(code, description string, data []byte, _ ...micro.RespondOpt) error {
+	slog.Warn("One-way request failed", "method", r.method, "code", code, "description", description, "details", string(data))
+	return nil
+}
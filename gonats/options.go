@@ -0,0 +1,101 @@
+package gonats
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nats-io/nkeys"
+	"xiam.li/protonats/go/protonats"
+)
+
+// ServerConfig contains the options of a generated server, set with the ServerOption passed to its New...Server function.
+type ServerConfig struct {
+	// ServerOptions are the options of protonats, set with WithoutLeaderFns, WithoutFollowerFns, WithExtraSubject or WithServerOptions.
+	protonats.ServerOptions
+	// Pool configures the worker pool of every endpoint, unless the method declares its own with the worker_pool option.
+	Pool *WorkerPool
+	// RateLimits overrides the rate_limit option of methods, by the name of the method.
+	RateLimits map[string]*RateLimit
+	// Authorizer is called for every request before it is passed to the implementation.
+	Authorizer Authorizer
+	// TrustedIssuers are the public keys of the accounts and signing keys whose user JWTs are passed to the Authorizer.
+	TrustedIssuers []string
+	// TrustedSigners are the public keys of the NKeys every request must be signed with.
+	TrustedSigners []string
+	// XKey is the curve key pair requests are encrypted to, advertised under XKeyMetadata.
+	XKey nkeys.KeyPair
+	// ElectionTTL is the TTL of the leader key if leader election is enabled with WithLeaderElection.
+	ElectionTTL time.Duration
+	// Role is the initial role of an instance whose role is switched manually, set with WithRole.
+	Role Role
+	// ForwardToLeader makes followers forward requests to methods with consensus_target LEADER to the leader, set with WithLeaderForwarding.
+	ForwardToLeader bool
+	// ForwarderSigner is the NKey followers sign forwarded requests with, set with WithForwarderSigner.
+	ForwarderSigner nkeys.KeyPair
+}
+
+// ServerOption configures a generated server. It is the ServerOption of protonats, so that the options of gonats
+// can be passed to New...Server functions along with those of protonats.
+// The options of gonats only apply to servers created by NewService, other servers ignore them.
+type ServerOption = protonats.ServerOption
+
+// configs contains the ServerConfig of the servers being created by NewService, by the address of their ServerOptions.
+var configs sync.Map
+
+// configure returns a ServerOption applying fn to the ServerConfig of a server created by NewService.
+func configure(fn func(*ServerConfig)) ServerOption {
+	return func(opts *protonats.ServerOptions) {
+		if cfg, ok := configs.Load(opts); ok {
+			fn(cfg.(*ServerConfig))
+		}
+	}
+}
+
+// newServerConfig returns the ServerConfig set by opts.
+func newServerConfig(opts ...ServerOption) ServerConfig {
+	var cfg ServerConfig
+	configs.Store(&cfg.ServerOptions, &cfg)
+	defer configs.Delete(&cfg.ServerOptions)
+	for _, opt := range opts {
+		opt(&cfg.ServerOptions)
+	}
+	return cfg
+}
+
+// WithoutLeaderFns doesn't serve the methods with consensus_target LEADER, like protonats.WithoutLeaderFns.
+func WithoutLeaderFns() ServerOption {
+	return protonats.WithoutLeaderFns()
+}
+
+// WithoutFollowerFns doesn't serve the methods with consensus_target FOLLOWER, like protonats.WithoutFollowerFns.
+func WithoutFollowerFns() ServerOption {
+	return protonats.WithoutFollowerFns()
+}
+
+// WithExtraSubject appends subject to the subjects of all endpoints, like protonats.WithExtraSubjectSrv.
+func WithExtraSubject(subject string) ServerOption {
+	return protonats.WithExtraSubjectSrv(subject)
+}
+
+// WithServerOptions combines opts, options of protonats or gonats, into a single option.
+func WithServerOptions(opts ...ServerOption) ServerOption {
+	return func(o *protonats.ServerOptions) {
+		for _, opt := range opts {
+			opt(o)
+		}
+	}
+}
+
+// EndpointConfig contains the options declared for the method of an endpoint, passed to InFlight.Handler by generated servers.
+type EndpointConfig struct {
+	// Method is the name of the method, shared by all of its endpoints.
+	Method string
+	// Pool is the worker_pool option of the method.
+	Pool *WorkerPool
+	// RateLimit is the rate_limit option of the method.
+	RateLimit *RateLimit
+	// Roles is the required_roles option of the method.
+	Roles []string
+	// OneWay is the one_way option of the method.
+	OneWay bool
+}
@@ -0,0 +1,83 @@
+package gonats
+
+import (
+	"errors"
+	"sync"
+)
+
+// OverloadedCode is the code of the error returned for requests rejected because the worker pool of the endpoint is saturated.
+// Unlike ShuttingDownCode, it doesn't make clients forget the instance, as it will accept requests again once its workers catch up.
+// As 529 isn't a standard HTTP status, the HTTP gateway responds with 503 and grpcnats translates it to ResourceExhausted.
+const OverloadedCode = "529"
+
+// WithWorkerPool runs the handlers of every endpoint on a pool of workers, instead of the delivery goroutine of its subscription,
+// so that a slow request doesn't block the following ones.
+// Up to queue requests wait for a free worker, further requests are rejected with OverloadedCode.
+func WithWorkerPool(workers, queue int) ServerOption {
+	return configure(func(cfg *ServerConfig) {
+		cfg.Pool = &WorkerPool{Workers: uint32(max(workers, 0)), Queue: uint32(max(queue, 0))}
+	})
+}
+
+var (
+	// errPoolFull is returned by workerPool.submit if all workers are busy and the queue is full.
+	errPoolFull = errors.New("worker pool is full")
+	// errPoolClosed is returned by workerPool.submit once the pool was closed.
+	errPoolClosed = errors.New("worker pool is closed")
+)
+
+// workerPool runs handlers on a bounded number of goroutines.
+type workerPool struct {
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan func()
+}
+
+// newWorkerPool starts the workers of the pool configured by cfg, or returns nil if it has no workers.
+func newWorkerPool(cfg *WorkerPool) *workerPool {
+	if cfg.GetWorkers() == 0 {
+		return nil
+	}
+	p := &workerPool{jobs: make(chan func(), cfg.GetQueue())}
+	for range cfg.GetWorkers() {
+		go func() {
+			for job := range p.jobs {
+				job()
+			}
+		}()
+	}
+	return p
+}
+
+// submit runs job on the pool, or directly if p is nil.
+// It returns errPoolFull if all workers are busy and the queue is full, or errPoolClosed once the pool was closed.
+func (p *workerPool) submit(job func()) error {
+	if p == nil {
+		job()
+		return nil
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return errPoolClosed
+	}
+	select {
+	case p.jobs <- job:
+		return nil
+	default:
+		return errPoolFull
+	}
+}
+
+// close stops the workers once the queued requests are handled.
+func (p *workerPool) close() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+}
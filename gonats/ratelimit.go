@@ -0,0 +1,111 @@
+package gonats
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// RateLimitedCode is the code of the error returned for requests exceeding the rate limit of a method.
+	RateLimitedCode = "429"
+	// RetryAfterHeader contains the duration after which a rate limited request can be retried, formatted by time.Duration.String.
+	RetryAfterHeader = "Protonats-Retry-After"
+)
+
+// WithRateLimit overrides the rate limit of method, declared with the rate_limit option, for this instance.
+// A rps of 0 or less disables the rate limit of the method.
+func WithRateLimit(method string, rps float64, burst int) ServerOption {
+	return configure(func(cfg *ServerConfig) {
+		if cfg.RateLimits == nil {
+			cfg.RateLimits = make(map[string]*RateLimit)
+		}
+		cfg.RateLimits[method] = &RateLimit{Rps: rps, Burst: uint32(max(burst, 0))}
+	})
+}
+
+// limiters contains the rate limiters of the methods of a server, shared by all endpoints of a method.
+type limiters struct {
+	mu       sync.Mutex
+	byMethod map[string]*rate.Limiter
+}
+
+// get returns the limiter of method, creating it with limit for the first endpoint of the method.
+func (l *limiters) get(method string, limit *RateLimit) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limiter, ok := l.byMethod[method]
+	if !ok {
+		limiter = newLimiter(limit)
+		l.byMethod[method] = limiter
+	}
+	return limiter
+}
+
+// newLimiter returns the token bucket configured by limit, or nil if it doesn't limit the requests.
+func newLimiter(limit *RateLimit) *rate.Limiter {
+	if limit.GetRps() <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(limit.GetRps()), int(max(limit.GetBurst(), 1)))
+}
+
+// rateLimited returns handler rejecting requests with RateLimitedCode and the RetryAfterHeader once limiter is exhausted.
+func rateLimited(limiter *rate.Limiter, handler micro.Handler, opts ...micro.RespondOpt) micro.Handler {
+	if limiter == nil {
+		return handler
+	}
+	return micro.HandlerFunc(func(request micro.Request) {
+		reservation := limiter.Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+			_ = request.Error(RateLimitedCode, "Rate limit exceeded", nil, append(slices.Clone(opts), withHeader(RetryAfterHeader, delay.String()))...)
+			return
+		}
+		handler.Handle(request)
+	})
+}
+
+// RetryAfterError is returned by generated clients for errors carrying a RetryAfterHeader.
+// It wraps the protonats.ServiceError, so protonats.AsServiceError still works.
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// WithRetryAfter wraps err in a RetryAfterError if header contains a RetryAfterHeader.
+func WithRetryAfter(err error, header nats.Header) error {
+	d, parseErr := time.ParseDuration(header.Get(RetryAfterHeader))
+	if parseErr != nil {
+		return err
+	}
+	return RetryAfterError{Err: err, RetryAfter: d}
+}
+
+// RetryAfter returns the duration after which the call that returned err can be retried, if err is a RetryAfterError.
+func RetryAfter(err error) (time.Duration, bool) {
+	var retryErr RetryAfterError
+	if !errors.As(err, &retryErr) {
+		return 0, false
+	}
+	return retryErr.RetryAfter, true
+}
+
+// retryAfterSeconds formats d as the value of an HTTP Retry-After header, rounded up to whole seconds.
+func retryAfterSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
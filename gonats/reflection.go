@@ -0,0 +1,70 @@
+package gonats
+
+import (
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	// ReflectionEndpoint is the name of the reflection endpoint registered by every generated server.
+	ReflectionEndpoint = "Reflect"
+	// ServiceHeader contains the full proto name of the service in responses of the reflection endpoint.
+	ServiceHeader = "Protonats-Service"
+)
+
+// ReflectionSubject returns the subject of the reflection endpoint of the service with the given Go name.
+func ReflectionSubject(service string) string {
+	return "service." + service + ".$REFLECT"
+}
+
+// FileDescriptorSet returns the file and all of its transitive dependencies, with every file following its dependencies.
+func FileDescriptorSet(file protoreflect.FileDescriptor) *descriptorpb.FileDescriptorSet {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]struct{})
+	var add func(protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if _, ok := seen[fd.Path()]; ok || fd.IsPlaceholder() {
+			return
+		}
+		seen[fd.Path()] = struct{}{}
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	add(file)
+	return set
+}
+
+// ReflectionHandler returns a handler responding with the serialized FileDescriptorSet of the file declaring the service.
+// The service has to be registered in protoregistry.GlobalFiles, which generated code does upon initialization.
+func ReflectionHandler(service protoreflect.FullName, opts ...micro.RespondOpt) (micro.Handler, error) {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(service)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := desc.(protoreflect.ServiceDescriptor); !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	data, err := proto.Marshal(FileDescriptorSet(desc.ParentFile()))
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts[:len(opts):len(opts)], func(m *nats.Msg) {
+		if m.Header == nil {
+			m.Header = nats.Header{}
+		}
+		m.Header.Set(ServiceHeader, string(service))
+	})
+	return micro.HandlerFunc(func(request micro.Request) {
+		_ = request.Respond(data, opts...)
+	}), nil
+}
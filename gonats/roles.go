@@ -0,0 +1,383 @@
+package gonats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+)
+
+// ErrRoleNotSwitchable is returned by SetRole for servers that weren't created with WithRole.
+var ErrRoleNotSwitchable = errors.New("role of the instance can't be switched, as it wasn't created with gonats.WithRole")
+
+// Role is the consensus role of an instance of a generated server, deciding which of the methods with a consensus_target it serves.
+type Role string
+
+const (
+	// RoleLeader serves the methods with consensus_target LEADER.
+	RoleLeader Role = "leader"
+	// RoleFollower serves the methods with consensus_target FOLLOWER.
+	RoleFollower Role = "follower"
+)
+
+// WithRole serves only the methods with the consensus_target of role, until it is switched with SetRole of RoleOf the
+// generated server, e.g. when your own consensus algorithm elects a new leader.
+// Unlike WithoutLeaderFns and WithoutFollowerFns, the endpoints can be switched without recreating the service or changing its instance ID.
+func WithRole(role Role) ServerOption {
+	return configure(func(cfg *ServerConfig) {
+		cfg.Role = role
+	})
+}
+
+// Roles switches the role of a running generated server, returned by RoleOf.
+type Roles struct {
+	inFlight *InFlight
+}
+
+// RoleOf returns the Roles of service, returned by a generated New...Server function.
+// For other services, Role returns an empty role and switching roles returns ErrRoleNotSwitchable.
+func RoleOf(service micro.Service) Roles {
+	inFlight, _ := inFlightOf(service)
+	return Roles{inFlight: inFlight}
+}
+
+// Role returns the current role of the instance, which is empty unless it was created with WithRole or WithLeaderElection.
+func (r Roles) Role() Role {
+	if r.inFlight == nil {
+		return ""
+	}
+	return r.inFlight.Role()
+}
+
+// SetRole switches the endpoints of the methods with a consensus_target to those of role, keeping the service and its instance ID.
+// It returns ErrRoleNotSwitchable unless the server was created with WithRole.
+func (r Roles) SetRole(role Role) error {
+	if r.inFlight == nil {
+		return ErrRoleNotSwitchable
+	}
+	return r.inFlight.SetRole(role)
+}
+
+// BecomeLeader switches the instance to serve the leader methods instead of the follower methods.
+func (r Roles) BecomeLeader() error {
+	return r.SetRole(RoleLeader)
+}
+
+// BecomeFollower switches the instance to serve the follower methods instead of the leader methods.
+func (r Roles) BecomeFollower() error {
+	return r.SetRole(RoleFollower)
+}
+
+// roleEndpoint is an endpoint of a method with a consensus_target, subscribed only while the instance has its role.
+// micro can't remove endpoints from a running service, so these endpoints are subscribed by the InFlight instead,
+// which keeps their stats like micro does for its endpoints.
+type roleEndpoint struct {
+	role       Role
+	name       string
+	subject    string
+	queueGroup string
+	handler    micro.Handler
+	sub        *nats.Subscription
+
+	mu    sync.Mutex
+	stats micro.EndpointStats
+}
+
+// handled records a request handled within d.
+func (e *roleEndpoint) handled(d time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stats.NumRequests++
+	e.stats.ProcessingTime += d
+	e.stats.AverageProcessingTime = e.stats.ProcessingTime / time.Duration(e.stats.NumRequests)
+}
+
+// failed records an error, formatted like micro does.
+func (e *roleEndpoint) failed(err string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stats.NumErrors++
+	e.stats.LastError = err
+}
+
+// info returns the info of e as reported by micro, with its role under RoleMetadata.
+func (e *roleEndpoint) info() micro.EndpointInfo {
+	return micro.EndpointInfo{
+		Name:       e.name,
+		Subject:    e.subject,
+		QueueGroup: e.queueGroup,
+		Metadata:   map[string]string{RoleMetadata: string(e.role)},
+	}
+}
+
+// snapshot returns a copy of the stats of e.
+func (e *roleEndpoint) snapshot() *micro.EndpointStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	stats := e.stats
+	return &stats
+}
+
+// reset resets the stats of e.
+func (e *roleEndpoint) reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stats = micro.EndpointStats{Name: e.name, Subject: e.subject, QueueGroup: e.queueGroup}
+}
+
+// RoleStatsData is the data reported in the stats of the RoleEndpoint: the current role of the instance and the stats
+// of the endpoints of its role, which $SRV.INFO and the stats of micro don't contain, see TrackedService.
+type RoleStatsData struct {
+	// Role is the current role of the instance.
+	Role Role `json:"role"`
+	// Endpoints are the stats of the endpoints currently subscribed for the role.
+	Endpoints []*micro.EndpointStats `json:"endpoints,omitempty"`
+}
+
+// roles contains the role of an instance and the endpoints switched with it.
+type roles struct {
+	mu             sync.Mutex
+	role           Role
+	endpoints      []*roleEndpoint
+	draining       []*nats.Subscription
+	cancelElection context.CancelFunc
+	electionDone   chan struct{}
+}
+
+// DynamicRoles reports whether the endpoints of methods with a consensus_target are switched with the role of the instance,
+// instead of being added to the service once.
+func (f *InFlight) DynamicRoles() bool {
+	return f.cfg.dynamicRoles()
+}
+
+// dynamicRoles reports whether the role of the instance is decided by the election or switched with SetRole.
+func (cfg ServerConfig) dynamicRoles() bool {
+	return cfg.ElectionTTL > 0 || cfg.Role != ""
+}
+
+// AddRoleEndpoint adds an endpoint of a method with a consensus_target, which is only served while the instance has role.
+// Like impl.ServerOpts.Subject, id and the extra subject of the server are appended to subject.
+// An empty queueGroup uses the default queue group of micro.
+func (f *InFlight) AddRoleEndpoint(role Role, name string, handler micro.Handler, subject, id, queueGroup string) error {
+	if queueGroup == "" {
+		queueGroup = micro.DefaultQueueGroup
+	}
+	e := &roleEndpoint{role: role, name: name, subject: f.subject(subject, id), queueGroup: queueGroup, handler: handler}
+	e.reset()
+	f.roles.mu.Lock()
+	defer f.roles.mu.Unlock()
+	f.roles.endpoints = append(f.roles.endpoints, e)
+	if f.roles.role == role {
+		return f.subscribe(e)
+	}
+	return nil
+}
+
+// subject appends id, unless it is empty, and the extra subject of the server to subject, like impl.ServerOpts.Subject.
+func (f *InFlight) subject(subject, id string) string {
+	if id != "" {
+		subject += "." + id
+	}
+	if f.cfg.ExtraSubject != "" {
+		subject += "." + f.cfg.ExtraSubject
+	}
+	return subject
+}
+
+// Role returns the current role of the instance, which is empty until it is first decided.
+func (f *InFlight) Role() Role {
+	f.roles.mu.Lock()
+	defer f.roles.mu.Unlock()
+	return f.roles.role
+}
+
+// SetRole switches the role of the instance, adding the endpoints of the methods with the consensus_target of role
+// and draining those of the other role, and notifies the RoleChanged handler of the implementation if the role changed.
+// It returns ErrRoleNotSwitchable unless the server was created with WithRole, as the role is decided by the election with WithLeaderElection.
+func (f *InFlight) SetRole(role Role) error {
+	if f.cfg.Role == "" || f.cfg.ElectionTTL > 0 {
+		return ErrRoleNotSwitchable
+	}
+	return f.setRole(role)
+}
+
+// setRole switches the endpoints to role and notifies the RoleChanged handler of the implementation, if the role changed.
+func (f *InFlight) setRole(role Role) error {
+	changed, err := f.switchRole(role)
+	if changed {
+		if h, ok := f.impl.(interface{ RoleChanged(Role) }); ok {
+			h.RoleChanged(role)
+		}
+	}
+	return err
+}
+
+// switchRole subscribes the endpoints of role and drains the subscriptions of all others, letting their pending requests finish.
+func (f *InFlight) switchRole(role Role) (bool, error) {
+	f.roles.mu.Lock()
+	defer f.roles.mu.Unlock()
+	if f.roles.role == role {
+		return false, nil
+	}
+	f.roles.role = role
+	f.roles.draining = slices.DeleteFunc(f.roles.draining, func(sub *nats.Subscription) bool { return !sub.IsValid() })
+	var errs []error
+	for _, e := range f.roles.endpoints {
+		switch {
+		case e.role == role && e.sub == nil:
+			errs = append(errs, f.subscribe(e))
+		case e.role != role && e.sub != nil:
+			errs = append(errs, e.sub.Drain())
+			f.roles.draining = append(f.roles.draining, e.sub)
+			e.sub = nil
+		}
+	}
+	return true, errors.Join(errs...)
+}
+
+// waitRolesDrained waits until the subscriptions of the role endpoints drained by switching roles are closed,
+// which is once the requests pending in them were passed to their handlers, or until ctx is done.
+func (f *InFlight) waitRolesDrained(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		f.roles.mu.Lock()
+		f.roles.draining = slices.DeleteFunc(f.roles.draining, func(sub *nats.Subscription) bool { return !sub.IsValid() })
+		draining := len(f.roles.draining)
+		f.roles.mu.Unlock()
+		if draining == 0 {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// subscribe subscribes e, passing its requests to its handler. f.roles.mu must be held.
+func (f *InFlight) subscribe(e *roleEndpoint) error {
+	sub, err := f.nc.QueueSubscribe(e.subject, e.queueGroup, func(msg *nats.Msg) {
+		start := time.Now()
+		e.handler.Handle(msgRequest{msg: msg, endpoint: e})
+		e.handled(time.Since(start))
+	})
+	if err != nil {
+		return err
+	}
+	e.sub = sub
+	return nil
+}
+
+// activeRoleEndpoints returns the role endpoints currently subscribed.
+func (f *InFlight) activeRoleEndpoints() []*roleEndpoint {
+	f.roles.mu.Lock()
+	defer f.roles.mu.Unlock()
+	var active []*roleEndpoint
+	for _, e := range f.roles.endpoints {
+		if e.sub != nil {
+			active = append(active, e)
+		}
+	}
+	return active
+}
+
+// roleStats returns the stats of the role endpoints currently subscribed.
+func (f *InFlight) roleStats() []*micro.EndpointStats {
+	var stats []*micro.EndpointStats
+	for _, e := range f.activeRoleEndpoints() {
+		stats = append(stats, e.snapshot())
+	}
+	return stats
+}
+
+// watchRoleErrors passes the asynchronous errors of the subscriptions of role endpoints, like slow consumers,
+// to the Err handler of service, as micro only does so for its own endpoints. Unlike micro, the service isn't stopped.
+// Like the handler of micro, it is removed when the service is stopped.
+func (f *InFlight) watchRoleErrors(service micro.Service) {
+	next := f.nc.ErrorHandler()
+	f.nc.SetErrorHandler(func(c *nats.Conn, sub *nats.Subscription, err error) {
+		if e := f.roleEndpointOf(sub); e != nil {
+			e.failed(err.Error())
+			f.Err(service, &micro.NATSError{Subject: sub.Subject, Description: err.Error()})
+		}
+		if next != nil {
+			next(c, sub, err)
+		}
+	})
+}
+
+// roleEndpointOf returns the role endpoint subscribed with sub, or nil if sub belongs to none.
+func (f *InFlight) roleEndpointOf(sub *nats.Subscription) *roleEndpoint {
+	if sub == nil {
+		return nil
+	}
+	f.roles.mu.Lock()
+	defer f.roles.mu.Unlock()
+	for _, e := range f.roles.endpoints {
+		if e.sub == sub {
+			return e
+		}
+	}
+	return nil
+}
+
+// msgRequest is a request received on the subscription of a role endpoint, passed to the handlers of the endpoints as micro.Request.
+type msgRequest struct {
+	msg      *nats.Msg
+	endpoint *roleEndpoint
+}
+
+func (r msgRequest) Data() []byte {
+	return r.msg.Data
+}
+
+func (r msgRequest) Headers() micro.Headers {
+	return micro.Headers(r.msg.Header)
+}
+
+func (r msgRequest) Subject() string {
+	return r.msg.Subject
+}
+
+func (r msgRequest) Reply() string {
+	return r.msg.Reply
+}
+
+func (r msgRequest) Respond(data []byte, opts ...micro.RespondOpt) error {
+	response := &nats.Msg{Header: nats.Header{}, Data: data}
+	for _, opt := range opts {
+		opt(response)
+	}
+	return r.msg.RespondMsg(response)
+}
+
+func (r msgRequest) RespondJSON(response any, opts ...micro.RespondOpt) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return micro.ErrMarshalResponse
+	}
+	return r.Respond(data, opts...)
+}
+
+func (r msgRequest) Error(code, description string, data []byte, opts ...micro.RespondOpt) error {
+	if code == "" || description == "" {
+		return micro.ErrArgRequired
+	}
+	response := &nats.Msg{Header: nats.Header{}, Data: data}
+	response.Header.Set(micro.ErrorHeader, description)
+	response.Header.Set(micro.ErrorCodeHeader, code)
+	for _, opt := range opts {
+		opt(response)
+	}
+	if r.endpoint != nil {
+		r.endpoint.failed(code + ":" + description)
+	}
+	return r.msg.RespondMsg(response)
+}
@@ -0,0 +1,134 @@
+package gonats
+
+import (
+	"encoding/json"
+	"maps"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"xiam.li/protonats/go/impl"
+	"xiam.li/protonats/go/protonats"
+)
+
+// EndpointDescriptionMetadata is the endpoint metadata key the protonats.method_description option is reported under.
+const EndpointDescriptionMetadata = "description"
+
+// DefaultVersion is the version reported by services that don't declare one with the protonats.service_version option.
+const DefaultVersion = "0.0.1"
+
+// ServiceConfig contains the service options declared in the proto file.
+type ServiceConfig struct {
+	Version     string
+	Description string
+	Metadata    map[string]string
+}
+
+// TrackedService is the micro.Service of a generated server returned by NewService,
+// through which Shutdown and InFlightRequests find the InFlight of the server.
+type TrackedService struct {
+	micro.Service
+	inFlight *InFlight
+}
+
+// InFlight returns the InFlight of the server.
+func (s *TrackedService) InFlight() *InFlight {
+	return s.inFlight
+}
+
+// Info returns the info of the service, including the endpoints of methods with a consensus_target currently subscribed for the
+// role of the instance and the current role under RoleMetadata. micro doesn't know about either of them,
+// so on $SRV.INFO these endpoints are missing and the role is only advertised if it is fixed by the options of the server.
+func (s *TrackedService) Info() micro.Info {
+	info := s.Service.Info()
+	if role := s.inFlight.Role(); role != "" {
+		info.Metadata = maps.Clone(info.Metadata)
+		if info.Metadata == nil {
+			info.Metadata = map[string]string{}
+		}
+		info.Metadata[RoleMetadata] = string(role)
+	}
+	for _, e := range s.inFlight.activeRoleEndpoints() {
+		info.Endpoints = append(info.Endpoints, e.info())
+	}
+	return info
+}
+
+// Stats returns the stats of the service, including the endpoints of methods with a consensus_target currently subscribed
+// for the role of the instance, with their in-flight requests as EndpointStatsData.
+// On $SRV.STATS, they are only reported in the RoleStatsData of the RoleEndpoint.
+func (s *TrackedService) Stats() micro.Stats {
+	stats := s.Service.Stats()
+	for _, e := range s.inFlight.activeRoleEndpoints() {
+		endpoint := e.snapshot()
+		if data, err := json.Marshal(EndpointStatsData{InFlight: s.inFlight.requests.count(e.name)}); err == nil {
+			endpoint.Data = data
+		}
+		stats.Endpoints = append(stats.Endpoints, endpoint)
+	}
+	return stats
+}
+
+// Reset resets the stats of the service, including those of the endpoints of methods with a consensus_target.
+func (s *TrackedService) Reset() {
+	s.Service.Reset()
+	s.inFlight.roles.mu.Lock()
+	defer s.inFlight.roles.mu.Unlock()
+	for _, e := range s.inFlight.roles.endpoints {
+		e.reset()
+	}
+}
+
+// NewService creates the micro.Service of a generated server with the name, version, description and metadata of cfg,
+// reported in $SRV.INFO and $SRV.PING, and registers inFlight for it, which wraps the implementation of the server.
+// Unlike impl.NewService, the service options are part of the micro.Config the service is created with,
+// as micro doesn't support changing the info of a running service.
+// The returned impl.ServerOpts contain the protonats options set by opts.
+func NewService(name string, nc *nats.Conn, inFlight *InFlight, cfg ServiceConfig, opts ...ServerOption) (*TrackedService, *impl.ServerOpts, error) {
+	serverCfg := newServerConfig(opts...)
+	version := cfg.Version
+	if version == "" {
+		version = DefaultVersion
+	}
+	service, err := micro.AddService(nc, micro.Config{
+		Name:         name,
+		Version:      version,
+		Description:  cfg.Description,
+		Metadata:     serviceMetadata(cfg, serverCfg),
+		StatsHandler: inFlight.Stats,
+		DoneHandler:  inFlight.Done,
+		ErrorHandler: inFlight.Err,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	inFlight.register(nc, service, serverCfg)
+	return &TrackedService{Service: service, inFlight: inFlight}, &impl.ServerOpts{ServerOptions: serverCfg.ServerOptions}, nil
+}
+
+// serviceMetadata returns the metadata of a service: the metadata declared in cfg, the public xkey of the server
+// under XKeyMetadata and its role under RoleMetadata, if it is fixed by the options of the server.
+func serviceMetadata(cfg ServiceConfig, serverCfg ServerConfig) map[string]string {
+	metadata := make(map[string]string, len(cfg.Metadata)+2)
+	for k, v := range cfg.Metadata {
+		metadata[k] = v
+	}
+	if serverCfg.XKey != nil {
+		if pub, err := serverCfg.XKey.PublicKey(); err == nil {
+			metadata[XKeyMetadata] = pub
+		}
+	}
+	if !serverCfg.dynamicRoles() {
+		if role := staticRole(&serverCfg.ServerOptions); role != "" {
+			metadata[RoleMetadata] = string(role)
+		}
+	}
+	return metadata
+}
+
+// UnimplementedCode is the code of the ServerError returned by methods of the generated Unimplemented servers.
+const UnimplementedCode = "501"
+
+// Unimplemented returns the ServerError returned by the generated Unimplemented servers for methods that are not implemented.
+func Unimplemented(method string) error {
+	return protonats.NewServerErr(UnimplementedCode, "method "+method+" not implemented")
+}
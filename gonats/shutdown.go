@@ -0,0 +1,348 @@
+package gonats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"golang.org/x/time/rate"
+)
+
+// ShuttingDownCode is the code of the error returned for requests delivered to an instance after it stopped handling them,
+// which only happens for requests that were already on their way when Shutdown or Stop was called.
+const ShuttingDownCode = "503"
+
+// ErrNotTracked is returned by Shutdown and InFlightRequests for services that weren't created by a generated New...Server function.
+var ErrNotTracked = errors.New("service is not tracked for graceful shutdown")
+
+// InFlight is the state of a running generated server shared by its endpoints: the requests in flight,
+// the rate limiters of its methods, the consumers of its durable methods and its role.
+// It is passed as implementation to NewService and forwards the optional Stats, Done and Err handlers
+// to the actual implementation, adding the in-flight requests to the stats of every endpoint.
+type InFlight struct {
+	impl    any
+	id      string
+	cfg     ServerConfig
+	nc      *nats.Conn
+	service string
+
+	requests requests
+	limiters limiters
+	durables durables
+	roles    roles
+	leader   LeaderCache
+}
+
+// requests tracks the requests in flight on the endpoints of a server, and the worker pools they run on.
+type requests struct {
+	mu        sync.Mutex
+	total     int
+	closing   bool
+	draining  bool
+	idle      chan struct{}
+	idleOnce  sync.Once
+	stopped   bool
+	endpoints map[string]*atomic.Int64
+	pools     []*workerPool
+}
+
+// EndpointStatsData is the data reported in the stats of every endpoint of a generated server whose implementation
+// doesn't have a Stats handler. The data of a Stats handler is reported unchanged instead, see InFlightRequests.
+type EndpointStatsData struct {
+	// InFlight is the number of requests currently being handled by the endpoint.
+	InFlight int64 `json:"in_flight"`
+}
+
+// NewInFlight returns an InFlight wrapping the implementation impl.
+func NewInFlight(impl any) *InFlight {
+	return &InFlight{
+		impl: impl,
+		requests: requests{
+			idle:      make(chan struct{}),
+			endpoints: make(map[string]*atomic.Int64),
+		},
+		limiters: limiters{byMethod: make(map[string]*rate.Limiter)},
+	}
+}
+
+// register applies cfg, the ServerConfig set by the options of service, and reports the requests of service to the InFlight.
+// nc is the connection of the service, used to consume the requests of durable methods and to subscribe role endpoints.
+// With WithLeaderElection, the instance starts campaigning for the leadership of service.
+func (f *InFlight) register(nc *nats.Conn, service micro.Service, cfg ServerConfig) {
+	f.nc = nc
+	f.id = service.Info().ID
+	f.cfg = cfg
+	f.service = service.Info().Name
+	f.roles.role = f.cfg.Role
+	if !f.DynamicRoles() {
+		f.roles.role = staticRole(&f.cfg.ServerOptions)
+	}
+	if f.DynamicRoles() {
+		f.watchRoleErrors(service)
+	}
+	if f.cfg.ElectionTTL > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		f.roles.cancelElection, f.roles.electionDone = cancel, make(chan struct{})
+		go f.elect(ctx, f.service, f.roles.electionDone)
+	}
+}
+
+// Handler wraps handler, the handler of endpoint, with the features configured for the server and for the method in cfg.
+// Requests pass through the following steps, each of which may reject them:
+//   - Requests exceeding the rate limit of the method, shared by all of its endpoints, are rejected with RateLimitedCode.
+//   - Requests are tracked under the name of the endpoint until they are handled.
+//     Those delivered after the instance stopped handling requests are rejected with ShuttingDownCode, so that clients retry them elsewhere.
+//   - The handler runs on the worker pool of the method, or the one set with WithWorkerPool if the method doesn't declare one.
+//     Requests not fitting into its queue are rejected with OverloadedCode.
+//   - Requests forwarded by a follower with WithForwarderSigner are verified against the subject they were originally sent to,
+//     if the follower signed them with one of the TrustedSigners. Other requests claiming to be forwarded are rejected with UnauthenticatedCode.
+//   - With WithTrustedSigners, requests without a signature of a trusted signer are rejected with UnauthenticatedCode.
+//   - With WithEncryption, requests are decrypted and their responses encrypted. Plaintext requests are rejected with EncryptionRequiredCode.
+//   - With WithAuthorizer, requests rejected by the Authorizer are rejected with ForbiddenCode.
+//
+// Requests to one-way methods are never responded to, errors are logged instead.
+func (f *InFlight) Handler(endpoint string, handler micro.Handler, cfg EndpointConfig) micro.Handler {
+	pool := cfg.Pool
+	if pool == nil {
+		pool = f.cfg.Pool
+	}
+	limit, overridden := f.cfg.RateLimits[cfg.Method]
+	if !overridden {
+		limit = cfg.RateLimit
+	}
+	limiter := f.limiters.get(cfg.Method, limit)
+	workers := newWorkerPool(pool)
+	counter := f.requests.add(endpoint, workers)
+
+	handler = authorized(f.cfg.Authorizer, f.cfg.TrustedIssuers, cfg.Method, cfg.Roles, handler, WithInstanceHeader(f.id))
+	handler = sealed(f.cfg.XKey, handler, WithInstanceHeader(f.id))
+	handler = signed(f.cfg.TrustedSigners, handler, WithInstanceHeader(f.id))
+	handler = f.forwarded(handler)
+	tracked := micro.HandlerFunc(func(request micro.Request) {
+		if !f.requests.acquire() {
+			_ = request.Error(ShuttingDownCode, "Service is shutting down", nil, WithInstanceHeader(f.id))
+			return
+		}
+		counter.Add(1)
+		done := func() {
+			counter.Add(-1)
+			f.requests.release()
+		}
+		err := workers.submit(func() {
+			defer done()
+			handler.Handle(request)
+		})
+		switch {
+		case errors.Is(err, errPoolClosed):
+			done()
+			_ = request.Error(ShuttingDownCode, "Service is shutting down", nil, WithInstanceHeader(f.id))
+		case err != nil:
+			done()
+			_ = request.Error(OverloadedCode, "Too many requests in progress", nil, WithInstanceHeader(f.id))
+		}
+	})
+	limited := rateLimited(limiter, tracked, WithInstanceHeader(f.id))
+	if cfg.OneWay {
+		return oneWay(cfg.Method, limited)
+	}
+	return limited
+}
+
+// Count returns the number of requests currently handled by all endpoints.
+func (f *InFlight) Count() int {
+	f.requests.mu.Lock()
+	defer f.requests.mu.Unlock()
+	return f.requests.total
+}
+
+// add returns the counter of the requests in flight on endpoint, and closes workers together with the other pools on stop.
+func (r *requests) add(endpoint string, workers *workerPool) *atomic.Int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counter, ok := r.endpoints[endpoint]
+	if !ok {
+		counter = new(atomic.Int64)
+		r.endpoints[endpoint] = counter
+	}
+	if workers != nil {
+		r.pools = append(r.pools, workers)
+	}
+	return counter
+}
+
+func (r *requests) acquire() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return false
+	}
+	r.total++
+	return true
+}
+
+func (r *requests) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.total--
+	if r.draining && r.total == 0 {
+		r.idleOnce.Do(func() { close(r.idle) })
+	}
+}
+
+// close marks the requests as closing, so that the worker pools are only closed once they were drained.
+func (r *requests) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closing = true
+}
+
+// drain returns a channel that is closed once no requests are in flight anymore.
+// Requests are still accepted while draining, as the endpoint subscriptions are drained before.
+func (r *requests) drain() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.draining = true
+	if r.total == 0 {
+		r.idleOnce.Do(func() { close(r.idle) })
+	}
+	return r.idle
+}
+
+// stop rejects all further requests with ShuttingDownCode and closes the worker pools once their queued requests are handled.
+// Unless force is set, nothing happens while the requests are closing, as they are stopped once they were drained.
+func (r *requests) stop(force bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closing && !force {
+		return
+	}
+	r.stopped = true
+	for _, pool := range r.pools {
+		pool.close()
+	}
+}
+
+// counts returns the number of requests in flight on every endpoint.
+func (r *requests) counts() map[string]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make(map[string]int64, len(r.endpoints))
+	for endpoint, counter := range r.endpoints {
+		counts[endpoint] = counter.Load()
+	}
+	return counts
+}
+
+// count returns the number of requests in flight on endpoint.
+func (r *requests) count(endpoint string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if counter, ok := r.endpoints[endpoint]; ok {
+		return counter.Load()
+	}
+	return 0
+}
+
+// Stats returns the data of the Stats handler of the implementation unchanged,
+// or the in-flight requests of the endpoint as EndpointStatsData if the implementation doesn't have one.
+// The RoleEndpoint, which isn't part of the implementation, reports RoleStatsData instead.
+func (f *InFlight) Stats(endpoint *micro.Endpoint) any {
+	if endpoint.Name == RoleEndpoint || endpoint.Name == RoleEndpoint+"-Direct" {
+		return RoleStatsData{Role: f.Role(), Endpoints: f.roleStats()}
+	}
+	if h, ok := f.impl.(interface{ Stats(*micro.Endpoint) any }); ok {
+		return h.Stats(endpoint)
+	}
+	return EndpointStatsData{InFlight: f.requests.count(endpoint.Name)}
+}
+
+// Done forwards to the Done handler of the implementation, after stopping the consumers of durable methods,
+// the leader election and the role endpoints.
+// The worker pools are closed as well, unless Shutdown is still waiting for their requests.
+func (f *InFlight) Done(service micro.Service) {
+	f.stopConsumers()
+	f.stopRoles()
+	f.requests.stop(false)
+	if h, ok := f.impl.(interface{ Done(micro.Service) }); ok {
+		h.Done(service)
+	}
+}
+
+// Err forwards to the Err handler of the implementation.
+func (f *InFlight) Err(service micro.Service, err *micro.NATSError) {
+	if h, ok := f.impl.(interface {
+		Err(micro.Service, *micro.NATSError)
+	}); ok {
+		h.Err(service, err)
+	}
+}
+
+// inFlightOf returns the InFlight of a service returned by a generated New...Server function.
+func inFlightOf(service micro.Service) (*InFlight, bool) {
+	s, ok := service.(interface{ InFlight() *InFlight })
+	if !ok || s.InFlight() == nil {
+		return nil, false
+	}
+	return s.InFlight(), true
+}
+
+// waitDispatched waits until the requests received by the drained subscriptions of the endpoints were passed to their handlers,
+// so that they are counted as in flight, or until ctx is done.
+// micro doesn't expose the subscriptions of its endpoints, so a barrier is queued behind the pending messages of every
+// subscription of the connection, which is passed once all of them were dispatched.
+// The subscriptions of role endpoints are waited for until they are closed.
+func (f *InFlight) waitDispatched(ctx context.Context) error {
+	dispatched := make(chan struct{})
+	if err := f.nc.Barrier(func() { close(dispatched) }); err == nil {
+		select {
+		case <-dispatched:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return f.waitRolesDrained(ctx)
+}
+
+// InFlightRequests returns the number of requests in flight on every endpoint of a service created by a generated New...Server function,
+// which is reported in the stats only if the implementation doesn't have a Stats handler.
+func InFlightRequests(service micro.Service) (map[string]int64, error) {
+	f, ok := inFlightOf(service)
+	if !ok {
+		return nil, ErrNotTracked
+	}
+	return f.requests.counts(), nil
+}
+
+// Shutdown gracefully stops a service created by a generated New...Server function.
+// The consumers of durable methods are stopped, so that their pending requests are delivered to other instances,
+// and with WithLeaderElection the instance resigns its leadership, so that another instance takes over.
+// The service is stopped first, draining the subscriptions of its endpoints, so that new requests are delivered to the other
+// instances of the queue groups, while the requests already delivered to this instance are still handled.
+// It waits until the requests pending in the drained subscriptions were passed to their handlers,
+// and then until all in-flight requests are done, or until ctx is done, whichever happens first.
+// If ctx is done before all requests finished, the error of ctx is returned.
+func Shutdown(ctx context.Context, service micro.Service) error {
+	f, ok := inFlightOf(service)
+	if !ok {
+		return ErrNotTracked
+	}
+	f.stopConsumers()
+	f.stopRoles()
+	f.requests.close()
+	stopErr := service.Stop()
+	// Once the server confirmed the flush, all requests it delivered before the subscriptions were drained have been received
+	_ = f.nc.Flush()
+	ctxErr := f.waitDispatched(ctx)
+	if ctxErr == nil {
+		select {
+		case <-f.requests.drain():
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+		}
+	}
+	f.requests.stop(true)
+	return errors.Join(ctxErr, stopErr)
+}
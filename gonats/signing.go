@@ -0,0 +1,150 @@
+package gonats
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/nats-io/nkeys"
+)
+
+const (
+	// UnauthenticatedCode is the code of the error returned for requests without a valid signature of a trusted signer.
+	UnauthenticatedCode = "401"
+	// SignerHeader contains the public key of the NKey that signed a request.
+	SignerHeader = "Protonats-Signer"
+	// SignatureHeader contains the base64 encoded signature of a request.
+	SignatureHeader = "Protonats-Signature"
+	// SignedAtHeader contains the time a request was signed at, formatted as RFC 3339.
+	SignedAtHeader = "Protonats-Signed-At"
+)
+
+// MaxSignatureAge is the maximum difference between the time a request was signed at and the time it is verified.
+var MaxSignatureAge = 5 * time.Minute
+
+// Errors returned by VerifyRequest.
+var (
+	ErrMissingSignature = errors.New("request is not signed")
+	ErrInvalidSignature = errors.New("invalid request signature")
+	ErrExpiredSignature = errors.New("request signature expired")
+	ErrUntrustedSigner  = errors.New("request signer is not trusted")
+)
+
+// signedData returns the data covered by the signature of a request: the subject, the SignedAtHeader, the JWTHeader and the payload.
+func signedData(subject string, header nats.Header, data []byte) []byte {
+	hash := sha256.Sum256(data)
+	return []byte(strings.Join([]string{
+		subject,
+		header.Get(SignedAtHeader),
+		header.Get(JWTHeader),
+		hex.EncodeToString(hash[:]),
+	}, "\n"))
+}
+
+// SignRequest signs msg with the NKey kp, setting the SignerHeader, SignatureHeader and SignedAtHeader.
+// The signature covers the subject, payload and the SignedAtHeader and JWTHeader, so msg must not be changed afterward.
+func SignRequest(msg *nats.Msg, kp nkeys.KeyPair) error {
+	pub, err := kp.PublicKey()
+	if err != nil {
+		return err
+	}
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	msg.Header.Set(SignerHeader, pub)
+	msg.Header.Set(SignedAtHeader, time.Now().UTC().Format(time.RFC3339Nano))
+	sig, err := kp.Sign(signedData(msg.Subject, msg.Header, msg.Data))
+	if err != nil {
+		return err
+	}
+	msg.Header.Set(SignatureHeader, base64.RawURLEncoding.EncodeToString(sig))
+	return nil
+}
+
+// VerifyRequest verifies the signature of a request and returns the public key of its signer.
+// It doesn't check whether the signer is trusted.
+func VerifyRequest(subject string, header nats.Header, data []byte) (string, error) {
+	signer, signature := header.Get(SignerHeader), header.Get(SignatureHeader)
+	if signer == "" || signature == "" {
+		return "", ErrMissingSignature
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	kp, err := nkeys.FromPublicKey(signer)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	if err := kp.Verify(signedData(subject, header, data), sig); err != nil {
+		return "", ErrInvalidSignature
+	}
+	signedAt, err := time.Parse(time.RFC3339Nano, header.Get(SignedAtHeader))
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	if age := time.Since(signedAt).Abs(); age > MaxSignatureAge {
+		return "", ErrExpiredSignature
+	}
+	return signer, nil
+}
+
+// WithTrustedSigners requires every request to be signed by one of the NKeys with the given public keys,
+// rejecting requests with a missing, invalid or untrusted signature with UnauthenticatedCode.
+func WithTrustedSigners(keys ...string) ServerOption {
+	return configure(func(cfg *ServerConfig) {
+		cfg.TrustedSigners = append(cfg.TrustedSigners, keys...)
+	})
+}
+
+// signed returns handler only calling the next handler for requests signed by one of trusted, or handler if there are no trusted signers.
+// The requests are passed on with their signer, which is returned by SignerOf.
+func signed(trusted []string, handler micro.Handler, opts ...micro.RespondOpt) micro.Handler {
+	if len(trusted) == 0 {
+		return handler
+	}
+	return micro.HandlerFunc(func(request micro.Request) {
+		signer, err := VerifyRequest(request.Subject(), nats.Header(request.Headers()), request.Data())
+		if err == nil && !slices.Contains(trusted, signer) {
+			err = ErrUntrustedSigner
+		}
+		if err != nil {
+			_ = request.Error(UnauthenticatedCode, "Unauthenticated: "+err.Error(), nil, opts...)
+			return
+		}
+		handler.Handle(&signedRequest{Request: request, signer: signer})
+	})
+}
+
+// signedRequest is a request whose signature was verified to be from a trusted signer.
+type signedRequest struct {
+	micro.Request
+	signer string
+}
+
+// wrappedRequest is implemented by the requests wrapping a request after it was verified by signed.
+type wrappedRequest interface {
+	unwrap() micro.Request
+}
+
+// SignerOf returns the public key of the NKey that signed a request passed to a generated handler, e.g. to the WithRequest
+// methods of implementations, or an empty string if it isn't signed or its signature is invalid.
+// Only servers created with WithTrustedSigners ensure that it is one of the trusted signers.
+func SignerOf(request micro.Request) string {
+	for r := request; ; {
+		switch w := r.(type) {
+		case *signedRequest:
+			return w.signer
+		case wrappedRequest:
+			r = w.unwrap()
+		default:
+			return CallerOf(request).Signer
+		}
+	}
+}
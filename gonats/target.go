@@ -0,0 +1,91 @@
+package gonats
+
+import (
+	"slices"
+
+	"github.com/nats-io/nats.go/micro"
+	"xiam.li/protonats/go/protonats"
+)
+
+// Target selects the instances a targeted broadcast is sent to.
+// The zero value selects no instances.
+type Target struct {
+	ids      []string
+	version  string
+	metadata map[string]string
+}
+
+// Instances targets the instances with the given IDs.
+func Instances(ids ...string) Target {
+	return Target{ids: ids}
+}
+
+// Metadata targets all instances whose micro.Info metadata contains all the given key value pairs.
+func Metadata(metadata map[string]string) Target {
+	return Target{metadata: metadata}
+}
+
+// Version targets all instances running the given version.
+// Generated servers report the version declared with the protonats.service_version option.
+func Version(version string) Target {
+	return Target{version: version}
+}
+
+// WithMetadata returns a copy of the target that additionally requires the given metadata key value pair.
+func (t Target) WithMetadata(key, value string) Target {
+	metadata := make(map[string]string, len(t.metadata)+1)
+	for k, v := range t.metadata {
+		metadata[k] = v
+	}
+	metadata[key] = value
+	t.metadata = metadata
+	return t
+}
+
+// WithVersion returns a copy of the target that additionally requires the given version.
+func (t Target) WithVersion(version string) Target {
+	t.version = version
+	return t
+}
+
+// Match reports whether the instance described by info is selected by the target.
+func (t Target) Match(info *micro.Info) bool {
+	if t.ids != nil && !slices.Contains(t.ids, info.ID) {
+		return false
+	}
+	if t.version != "" && info.Version != t.version {
+		return false
+	}
+	for k, v := range t.metadata {
+		if value, ok := info.Metadata[k]; !ok || value != v {
+			return false
+		}
+	}
+	return t.ids != nil || t.version != "" || len(t.metadata) > 0
+}
+
+// Resolve returns the IDs of all instances selected by the target.
+// The returned slice is never nil, so that it can't be confused with an untargeted broadcast.
+// The info function is only called if the target selects instances by version or metadata.
+func (t Target) Resolve(info func() ([]*micro.Info, error)) ([]string, error) {
+	if t.version == "" && len(t.metadata) == 0 {
+		return slices.Compact(append([]string{}, slices.Sorted(slices.Values(t.ids))...)), nil
+	}
+	infos, err := info()
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, i := range infos {
+		if t.Match(i) && !slices.Contains(ids, i.ID) {
+			ids = append(ids, i.ID)
+		}
+	}
+	return ids, nil
+}
+
+// AllInstances returns opts without the instance ID and extra subject they select, so that a call with them reaches all instances,
+// e.g. the Info call resolving a Target, which the instance ID and extra subject meant for the targeted broadcast must not narrow down.
+func AllInstances(opts []protonats.CallOption) []protonats.CallOption {
+	return append(opts[:len(opts):len(opts)], protonats.WithInstanceID(""), protonats.WithExtraSubject(""))
+}
@@ -0,0 +1,39 @@
+package gonats
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+)
+
+// TimeoutHeader contains the time a generated client waits for the response to a request, as a time.Duration string.
+// It isn't covered by the signature of a request, as handlers only use it to stop working on requests nobody waits for anymore.
+const TimeoutHeader = "Protonats-Timeout"
+
+// SetTimeout sets the TimeoutHeader of msg to the time until the deadline of ctx, or to timeout if ctx is nil or has no deadline.
+func SetTimeout(msg *nats.Msg, ctx context.Context, timeout time.Duration) {
+	if ctx != nil {
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		}
+	}
+	if timeout <= 0 {
+		return
+	}
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	msg.Header.Set(TimeoutHeader, timeout.String())
+}
+
+// RequestContext returns the context for handling request, which ends once the client stopped waiting for the response
+// according to the TimeoutHeader, or only when canceled if the request doesn't have one.
+func RequestContext(request micro.Request) (context.Context, context.CancelFunc) {
+	timeout, err := time.ParseDuration(request.Headers().Get(TimeoutHeader))
+	if err != nil || timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
@@ -1,8 +1,18 @@
 package test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/nats-io/nats.go/micro"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"sync/atomic"
 	"time"
+	"xiam.li/go-protonats/gonats"
 	"xiam.li/protonats/go/protonats"
 )
 
@@ -131,5 +141,192 @@ func (t *testImplementation) ThreeSecondDelay() error {
 	return nil
 }
 
+func (t *testImplementation) PooledDelay() error {
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+func (t *testImplementation) RateLimited(req *Test) (*Test, error) {
+	return &Test{Test: fmt.Sprintf("server replying to %s from %s", req.Test, t.id)}, nil
+}
+
+func (t *testImplementation) AdminOnly(req *Test) (*Test, error) {
+	return &Test{Test: fmt.Sprintf("server replying to %s from %s", req.Test, t.id)}, nil
+}
+
+func (t *testImplementation) Durable(req *Test) (*Test, error) {
+	if req.Test == "invalid" {
+		return nil, protonats.NewServerErr("400", "Invalid request")
+	}
+	return &Test{Test: fmt.Sprintf("server replying to %s from %s", req.Test, t.id)}, nil
+}
+
+func (t *testImplementation) HTTPGet(req *HTTPTest) (*Test, error) {
+	return &Test{Test: fmt.Sprintf("server replying to %s with filter %q from %s", req.Id, req.Filter, t.id)}, nil
+}
+
+func (t *testImplementation) HTTPPost(req *HTTPTest) (*Test, error) {
+	return &Test{Test: fmt.Sprintf("server replying to %s with %s from %s", req.Id, req.GetTest().GetTest(), t.id)}, nil
+}
+
 // Interface guard
 var _ TestServiceNATSServer = (*testImplementation)(nil)
+
+// partialImplementation only implements NormalTestTest and relies on UnimplementedTestServiceNATSServer for the rest
+type partialImplementation struct {
+	UnimplementedTestServiceNATSServer
+}
+
+func (p *partialImplementation) NormalTestTest(req *Test) (*Test, error) {
+	return &Test{Test: "partial server replying to " + req.Test}, nil
+}
+
+// Interface guard
+var _ TestServiceNATSServer = (*partialImplementation)(nil)
+
+// hooksImplementation additionally implements the optional stats, done and err handlers
+type hooksImplementation struct {
+	testImplementation
+	done chan struct{}
+}
+
+func (h *hooksImplementation) Stats(endpoint *micro.Endpoint) any {
+	return map[string]string{"endpoint": endpoint.Name}
+}
+
+func (h *hooksImplementation) Done(micro.Service) {
+	close(h.done)
+}
+
+func (h *hooksImplementation) Err(micro.Service, *micro.NATSError) {}
+
+// blockingImplementation blocks in NormalTestTest until release is closed
+type blockingImplementation struct {
+	testImplementation
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingImplementation) NormalTestTest(req *Test) (*Test, error) {
+	close(b.started)
+	<-b.release
+	return b.testImplementation.NormalTestTest(req)
+}
+
+// signerImplementation responds to NormalTestTest with the signer of the request
+type signerImplementation struct {
+	testImplementation
+}
+
+func (s *signerImplementation) NormalTestTestWithRequest(request micro.Request, _ *Test) (*Test, error) {
+	return &Test{Test: gonats.SignerOf(request)}, nil
+}
+
+// queuedImplementation blocks in NormalTestTest until release is closed, sending to started on every call
+type queuedImplementation struct {
+	testImplementation
+	started chan struct{}
+	release chan struct{}
+}
+
+func (q *queuedImplementation) NormalTestTest(req *Test) (*Test, error) {
+	q.started <- struct{}{}
+	<-q.release
+	return q.testImplementation.NormalTestTest(req)
+}
+
+// delayImplementation delays NormalTestTest by delay
+type delayImplementation struct {
+	testImplementation
+	delay time.Duration
+}
+
+func (d *delayImplementation) NormalTestTest(req *Test) (*Test, error) {
+	time.Sleep(d.delay)
+	return d.testImplementation.NormalTestTest(req)
+}
+
+func (t *testImplementation) OneWay(*Test) error {
+	return nil
+}
+
+// oneWayImplementation sends the requests to OneWay to received
+type oneWayImplementation struct {
+	testImplementation
+	received chan string
+}
+
+func (o *oneWayImplementation) OneWay(req *Test) error {
+	o.received <- req.Test
+	if req.Test == "fail" {
+		return errors.New("one-way failure")
+	}
+	return nil
+}
+
+// flakyImplementation fails Durable with err until failures is used up
+type flakyImplementation struct {
+	testImplementation
+	failures atomic.Int32
+	err      error
+}
+
+func (f *flakyImplementation) Durable(req *Test) (*Test, error) {
+	if f.failures.Add(-1) >= 0 {
+		return nil, f.err
+	}
+	return f.testImplementation.Durable(req)
+}
+
+// electionImplementation sends the roles it is notified about to roles
+type electionImplementation struct {
+	testImplementation
+	roles chan gonats.Role
+}
+
+func (e *electionImplementation) RoleChanged(role gonats.Role) {
+	e.roles <- role
+}
+
+// Interface guards
+var (
+	_ TestServiceStats       = (*hooksImplementation)(nil)
+	_ TestServiceDone        = (*hooksImplementation)(nil)
+	_ TestServiceErr         = (*hooksImplementation)(nil)
+	_ TestServiceRoleChanged = (*electionImplementation)(nil)
+)
+
+type grpcImplementation struct {
+	UnimplementedTestServiceServer
+	id       string
+	canceled atomic.Bool
+}
+
+func (g *grpcImplementation) SetTestServiceId(id string) {
+	g.id = id
+}
+
+func (g *grpcImplementation) NormalTestTest(ctx context.Context, req *Test) (*Test, error) {
+	var caller string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-caller")) > 0 {
+		caller = " for " + md.Get("x-caller")[0]
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-served-by", g.id)); err != nil {
+		return nil, err
+	}
+	return &Test{Test: fmt.Sprintf("grpc server replying to %s%s from %s", req.Test, caller, g.id)}, nil
+}
+
+func (g *grpcImplementation) ThreeSecondDelay(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	select {
+	case <-time.After(3 * time.Second):
+		return &emptypb.Empty{}, nil
+	case <-ctx.Done():
+		g.canceled.Store(true)
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (g *grpcImplementation) ErrServiceError(context.Context, *Test) (*Test, error) {
+	return nil, status.Error(codes.NotFound, "This is a gRPC error")
+}
@@ -16,11 +16,22 @@ type NatsInstance struct {
 }
 
 func newNATS(t *testing.T) *NatsInstance {
+	return runNATS(t, natstest.DefaultTestOptions)
+}
+
+// newJetStream starts a NATS server with JetStream enabled, storing its data in a temporary directory.
+func newJetStream(t *testing.T) *NatsInstance {
+	opts := natstest.DefaultTestOptions
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	return runNATS(t, opts)
+}
+
+func runNATS(t *testing.T, opts server.Options) *NatsInstance {
 	port, err := GetFreePort()
 	if err != nil {
 		t.Fatalf("Failed to get free port: %v", err)
 	}
-	opts := natstest.DefaultTestOptions
 	opts.Port = port
 	testServer := natstest.RunServer(&opts)
 	if testServer.ReadyForConnections(1*time.Second) != true {
@@ -7,11 +7,13 @@
 package test
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
+	_ "xiam.li/go-protonats/gonats"
 	_ "xiam.li/protonats/go/protonats"
 )
 
@@ -69,170 +71,347 @@ func (x *Test) GetTest() string {
 	return ""
 }
 
+type HTTPTest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Test   *Test  `protobuf:"bytes,3,opt,name=test,proto3" json:"test,omitempty"`
+}
+
+func (x *HTTPTest) Reset() {
+	*x = HTTPTest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTPTest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPTest) ProtoMessage() {}
+
+func (x *HTTPTest) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPTest.ProtoReflect.Descriptor instead.
+func (*HTTPTest) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HTTPTest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HTTPTest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *HTTPTest) GetTest() *Test {
+	if x != nil {
+		return x.Test
+	}
+	return nil
+}
+
+type OrderCreated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Region string `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Id     string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *OrderCreated) Reset() {
+	*x = OrderCreated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderCreated) ProtoMessage() {}
+
+func (x *OrderCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderCreated.ProtoReflect.Descriptor instead.
+func (*OrderCreated) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderCreated) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *OrderCreated) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 var File_test_proto protoreflect.FileDescriptor
 
 var file_test_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1a, 0x0a,
-	0x04, 0x54, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x73, 0x74, 0x32, 0xf3, 0x12, 0x0a, 0x0b, 0x54, 0x65,
-	0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x0e, 0x4e, 0x6f, 0x72,
-	0x6d, 0x61, 0x6c, 0x54, 0x65, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72,
+	0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65,
+	0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x67, 0x6f, 0x6e, 0x61,
+	0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e,
+	0x61, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1a, 0x0a, 0x04, 0x54, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x65, 0x73, 0x74, 0x22, 0x5f, 0x0a, 0x08, 0x48, 0x54, 0x54, 0x50, 0x54, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x65, 0x73,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e,
+	0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74,
+	0x52, 0x04, 0x74, 0x65, 0x73, 0x74, 0x22, 0x57, 0x0a, 0x0c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x3a, 0x1f,
+	0x82, 0xe7, 0xa0, 0xd9, 0x0f, 0x19, 0x0a, 0x17, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2e, 0x7b,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x7d, 0x2e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x32,
+	0xb8, 0x18, 0x0a, 0x0b, 0x54, 0x65, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x8a, 0x01, 0x0a, 0x0e, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x54, 0x65, 0x73, 0x74, 0x54, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67,
+	0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e,
-	0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73,
-	0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a,
-	0x0f, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73,
-	0x74, 0x12, 0x42, 0x0a, 0x0f, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x54, 0x65, 0x73, 0x74, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73,
-	0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x10, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x43, 0x0a, 0x0f, 0x45, 0x72, 0x72,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x17, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74,
-	0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74,
-	0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x12, 0x42,
-	0x0a, 0x0e, 0x45, 0x72, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72,
-	0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e,
-	0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65,
-	0x73, 0x74, 0x12, 0x54, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x17,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65,
-	0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e,
+	0x54, 0x65, 0x73, 0x74, 0x22, 0x46, 0xb2, 0xe6, 0xa0, 0xd9, 0x0f, 0x2c, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x65, 0x73, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x74, 0x68, 0x65, 0x20, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x74, 0x68, 0x65, 0x20, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x20, 0x49, 0x44, 0xba, 0xe6, 0xa0, 0xd9, 0x0f, 0x0e, 0x12, 0x06,
+	0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x42, 0x0a, 0x0f,
+	0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e,
 	0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74,
-	0x22, 0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x53, 0x0a, 0x17, 0x45, 0x72, 0x72, 0x53,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
-	0x61, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e,
-	0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74,
-	0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x53, 0x0a,
-	0x17, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
-	0x54, 0x65, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73,
-	0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f,
-	0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd0, 0xe4, 0xa0, 0xd9,
-	0x0f, 0x01, 0x12, 0x53, 0x0a, 0x18, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x42, 0x72, 0x6f, 0x61,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61,
-	0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22,
-	0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x53, 0x0a, 0x18, 0x4e, 0x6f, 0x72, 0x6d, 0x61,
-	0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x45, 0x6d,
+	0x12, 0x42, 0x0a, 0x0f, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x54, 0x65, 0x73, 0x74, 0x45, 0x6d,
 	0x70, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e,
 	0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x53, 0x0a, 0x19,
-	0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f,
-	0x01, 0x12, 0x4e, 0x0a, 0x12, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x54,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x10, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x43, 0x0a, 0x0f, 0x45, 0x72, 0x72, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x17, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73,
+	0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a,
+	0x0e, 0x45, 0x72, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73,
+	0x74, 0x12, 0x54, 0x0a, 0x18, 0x45, 0x72, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61,
+	0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22,
+	0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x53, 0x0a, 0x17, 0x45, 0x72, 0x72, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67,
+	0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x53, 0x0a, 0x17,
+	0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54,
 	0x65, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e,
 	0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74,
 	0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e,
-	0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f,
-	0x01, 0x12, 0x4e, 0x0a, 0x13, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f,
+	0x01, 0x12, 0x53, 0x0a, 0x18, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64,
+	0x63, 0x61, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74,
+	0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06,
+	0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x53, 0x0a, 0x18, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c,
+	0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67,
+	0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x53, 0x0a, 0x19, 0x4e,
+	0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e,
-	0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f,
-	0x01, 0x12, 0x4e, 0x0a, 0x13, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x54,
-	0x65, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f,
-	0x01, 0x12, 0x4e, 0x0a, 0x14, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f,
-	0x01, 0x12, 0x5d, 0x0a, 0x1b, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42,
-	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74,
-	0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e,
-	0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65,
-	0x73, 0x74, 0x22, 0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01,
-	0x12, 0x5d, 0x0a, 0x1c, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72,
-	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01,
+	0x12, 0x4e, 0x0a, 0x12, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x54, 0x65,
+	0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61,
+	0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01,
+	0x12, 0x4e, 0x0a, 0x13, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01,
+	0x12, 0x4e, 0x0a, 0x13, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x54, 0x65,
+	0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e,
+	0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01,
+	0x12, 0x4e, 0x0a, 0x14, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01,
+	0x12, 0x5d, 0x0a, 0x1b, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73,
 	0x74, 0x22, 0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12,
 	0x5d, 0x0a, 0x1c, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f,
-	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
-	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74,
-	0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x5d,
-	0x0a, 0x1d, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12,
 	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e,
+	0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74,
+	0x22, 0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x5d,
+	0x0a, 0x1c, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61,
+	0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x17,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x50, 0x0a,
-	0x14, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x54, 0x65, 0x73,
+	0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x5d, 0x0a,
+	0x1d, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61, 0x64,
+	0x63, 0x61, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x0c,
+	0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x50, 0x0a, 0x14,
+	0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x54, 0x65, 0x73, 0x74,
+	0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73,
+	0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x50,
+	0x0a, 0x15, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x00,
+	0x12, 0x50, 0x0a, 0x15, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79,
+	0x54, 0x65, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9,
+	0x0f, 0x00, 0x12, 0x50, 0x0a, 0x16, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e,
+	0x6c, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd8, 0xe4,
+	0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x5f, 0x0a, 0x1d, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72,
+	0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x65, 0x73,
 	0x74, 0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74,
 	0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65,
-	0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12,
-	0x50, 0x0a, 0x15, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e,
-	0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f,
-	0x00, 0x12, 0x50, 0x0a, 0x15, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e, 0x6c,
-	0x79, 0x54, 0x65, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54,
-	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd8, 0xe4, 0xa0,
-	0xd9, 0x0f, 0x00, 0x12, 0x50, 0x0a, 0x16, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x4f,
-	0x6e, 0x6c, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xd8,
-	0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x5f, 0x0a, 0x1d, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
-	0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x65,
-	0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61,
-	0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a,
+	0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01, 0xd8,
+	0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x5f, 0x0a, 0x1e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
 	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74,
 	0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f, 0x01,
 	0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x5f, 0x0a, 0x1e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
-	0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x54, 0x65, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e,
-	0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f,
-	0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x5f, 0x0a, 0x1e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54,
+	0x65, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x0c, 0xd0, 0xe4, 0xa0, 0xd9, 0x0f,
+	0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x5f, 0x0a, 0x1f, 0x46, 0x6f, 0x6c, 0x6c, 0x6f,
 	0x77, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
-	0x54, 0x65, 0x73, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x0c, 0xd0, 0xe4, 0xa0, 0xd9,
-	0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x5f, 0x0a, 0x1f, 0x46, 0x6f, 0x6c, 0x6c,
-	0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73,
-	0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x0c, 0xd0, 0xe4, 0xa0,
-	0xd9, 0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x44, 0x0a, 0x10, 0x54, 0x68, 0x72,
-	0x65, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x16, 0x2e,
+	0x0f, 0x01, 0xd8, 0xe4, 0xa0, 0xd9, 0x0f, 0x00, 0x12, 0x44, 0x0a, 0x10, 0x54, 0x68, 0x72, 0x65,
+	0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x49,
+	0x0a, 0x0b, 0x50, 0x6f, 0x6f, 0x6c, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x16, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
 	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42,
-	0x24, 0x5a, 0x22, 0x78, 0x69, 0x61, 0x6d, 0x2e, 0x6c, 0x69, 0x2f, 0x67, 0x6f, 0x2d, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2f, 0x74, 0x65, 0x73, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x0a, 0xc2,
+	0xe6, 0xa0, 0xd9, 0x0f, 0x04, 0x10, 0x01, 0x08, 0x02, 0x12, 0x52, 0x0a, 0x0b, 0x52, 0x61, 0x74,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x11, 0xca, 0xe6, 0xa0, 0xd9,
+	0x0f, 0x0b, 0x09, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f, 0x10, 0x02, 0x12, 0x4a, 0x0a,
+	0x09, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e,
+	0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x0b, 0xd2, 0xe6,
+	0xa0, 0xd9, 0x0f, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x43, 0x0a, 0x07, 0x44, 0x75, 0x72,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73,
+	0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x06, 0xd8, 0xe6, 0xa0, 0xd9, 0x0f, 0x01, 0x12, 0x41,
+	0x0a, 0x06, 0x4f, 0x6e, 0x65, 0x57, 0x61, 0x79, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xe0, 0xe6, 0xa0, 0xd9, 0x0f,
+	0x01, 0x12, 0x57, 0x0a, 0x07, 0x48, 0x54, 0x54, 0x50, 0x47, 0x65, 0x74, 0x12, 0x1b, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x48, 0x54, 0x54, 0x50, 0x54, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65,
+	0x73, 0x74, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x5e, 0x0a, 0x08, 0x48, 0x54,
+	0x54, 0x50, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61,
+	0x74, 0x73, 0x2e, 0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x54,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61, 0x74, 0x73, 0x2e,
+	0x67, 0x6f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x22, 0x1c, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x16, 0x3a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x22, 0x0e, 0x2f, 0x76, 0x31, 0x2f,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x1a, 0x4e, 0xe2, 0xe5, 0xa0, 0xd9,
+	0x0f, 0x05, 0x31, 0x2e, 0x32, 0x2e, 0x33, 0xea, 0xe5, 0xa0, 0xd9, 0x0f, 0x2b, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x20, 0x75, 0x73, 0x65, 0x64, 0x20, 0x66, 0x6f, 0x72, 0x20, 0x74, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x67, 0x20, 0x74, 0x68, 0x65, 0x20, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x64, 0x20, 0x63, 0x6f, 0x64, 0x65, 0xf2, 0xe5, 0xa0, 0xd9, 0x0f, 0x0c, 0x12, 0x04,
+	0x74, 0x65, 0x73, 0x74, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x42, 0x24, 0x5a, 0x22, 0x78, 0x69,
+	0x61, 0x6d, 0x2e, 0x6c, 0x69, 0x2f, 0x67, 0x6f, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x61,
+	0x74, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -247,75 +426,92 @@ func file_test_proto_rawDescGZIP() []byte {
 	return file_test_proto_rawDescData
 }
 
-var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_test_proto_goTypes = []interface{}{
 	(*Test)(nil),          // 0: protonats.go.test.Test
-	(*emptypb.Empty)(nil), // 1: google.protobuf.Empty
+	(*HTTPTest)(nil),      // 1: protonats.go.test.HTTPTest
+	(*OrderCreated)(nil),  // 2: protonats.go.test.OrderCreated
+	(*emptypb.Empty)(nil), // 3: google.protobuf.Empty
 }
 var file_test_proto_depIdxs = []int32{
-	0,  // 0: protonats.go.test.TestService.NormalTestTest:input_type -> protonats.go.test.Test
-	1,  // 1: protonats.go.test.TestService.NormalEmptyTest:input_type -> google.protobuf.Empty
-	0,  // 2: protonats.go.test.TestService.NormalTestEmpty:input_type -> protonats.go.test.Test
-	1,  // 3: protonats.go.test.TestService.NormalEmptyEmpty:input_type -> google.protobuf.Empty
-	0,  // 4: protonats.go.test.TestService.ErrServiceError:input_type -> protonats.go.test.Test
-	0,  // 5: protonats.go.test.TestService.ErrServerError:input_type -> protonats.go.test.Test
-	0,  // 6: protonats.go.test.TestService.ErrServiceErrorBroadcast:input_type -> protonats.go.test.Test
-	0,  // 7: protonats.go.test.TestService.ErrServerErrorBroadcast:input_type -> protonats.go.test.Test
-	0,  // 8: protonats.go.test.TestService.NormalBroadcastTestTest:input_type -> protonats.go.test.Test
-	1,  // 9: protonats.go.test.TestService.NormalBroadcastEmptyTest:input_type -> google.protobuf.Empty
-	0,  // 10: protonats.go.test.TestService.NormalBroadcastTestEmpty:input_type -> protonats.go.test.Test
-	1,  // 11: protonats.go.test.TestService.NormalBroadcastEmptyEmpty:input_type -> google.protobuf.Empty
-	0,  // 12: protonats.go.test.TestService.LeaderOnlyTestTest:input_type -> protonats.go.test.Test
-	1,  // 13: protonats.go.test.TestService.LeaderOnlyEmptyTest:input_type -> google.protobuf.Empty
-	0,  // 14: protonats.go.test.TestService.LeaderOnlyTestEmpty:input_type -> protonats.go.test.Test
-	1,  // 15: protonats.go.test.TestService.LeaderOnlyEmptyEmpty:input_type -> google.protobuf.Empty
-	0,  // 16: protonats.go.test.TestService.LeaderOnlyBroadcastTestTest:input_type -> protonats.go.test.Test
-	1,  // 17: protonats.go.test.TestService.LeaderOnlyBroadcastEmptyTest:input_type -> google.protobuf.Empty
-	0,  // 18: protonats.go.test.TestService.LeaderOnlyBroadcastTestEmpty:input_type -> protonats.go.test.Test
-	1,  // 19: protonats.go.test.TestService.LeaderOnlyBroadcastEmptyEmpty:input_type -> google.protobuf.Empty
-	0,  // 20: protonats.go.test.TestService.FollowerOnlyTestTest:input_type -> protonats.go.test.Test
-	1,  // 21: protonats.go.test.TestService.FollowerOnlyEmptyTest:input_type -> google.protobuf.Empty
-	0,  // 22: protonats.go.test.TestService.FollowerOnlyTestEmpty:input_type -> protonats.go.test.Test
-	1,  // 23: protonats.go.test.TestService.FollowerOnlyEmptyEmpty:input_type -> google.protobuf.Empty
-	0,  // 24: protonats.go.test.TestService.FollowerOnlyBroadcastTestTest:input_type -> protonats.go.test.Test
-	1,  // 25: protonats.go.test.TestService.FollowerOnlyBroadcastEmptyTest:input_type -> google.protobuf.Empty
-	0,  // 26: protonats.go.test.TestService.FollowerOnlyBroadcastTestEmpty:input_type -> protonats.go.test.Test
-	1,  // 27: protonats.go.test.TestService.FollowerOnlyBroadcastEmptyEmpty:input_type -> google.protobuf.Empty
-	1,  // 28: protonats.go.test.TestService.ThreeSecondDelay:input_type -> google.protobuf.Empty
-	0,  // 29: protonats.go.test.TestService.NormalTestTest:output_type -> protonats.go.test.Test
-	0,  // 30: protonats.go.test.TestService.NormalEmptyTest:output_type -> protonats.go.test.Test
-	1,  // 31: protonats.go.test.TestService.NormalTestEmpty:output_type -> google.protobuf.Empty
-	1,  // 32: protonats.go.test.TestService.NormalEmptyEmpty:output_type -> google.protobuf.Empty
-	0,  // 33: protonats.go.test.TestService.ErrServiceError:output_type -> protonats.go.test.Test
-	0,  // 34: protonats.go.test.TestService.ErrServerError:output_type -> protonats.go.test.Test
-	0,  // 35: protonats.go.test.TestService.ErrServiceErrorBroadcast:output_type -> protonats.go.test.Test
-	0,  // 36: protonats.go.test.TestService.ErrServerErrorBroadcast:output_type -> protonats.go.test.Test
-	0,  // 37: protonats.go.test.TestService.NormalBroadcastTestTest:output_type -> protonats.go.test.Test
-	0,  // 38: protonats.go.test.TestService.NormalBroadcastEmptyTest:output_type -> protonats.go.test.Test
-	1,  // 39: protonats.go.test.TestService.NormalBroadcastTestEmpty:output_type -> google.protobuf.Empty
-	1,  // 40: protonats.go.test.TestService.NormalBroadcastEmptyEmpty:output_type -> google.protobuf.Empty
-	0,  // 41: protonats.go.test.TestService.LeaderOnlyTestTest:output_type -> protonats.go.test.Test
-	0,  // 42: protonats.go.test.TestService.LeaderOnlyEmptyTest:output_type -> protonats.go.test.Test
-	1,  // 43: protonats.go.test.TestService.LeaderOnlyTestEmpty:output_type -> google.protobuf.Empty
-	1,  // 44: protonats.go.test.TestService.LeaderOnlyEmptyEmpty:output_type -> google.protobuf.Empty
-	0,  // 45: protonats.go.test.TestService.LeaderOnlyBroadcastTestTest:output_type -> protonats.go.test.Test
-	0,  // 46: protonats.go.test.TestService.LeaderOnlyBroadcastEmptyTest:output_type -> protonats.go.test.Test
-	1,  // 47: protonats.go.test.TestService.LeaderOnlyBroadcastTestEmpty:output_type -> google.protobuf.Empty
-	1,  // 48: protonats.go.test.TestService.LeaderOnlyBroadcastEmptyEmpty:output_type -> google.protobuf.Empty
-	0,  // 49: protonats.go.test.TestService.FollowerOnlyTestTest:output_type -> protonats.go.test.Test
-	0,  // 50: protonats.go.test.TestService.FollowerOnlyEmptyTest:output_type -> protonats.go.test.Test
-	1,  // 51: protonats.go.test.TestService.FollowerOnlyTestEmpty:output_type -> google.protobuf.Empty
-	1,  // 52: protonats.go.test.TestService.FollowerOnlyEmptyEmpty:output_type -> google.protobuf.Empty
-	0,  // 53: protonats.go.test.TestService.FollowerOnlyBroadcastTestTest:output_type -> protonats.go.test.Test
-	0,  // 54: protonats.go.test.TestService.FollowerOnlyBroadcastEmptyTest:output_type -> protonats.go.test.Test
-	1,  // 55: protonats.go.test.TestService.FollowerOnlyBroadcastTestEmpty:output_type -> google.protobuf.Empty
-	1,  // 56: protonats.go.test.TestService.FollowerOnlyBroadcastEmptyEmpty:output_type -> google.protobuf.Empty
-	1,  // 57: protonats.go.test.TestService.ThreeSecondDelay:output_type -> google.protobuf.Empty
-	29, // [29:58] is the sub-list for method output_type
-	0,  // [0:29] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	0,  // 0: protonats.go.test.HTTPTest.test:type_name -> protonats.go.test.Test
+	0,  // 1: protonats.go.test.TestService.NormalTestTest:input_type -> protonats.go.test.Test
+	3,  // 2: protonats.go.test.TestService.NormalEmptyTest:input_type -> google.protobuf.Empty
+	0,  // 3: protonats.go.test.TestService.NormalTestEmpty:input_type -> protonats.go.test.Test
+	3,  // 4: protonats.go.test.TestService.NormalEmptyEmpty:input_type -> google.protobuf.Empty
+	0,  // 5: protonats.go.test.TestService.ErrServiceError:input_type -> protonats.go.test.Test
+	0,  // 6: protonats.go.test.TestService.ErrServerError:input_type -> protonats.go.test.Test
+	0,  // 7: protonats.go.test.TestService.ErrServiceErrorBroadcast:input_type -> protonats.go.test.Test
+	0,  // 8: protonats.go.test.TestService.ErrServerErrorBroadcast:input_type -> protonats.go.test.Test
+	0,  // 9: protonats.go.test.TestService.NormalBroadcastTestTest:input_type -> protonats.go.test.Test
+	3,  // 10: protonats.go.test.TestService.NormalBroadcastEmptyTest:input_type -> google.protobuf.Empty
+	0,  // 11: protonats.go.test.TestService.NormalBroadcastTestEmpty:input_type -> protonats.go.test.Test
+	3,  // 12: protonats.go.test.TestService.NormalBroadcastEmptyEmpty:input_type -> google.protobuf.Empty
+	0,  // 13: protonats.go.test.TestService.LeaderOnlyTestTest:input_type -> protonats.go.test.Test
+	3,  // 14: protonats.go.test.TestService.LeaderOnlyEmptyTest:input_type -> google.protobuf.Empty
+	0,  // 15: protonats.go.test.TestService.LeaderOnlyTestEmpty:input_type -> protonats.go.test.Test
+	3,  // 16: protonats.go.test.TestService.LeaderOnlyEmptyEmpty:input_type -> google.protobuf.Empty
+	0,  // 17: protonats.go.test.TestService.LeaderOnlyBroadcastTestTest:input_type -> protonats.go.test.Test
+	3,  // 18: protonats.go.test.TestService.LeaderOnlyBroadcastEmptyTest:input_type -> google.protobuf.Empty
+	0,  // 19: protonats.go.test.TestService.LeaderOnlyBroadcastTestEmpty:input_type -> protonats.go.test.Test
+	3,  // 20: protonats.go.test.TestService.LeaderOnlyBroadcastEmptyEmpty:input_type -> google.protobuf.Empty
+	0,  // 21: protonats.go.test.TestService.FollowerOnlyTestTest:input_type -> protonats.go.test.Test
+	3,  // 22: protonats.go.test.TestService.FollowerOnlyEmptyTest:input_type -> google.protobuf.Empty
+	0,  // 23: protonats.go.test.TestService.FollowerOnlyTestEmpty:input_type -> protonats.go.test.Test
+	3,  // 24: protonats.go.test.TestService.FollowerOnlyEmptyEmpty:input_type -> google.protobuf.Empty
+	0,  // 25: protonats.go.test.TestService.FollowerOnlyBroadcastTestTest:input_type -> protonats.go.test.Test
+	3,  // 26: protonats.go.test.TestService.FollowerOnlyBroadcastEmptyTest:input_type -> google.protobuf.Empty
+	0,  // 27: protonats.go.test.TestService.FollowerOnlyBroadcastTestEmpty:input_type -> protonats.go.test.Test
+	3,  // 28: protonats.go.test.TestService.FollowerOnlyBroadcastEmptyEmpty:input_type -> google.protobuf.Empty
+	3,  // 29: protonats.go.test.TestService.ThreeSecondDelay:input_type -> google.protobuf.Empty
+	3,  // 30: protonats.go.test.TestService.PooledDelay:input_type -> google.protobuf.Empty
+	0,  // 31: protonats.go.test.TestService.RateLimited:input_type -> protonats.go.test.Test
+	0,  // 32: protonats.go.test.TestService.AdminOnly:input_type -> protonats.go.test.Test
+	0,  // 33: protonats.go.test.TestService.Durable:input_type -> protonats.go.test.Test
+	0,  // 34: protonats.go.test.TestService.OneWay:input_type -> protonats.go.test.Test
+	1,  // 35: protonats.go.test.TestService.HTTPGet:input_type -> protonats.go.test.HTTPTest
+	1,  // 36: protonats.go.test.TestService.HTTPPost:input_type -> protonats.go.test.HTTPTest
+	0,  // 37: protonats.go.test.TestService.NormalTestTest:output_type -> protonats.go.test.Test
+	0,  // 38: protonats.go.test.TestService.NormalEmptyTest:output_type -> protonats.go.test.Test
+	3,  // 39: protonats.go.test.TestService.NormalTestEmpty:output_type -> google.protobuf.Empty
+	3,  // 40: protonats.go.test.TestService.NormalEmptyEmpty:output_type -> google.protobuf.Empty
+	0,  // 41: protonats.go.test.TestService.ErrServiceError:output_type -> protonats.go.test.Test
+	0,  // 42: protonats.go.test.TestService.ErrServerError:output_type -> protonats.go.test.Test
+	0,  // 43: protonats.go.test.TestService.ErrServiceErrorBroadcast:output_type -> protonats.go.test.Test
+	0,  // 44: protonats.go.test.TestService.ErrServerErrorBroadcast:output_type -> protonats.go.test.Test
+	0,  // 45: protonats.go.test.TestService.NormalBroadcastTestTest:output_type -> protonats.go.test.Test
+	0,  // 46: protonats.go.test.TestService.NormalBroadcastEmptyTest:output_type -> protonats.go.test.Test
+	3,  // 47: protonats.go.test.TestService.NormalBroadcastTestEmpty:output_type -> google.protobuf.Empty
+	3,  // 48: protonats.go.test.TestService.NormalBroadcastEmptyEmpty:output_type -> google.protobuf.Empty
+	0,  // 49: protonats.go.test.TestService.LeaderOnlyTestTest:output_type -> protonats.go.test.Test
+	0,  // 50: protonats.go.test.TestService.LeaderOnlyEmptyTest:output_type -> protonats.go.test.Test
+	3,  // 51: protonats.go.test.TestService.LeaderOnlyTestEmpty:output_type -> google.protobuf.Empty
+	3,  // 52: protonats.go.test.TestService.LeaderOnlyEmptyEmpty:output_type -> google.protobuf.Empty
+	0,  // 53: protonats.go.test.TestService.LeaderOnlyBroadcastTestTest:output_type -> protonats.go.test.Test
+	0,  // 54: protonats.go.test.TestService.LeaderOnlyBroadcastEmptyTest:output_type -> protonats.go.test.Test
+	3,  // 55: protonats.go.test.TestService.LeaderOnlyBroadcastTestEmpty:output_type -> google.protobuf.Empty
+	3,  // 56: protonats.go.test.TestService.LeaderOnlyBroadcastEmptyEmpty:output_type -> google.protobuf.Empty
+	0,  // 57: protonats.go.test.TestService.FollowerOnlyTestTest:output_type -> protonats.go.test.Test
+	0,  // 58: protonats.go.test.TestService.FollowerOnlyEmptyTest:output_type -> protonats.go.test.Test
+	3,  // 59: protonats.go.test.TestService.FollowerOnlyTestEmpty:output_type -> google.protobuf.Empty
+	3,  // 60: protonats.go.test.TestService.FollowerOnlyEmptyEmpty:output_type -> google.protobuf.Empty
+	0,  // 61: protonats.go.test.TestService.FollowerOnlyBroadcastTestTest:output_type -> protonats.go.test.Test
+	0,  // 62: protonats.go.test.TestService.FollowerOnlyBroadcastEmptyTest:output_type -> protonats.go.test.Test
+	3,  // 63: protonats.go.test.TestService.FollowerOnlyBroadcastTestEmpty:output_type -> google.protobuf.Empty
+	3,  // 64: protonats.go.test.TestService.FollowerOnlyBroadcastEmptyEmpty:output_type -> google.protobuf.Empty
+	3,  // 65: protonats.go.test.TestService.ThreeSecondDelay:output_type -> google.protobuf.Empty
+	3,  // 66: protonats.go.test.TestService.PooledDelay:output_type -> google.protobuf.Empty
+	0,  // 67: protonats.go.test.TestService.RateLimited:output_type -> protonats.go.test.Test
+	0,  // 68: protonats.go.test.TestService.AdminOnly:output_type -> protonats.go.test.Test
+	0,  // 69: protonats.go.test.TestService.Durable:output_type -> protonats.go.test.Test
+	3,  // 70: protonats.go.test.TestService.OneWay:output_type -> google.protobuf.Empty
+	0,  // 71: protonats.go.test.TestService.HTTPGet:output_type -> protonats.go.test.Test
+	0,  // 72: protonats.go.test.TestService.HTTPPost:output_type -> protonats.go.test.Test
+	37, // [37:73] is the sub-list for method output_type
+	1,  // [1:37] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_test_proto_init() }
@@ -336,6 +532,30 @@ func file_test_proto_init() {
 				return nil
 			}
 		}
+		file_test_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPTest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderCreated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -343,7 +563,7 @@ func file_test_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_test_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,1470 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: test.proto
+
+package test
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TestService_NormalTestTest_FullMethodName                  = "/protonats.go.test.TestService/NormalTestTest"
+	TestService_NormalEmptyTest_FullMethodName                 = "/protonats.go.test.TestService/NormalEmptyTest"
+	TestService_NormalTestEmpty_FullMethodName                 = "/protonats.go.test.TestService/NormalTestEmpty"
+	TestService_NormalEmptyEmpty_FullMethodName                = "/protonats.go.test.TestService/NormalEmptyEmpty"
+	TestService_ErrServiceError_FullMethodName                 = "/protonats.go.test.TestService/ErrServiceError"
+	TestService_ErrServerError_FullMethodName                  = "/protonats.go.test.TestService/ErrServerError"
+	TestService_ErrServiceErrorBroadcast_FullMethodName        = "/protonats.go.test.TestService/ErrServiceErrorBroadcast"
+	TestService_ErrServerErrorBroadcast_FullMethodName         = "/protonats.go.test.TestService/ErrServerErrorBroadcast"
+	TestService_NormalBroadcastTestTest_FullMethodName         = "/protonats.go.test.TestService/NormalBroadcastTestTest"
+	TestService_NormalBroadcastEmptyTest_FullMethodName        = "/protonats.go.test.TestService/NormalBroadcastEmptyTest"
+	TestService_NormalBroadcastTestEmpty_FullMethodName        = "/protonats.go.test.TestService/NormalBroadcastTestEmpty"
+	TestService_NormalBroadcastEmptyEmpty_FullMethodName       = "/protonats.go.test.TestService/NormalBroadcastEmptyEmpty"
+	TestService_LeaderOnlyTestTest_FullMethodName              = "/protonats.go.test.TestService/LeaderOnlyTestTest"
+	TestService_LeaderOnlyEmptyTest_FullMethodName             = "/protonats.go.test.TestService/LeaderOnlyEmptyTest"
+	TestService_LeaderOnlyTestEmpty_FullMethodName             = "/protonats.go.test.TestService/LeaderOnlyTestEmpty"
+	TestService_LeaderOnlyEmptyEmpty_FullMethodName            = "/protonats.go.test.TestService/LeaderOnlyEmptyEmpty"
+	TestService_LeaderOnlyBroadcastTestTest_FullMethodName     = "/protonats.go.test.TestService/LeaderOnlyBroadcastTestTest"
+	TestService_LeaderOnlyBroadcastEmptyTest_FullMethodName    = "/protonats.go.test.TestService/LeaderOnlyBroadcastEmptyTest"
+	TestService_LeaderOnlyBroadcastTestEmpty_FullMethodName    = "/protonats.go.test.TestService/LeaderOnlyBroadcastTestEmpty"
+	TestService_LeaderOnlyBroadcastEmptyEmpty_FullMethodName   = "/protonats.go.test.TestService/LeaderOnlyBroadcastEmptyEmpty"
+	TestService_FollowerOnlyTestTest_FullMethodName            = "/protonats.go.test.TestService/FollowerOnlyTestTest"
+	TestService_FollowerOnlyEmptyTest_FullMethodName           = "/protonats.go.test.TestService/FollowerOnlyEmptyTest"
+	TestService_FollowerOnlyTestEmpty_FullMethodName           = "/protonats.go.test.TestService/FollowerOnlyTestEmpty"
+	TestService_FollowerOnlyEmptyEmpty_FullMethodName          = "/protonats.go.test.TestService/FollowerOnlyEmptyEmpty"
+	TestService_FollowerOnlyBroadcastTestTest_FullMethodName   = "/protonats.go.test.TestService/FollowerOnlyBroadcastTestTest"
+	TestService_FollowerOnlyBroadcastEmptyTest_FullMethodName  = "/protonats.go.test.TestService/FollowerOnlyBroadcastEmptyTest"
+	TestService_FollowerOnlyBroadcastTestEmpty_FullMethodName  = "/protonats.go.test.TestService/FollowerOnlyBroadcastTestEmpty"
+	TestService_FollowerOnlyBroadcastEmptyEmpty_FullMethodName = "/protonats.go.test.TestService/FollowerOnlyBroadcastEmptyEmpty"
+	TestService_ThreeSecondDelay_FullMethodName                = "/protonats.go.test.TestService/ThreeSecondDelay"
+	TestService_PooledDelay_FullMethodName                     = "/protonats.go.test.TestService/PooledDelay"
+	TestService_RateLimited_FullMethodName                     = "/protonats.go.test.TestService/RateLimited"
+	TestService_AdminOnly_FullMethodName                       = "/protonats.go.test.TestService/AdminOnly"
+	TestService_Durable_FullMethodName                         = "/protonats.go.test.TestService/Durable"
+	TestService_OneWay_FullMethodName                          = "/protonats.go.test.TestService/OneWay"
+	TestService_HTTPGet_FullMethodName                         = "/protonats.go.test.TestService/HTTPGet"
+	TestService_HTTPPost_FullMethodName                        = "/protonats.go.test.TestService/HTTPPost"
+)
+
+// TestServiceClient is the client API for TestService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TestServiceClient interface {
+	// Normal tests
+	NormalTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	NormalEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error)
+	NormalTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	NormalEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Methods that will expect an error in the test implementation
+	ErrServiceError(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	ErrServerError(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	ErrServiceErrorBroadcast(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	ErrServerErrorBroadcast(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	// Normal tests with broadcast option
+	NormalBroadcastTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	NormalBroadcastEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error)
+	NormalBroadcastTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	NormalBroadcastEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Leader option tests
+	LeaderOnlyTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	LeaderOnlyEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error)
+	LeaderOnlyTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LeaderOnlyEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Leader with broadcast option tests
+	LeaderOnlyBroadcastTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	LeaderOnlyBroadcastEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error)
+	LeaderOnlyBroadcastTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LeaderOnlyBroadcastEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Follower option tests
+	FollowerOnlyTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	FollowerOnlyEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error)
+	FollowerOnlyTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	FollowerOnlyEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Follower with broadcast option tests
+	FollowerOnlyBroadcastTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	FollowerOnlyBroadcastEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error)
+	FollowerOnlyBroadcastTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	FollowerOnlyBroadcastEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Special cases
+	ThreeSecondDelay(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PooledDelay(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RateLimited(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	AdminOnly(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	Durable(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error)
+	OneWay(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// HTTP gateway binding tests
+	HTTPGet(ctx context.Context, in *HTTPTest, opts ...grpc.CallOption) (*Test, error)
+	HTTPPost(ctx context.Context, in *HTTPTest, opts ...grpc.CallOption) (*Test, error)
+}
+
+type testServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTestServiceClient(cc grpc.ClientConnInterface) TestServiceClient {
+	return &testServiceClient{cc}
+}
+
+func (c *testServiceClient) NormalTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_NormalTestTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) NormalEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_NormalEmptyTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) NormalTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_NormalTestEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) NormalEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_NormalEmptyEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) ErrServiceError(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_ErrServiceError_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) ErrServerError(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_ErrServerError_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) ErrServiceErrorBroadcast(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_ErrServiceErrorBroadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) ErrServerErrorBroadcast(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_ErrServerErrorBroadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) NormalBroadcastTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_NormalBroadcastTestTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) NormalBroadcastEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_NormalBroadcastEmptyTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) NormalBroadcastTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_NormalBroadcastTestEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) NormalBroadcastEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_NormalBroadcastEmptyEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) LeaderOnlyTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_LeaderOnlyTestTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) LeaderOnlyEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_LeaderOnlyEmptyTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) LeaderOnlyTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_LeaderOnlyTestEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) LeaderOnlyEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_LeaderOnlyEmptyEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) LeaderOnlyBroadcastTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_LeaderOnlyBroadcastTestTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) LeaderOnlyBroadcastEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_LeaderOnlyBroadcastEmptyTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) LeaderOnlyBroadcastTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_LeaderOnlyBroadcastTestEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) LeaderOnlyBroadcastEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_LeaderOnlyBroadcastEmptyEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) FollowerOnlyTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_FollowerOnlyTestTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) FollowerOnlyEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_FollowerOnlyEmptyTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) FollowerOnlyTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_FollowerOnlyTestEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) FollowerOnlyEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_FollowerOnlyEmptyEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) FollowerOnlyBroadcastTestTest(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_FollowerOnlyBroadcastTestTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) FollowerOnlyBroadcastEmptyTest(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_FollowerOnlyBroadcastEmptyTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) FollowerOnlyBroadcastTestEmpty(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_FollowerOnlyBroadcastTestEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) FollowerOnlyBroadcastEmptyEmpty(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_FollowerOnlyBroadcastEmptyEmpty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) ThreeSecondDelay(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_ThreeSecondDelay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) PooledDelay(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_PooledDelay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) RateLimited(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_RateLimited_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) AdminOnly(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_AdminOnly_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) Durable(ctx context.Context, in *Test, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_Durable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) OneWay(ctx context.Context, in *Test, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestService_OneWay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) HTTPGet(ctx context.Context, in *HTTPTest, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_HTTPGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) HTTPPost(ctx context.Context, in *HTTPTest, opts ...grpc.CallOption) (*Test, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Test)
+	err := c.cc.Invoke(ctx, TestService_HTTPPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestServiceServer is the server API for TestService service.
+// All implementations must embed UnimplementedTestServiceServer
+// for forward compatibility.
+type TestServiceServer interface {
+	// Normal tests
+	NormalTestTest(context.Context, *Test) (*Test, error)
+	NormalEmptyTest(context.Context, *emptypb.Empty) (*Test, error)
+	NormalTestEmpty(context.Context, *Test) (*emptypb.Empty, error)
+	NormalEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Methods that will expect an error in the test implementation
+	ErrServiceError(context.Context, *Test) (*Test, error)
+	ErrServerError(context.Context, *Test) (*Test, error)
+	ErrServiceErrorBroadcast(context.Context, *Test) (*Test, error)
+	ErrServerErrorBroadcast(context.Context, *Test) (*Test, error)
+	// Normal tests with broadcast option
+	NormalBroadcastTestTest(context.Context, *Test) (*Test, error)
+	NormalBroadcastEmptyTest(context.Context, *emptypb.Empty) (*Test, error)
+	NormalBroadcastTestEmpty(context.Context, *Test) (*emptypb.Empty, error)
+	NormalBroadcastEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Leader option tests
+	LeaderOnlyTestTest(context.Context, *Test) (*Test, error)
+	LeaderOnlyEmptyTest(context.Context, *emptypb.Empty) (*Test, error)
+	LeaderOnlyTestEmpty(context.Context, *Test) (*emptypb.Empty, error)
+	LeaderOnlyEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Leader with broadcast option tests
+	LeaderOnlyBroadcastTestTest(context.Context, *Test) (*Test, error)
+	LeaderOnlyBroadcastEmptyTest(context.Context, *emptypb.Empty) (*Test, error)
+	LeaderOnlyBroadcastTestEmpty(context.Context, *Test) (*emptypb.Empty, error)
+	LeaderOnlyBroadcastEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Follower option tests
+	FollowerOnlyTestTest(context.Context, *Test) (*Test, error)
+	FollowerOnlyEmptyTest(context.Context, *emptypb.Empty) (*Test, error)
+	FollowerOnlyTestEmpty(context.Context, *Test) (*emptypb.Empty, error)
+	FollowerOnlyEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Follower with broadcast option tests
+	FollowerOnlyBroadcastTestTest(context.Context, *Test) (*Test, error)
+	FollowerOnlyBroadcastEmptyTest(context.Context, *emptypb.Empty) (*Test, error)
+	FollowerOnlyBroadcastTestEmpty(context.Context, *Test) (*emptypb.Empty, error)
+	FollowerOnlyBroadcastEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Special cases
+	ThreeSecondDelay(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	PooledDelay(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	RateLimited(context.Context, *Test) (*Test, error)
+	AdminOnly(context.Context, *Test) (*Test, error)
+	Durable(context.Context, *Test) (*Test, error)
+	OneWay(context.Context, *Test) (*emptypb.Empty, error)
+	// HTTP gateway binding tests
+	HTTPGet(context.Context, *HTTPTest) (*Test, error)
+	HTTPPost(context.Context, *HTTPTest) (*Test, error)
+	mustEmbedUnimplementedTestServiceServer()
+}
+
+// UnimplementedTestServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTestServiceServer struct{}
+
+func (UnimplementedTestServiceServer) NormalTestTest(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalTestTest not implemented")
+}
+func (UnimplementedTestServiceServer) NormalEmptyTest(context.Context, *emptypb.Empty) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalEmptyTest not implemented")
+}
+func (UnimplementedTestServiceServer) NormalTestEmpty(context.Context, *Test) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalTestEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) NormalEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalEmptyEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) ErrServiceError(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ErrServiceError not implemented")
+}
+func (UnimplementedTestServiceServer) ErrServerError(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ErrServerError not implemented")
+}
+func (UnimplementedTestServiceServer) ErrServiceErrorBroadcast(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ErrServiceErrorBroadcast not implemented")
+}
+func (UnimplementedTestServiceServer) ErrServerErrorBroadcast(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ErrServerErrorBroadcast not implemented")
+}
+func (UnimplementedTestServiceServer) NormalBroadcastTestTest(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalBroadcastTestTest not implemented")
+}
+func (UnimplementedTestServiceServer) NormalBroadcastEmptyTest(context.Context, *emptypb.Empty) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalBroadcastEmptyTest not implemented")
+}
+func (UnimplementedTestServiceServer) NormalBroadcastTestEmpty(context.Context, *Test) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalBroadcastTestEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) NormalBroadcastEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalBroadcastEmptyEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) LeaderOnlyTestTest(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaderOnlyTestTest not implemented")
+}
+func (UnimplementedTestServiceServer) LeaderOnlyEmptyTest(context.Context, *emptypb.Empty) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaderOnlyEmptyTest not implemented")
+}
+func (UnimplementedTestServiceServer) LeaderOnlyTestEmpty(context.Context, *Test) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaderOnlyTestEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) LeaderOnlyEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaderOnlyEmptyEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) LeaderOnlyBroadcastTestTest(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaderOnlyBroadcastTestTest not implemented")
+}
+func (UnimplementedTestServiceServer) LeaderOnlyBroadcastEmptyTest(context.Context, *emptypb.Empty) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaderOnlyBroadcastEmptyTest not implemented")
+}
+func (UnimplementedTestServiceServer) LeaderOnlyBroadcastTestEmpty(context.Context, *Test) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaderOnlyBroadcastTestEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) LeaderOnlyBroadcastEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaderOnlyBroadcastEmptyEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) FollowerOnlyTestTest(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowerOnlyTestTest not implemented")
+}
+func (UnimplementedTestServiceServer) FollowerOnlyEmptyTest(context.Context, *emptypb.Empty) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowerOnlyEmptyTest not implemented")
+}
+func (UnimplementedTestServiceServer) FollowerOnlyTestEmpty(context.Context, *Test) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowerOnlyTestEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) FollowerOnlyEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowerOnlyEmptyEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) FollowerOnlyBroadcastTestTest(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowerOnlyBroadcastTestTest not implemented")
+}
+func (UnimplementedTestServiceServer) FollowerOnlyBroadcastEmptyTest(context.Context, *emptypb.Empty) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowerOnlyBroadcastEmptyTest not implemented")
+}
+func (UnimplementedTestServiceServer) FollowerOnlyBroadcastTestEmpty(context.Context, *Test) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowerOnlyBroadcastTestEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) FollowerOnlyBroadcastEmptyEmpty(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowerOnlyBroadcastEmptyEmpty not implemented")
+}
+func (UnimplementedTestServiceServer) ThreeSecondDelay(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThreeSecondDelay not implemented")
+}
+func (UnimplementedTestServiceServer) PooledDelay(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PooledDelay not implemented")
+}
+func (UnimplementedTestServiceServer) RateLimited(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateLimited not implemented")
+}
+func (UnimplementedTestServiceServer) AdminOnly(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminOnly not implemented")
+}
+func (UnimplementedTestServiceServer) Durable(context.Context, *Test) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Durable not implemented")
+}
+func (UnimplementedTestServiceServer) OneWay(context.Context, *Test) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OneWay not implemented")
+}
+func (UnimplementedTestServiceServer) HTTPGet(context.Context, *HTTPTest) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HTTPGet not implemented")
+}
+func (UnimplementedTestServiceServer) HTTPPost(context.Context, *HTTPTest) (*Test, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HTTPPost not implemented")
+}
+func (UnimplementedTestServiceServer) mustEmbedUnimplementedTestServiceServer() {}
+func (UnimplementedTestServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeTestServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TestServiceServer will
+// result in compilation errors.
+type UnsafeTestServiceServer interface {
+	mustEmbedUnimplementedTestServiceServer()
+}
+
+func RegisterTestServiceServer(s grpc.ServiceRegistrar, srv TestServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTestServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TestService_ServiceDesc, srv)
+}
+
+func _TestService_NormalTestTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).NormalTestTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_NormalTestTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).NormalTestTest(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_NormalEmptyTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).NormalEmptyTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_NormalEmptyTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).NormalEmptyTest(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_NormalTestEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).NormalTestEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_NormalTestEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).NormalTestEmpty(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_NormalEmptyEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).NormalEmptyEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_NormalEmptyEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).NormalEmptyEmpty(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_ErrServiceError_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).ErrServiceError(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_ErrServiceError_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).ErrServiceError(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_ErrServerError_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).ErrServerError(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_ErrServerError_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).ErrServerError(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_ErrServiceErrorBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).ErrServiceErrorBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_ErrServiceErrorBroadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).ErrServiceErrorBroadcast(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_ErrServerErrorBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).ErrServerErrorBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_ErrServerErrorBroadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).ErrServerErrorBroadcast(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_NormalBroadcastTestTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).NormalBroadcastTestTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_NormalBroadcastTestTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).NormalBroadcastTestTest(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_NormalBroadcastEmptyTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).NormalBroadcastEmptyTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_NormalBroadcastEmptyTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).NormalBroadcastEmptyTest(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_NormalBroadcastTestEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).NormalBroadcastTestEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_NormalBroadcastTestEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).NormalBroadcastTestEmpty(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_NormalBroadcastEmptyEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).NormalBroadcastEmptyEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_NormalBroadcastEmptyEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).NormalBroadcastEmptyEmpty(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_LeaderOnlyTestTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).LeaderOnlyTestTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_LeaderOnlyTestTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).LeaderOnlyTestTest(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_LeaderOnlyEmptyTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).LeaderOnlyEmptyTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_LeaderOnlyEmptyTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).LeaderOnlyEmptyTest(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_LeaderOnlyTestEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).LeaderOnlyTestEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_LeaderOnlyTestEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).LeaderOnlyTestEmpty(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_LeaderOnlyEmptyEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).LeaderOnlyEmptyEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_LeaderOnlyEmptyEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).LeaderOnlyEmptyEmpty(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_LeaderOnlyBroadcastTestTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).LeaderOnlyBroadcastTestTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_LeaderOnlyBroadcastTestTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).LeaderOnlyBroadcastTestTest(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_LeaderOnlyBroadcastEmptyTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).LeaderOnlyBroadcastEmptyTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_LeaderOnlyBroadcastEmptyTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).LeaderOnlyBroadcastEmptyTest(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_LeaderOnlyBroadcastTestEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).LeaderOnlyBroadcastTestEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_LeaderOnlyBroadcastTestEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).LeaderOnlyBroadcastTestEmpty(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_LeaderOnlyBroadcastEmptyEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).LeaderOnlyBroadcastEmptyEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_LeaderOnlyBroadcastEmptyEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).LeaderOnlyBroadcastEmptyEmpty(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_FollowerOnlyTestTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).FollowerOnlyTestTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_FollowerOnlyTestTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).FollowerOnlyTestTest(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_FollowerOnlyEmptyTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).FollowerOnlyEmptyTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_FollowerOnlyEmptyTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).FollowerOnlyEmptyTest(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_FollowerOnlyTestEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).FollowerOnlyTestEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_FollowerOnlyTestEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).FollowerOnlyTestEmpty(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_FollowerOnlyEmptyEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).FollowerOnlyEmptyEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_FollowerOnlyEmptyEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).FollowerOnlyEmptyEmpty(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_FollowerOnlyBroadcastTestTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).FollowerOnlyBroadcastTestTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_FollowerOnlyBroadcastTestTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).FollowerOnlyBroadcastTestTest(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_FollowerOnlyBroadcastEmptyTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).FollowerOnlyBroadcastEmptyTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_FollowerOnlyBroadcastEmptyTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).FollowerOnlyBroadcastEmptyTest(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_FollowerOnlyBroadcastTestEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).FollowerOnlyBroadcastTestEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_FollowerOnlyBroadcastTestEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).FollowerOnlyBroadcastTestEmpty(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_FollowerOnlyBroadcastEmptyEmpty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).FollowerOnlyBroadcastEmptyEmpty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_FollowerOnlyBroadcastEmptyEmpty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).FollowerOnlyBroadcastEmptyEmpty(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_ThreeSecondDelay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).ThreeSecondDelay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_ThreeSecondDelay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).ThreeSecondDelay(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_PooledDelay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).PooledDelay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_PooledDelay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).PooledDelay(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_RateLimited_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).RateLimited(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_RateLimited_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).RateLimited(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_AdminOnly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).AdminOnly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_AdminOnly_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).AdminOnly(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_Durable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).Durable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_Durable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).Durable(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_OneWay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Test)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).OneWay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_OneWay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).OneWay(ctx, req.(*Test))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_HTTPGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HTTPTest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).HTTPGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_HTTPGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).HTTPGet(ctx, req.(*HTTPTest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_HTTPPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HTTPTest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).HTTPPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_HTTPPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).HTTPPost(ctx, req.(*HTTPTest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TestService_ServiceDesc is the grpc.ServiceDesc for TestService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TestService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protonats.go.test.TestService",
+	HandlerType: (*TestServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NormalTestTest",
+			Handler:    _TestService_NormalTestTest_Handler,
+		},
+		{
+			MethodName: "NormalEmptyTest",
+			Handler:    _TestService_NormalEmptyTest_Handler,
+		},
+		{
+			MethodName: "NormalTestEmpty",
+			Handler:    _TestService_NormalTestEmpty_Handler,
+		},
+		{
+			MethodName: "NormalEmptyEmpty",
+			Handler:    _TestService_NormalEmptyEmpty_Handler,
+		},
+		{
+			MethodName: "ErrServiceError",
+			Handler:    _TestService_ErrServiceError_Handler,
+		},
+		{
+			MethodName: "ErrServerError",
+			Handler:    _TestService_ErrServerError_Handler,
+		},
+		{
+			MethodName: "ErrServiceErrorBroadcast",
+			Handler:    _TestService_ErrServiceErrorBroadcast_Handler,
+		},
+		{
+			MethodName: "ErrServerErrorBroadcast",
+			Handler:    _TestService_ErrServerErrorBroadcast_Handler,
+		},
+		{
+			MethodName: "NormalBroadcastTestTest",
+			Handler:    _TestService_NormalBroadcastTestTest_Handler,
+		},
+		{
+			MethodName: "NormalBroadcastEmptyTest",
+			Handler:    _TestService_NormalBroadcastEmptyTest_Handler,
+		},
+		{
+			MethodName: "NormalBroadcastTestEmpty",
+			Handler:    _TestService_NormalBroadcastTestEmpty_Handler,
+		},
+		{
+			MethodName: "NormalBroadcastEmptyEmpty",
+			Handler:    _TestService_NormalBroadcastEmptyEmpty_Handler,
+		},
+		{
+			MethodName: "LeaderOnlyTestTest",
+			Handler:    _TestService_LeaderOnlyTestTest_Handler,
+		},
+		{
+			MethodName: "LeaderOnlyEmptyTest",
+			Handler:    _TestService_LeaderOnlyEmptyTest_Handler,
+		},
+		{
+			MethodName: "LeaderOnlyTestEmpty",
+			Handler:    _TestService_LeaderOnlyTestEmpty_Handler,
+		},
+		{
+			MethodName: "LeaderOnlyEmptyEmpty",
+			Handler:    _TestService_LeaderOnlyEmptyEmpty_Handler,
+		},
+		{
+			MethodName: "LeaderOnlyBroadcastTestTest",
+			Handler:    _TestService_LeaderOnlyBroadcastTestTest_Handler,
+		},
+		{
+			MethodName: "LeaderOnlyBroadcastEmptyTest",
+			Handler:    _TestService_LeaderOnlyBroadcastEmptyTest_Handler,
+		},
+		{
+			MethodName: "LeaderOnlyBroadcastTestEmpty",
+			Handler:    _TestService_LeaderOnlyBroadcastTestEmpty_Handler,
+		},
+		{
+			MethodName: "LeaderOnlyBroadcastEmptyEmpty",
+			Handler:    _TestService_LeaderOnlyBroadcastEmptyEmpty_Handler,
+		},
+		{
+			MethodName: "FollowerOnlyTestTest",
+			Handler:    _TestService_FollowerOnlyTestTest_Handler,
+		},
+		{
+			MethodName: "FollowerOnlyEmptyTest",
+			Handler:    _TestService_FollowerOnlyEmptyTest_Handler,
+		},
+		{
+			MethodName: "FollowerOnlyTestEmpty",
+			Handler:    _TestService_FollowerOnlyTestEmpty_Handler,
+		},
+		{
+			MethodName: "FollowerOnlyEmptyEmpty",
+			Handler:    _TestService_FollowerOnlyEmptyEmpty_Handler,
+		},
+		{
+			MethodName: "FollowerOnlyBroadcastTestTest",
+			Handler:    _TestService_FollowerOnlyBroadcastTestTest_Handler,
+		},
+		{
+			MethodName: "FollowerOnlyBroadcastEmptyTest",
+			Handler:    _TestService_FollowerOnlyBroadcastEmptyTest_Handler,
+		},
+		{
+			MethodName: "FollowerOnlyBroadcastTestEmpty",
+			Handler:    _TestService_FollowerOnlyBroadcastTestEmpty_Handler,
+		},
+		{
+			MethodName: "FollowerOnlyBroadcastEmptyEmpty",
+			Handler:    _TestService_FollowerOnlyBroadcastEmptyEmpty_Handler,
+		},
+		{
+			MethodName: "ThreeSecondDelay",
+			Handler:    _TestService_ThreeSecondDelay_Handler,
+		},
+		{
+			MethodName: "PooledDelay",
+			Handler:    _TestService_PooledDelay_Handler,
+		},
+		{
+			MethodName: "RateLimited",
+			Handler:    _TestService_RateLimited_Handler,
+		},
+		{
+			MethodName: "AdminOnly",
+			Handler:    _TestService_AdminOnly_Handler,
+		},
+		{
+			MethodName: "Durable",
+			Handler:    _TestService_Durable_Handler,
+		},
+		{
+			MethodName: "OneWay",
+			Handler:    _TestService_OneWay_Handler,
+		},
+		{
+			MethodName: "HTTPGet",
+			Handler:    _TestService_HTTPGet_Handler,
+		},
+		{
+			MethodName: "HTTPPost",
+			Handler:    _TestService_HTTPPost_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "test.proto",
+}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-nats. DO NOT EDIT.
 // Versions:
-// - protoc-gen-go-nats v0.1.13
+// - protoc-gen-go-nats v0.1.14+dirty
 // - protoc        v5.29.3
 // source: test.proto
 
@@ -10,13 +10,17 @@ import (
 	context "context"
 	json "encoding/json"
 	errors "errors"
+	fmt "fmt"
 	nats_go "github.com/nats-io/nats.go"
 	micro "github.com/nats-io/nats.go/micro"
+	nkeys "github.com/nats-io/nkeys"
 	nuid "github.com/nats-io/nuid"
 	proto "google.golang.org/protobuf/proto"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
 	slog "log/slog"
 	sync "sync"
 	time "time"
+	gonats "xiam.li/go-protonats/gonats"
 	impl "xiam.li/protonats/go/impl"
 	protonats "xiam.li/protonats/go/protonats"
 )
@@ -31,36 +35,84 @@ type TestServiceNATSClient interface {
 	// Methods that will expect an error in the test implementation
 	ErrServiceError(req *Test, opts ...protonats.CallOption) (*Test, error)
 	ErrServerError(req *Test, opts ...protonats.CallOption) (*Test, error)
-	ErrServiceErrorBroadcast(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error)
-	ErrServerErrorBroadcast(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error)
+	ErrServiceErrorBroadcast(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	// ErrServiceErrorBroadcastTo is like ErrServiceErrorBroadcast, but only sends the request to the direct endpoints of the instances selected by target
+	ErrServiceErrorBroadcastTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	ErrServerErrorBroadcast(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	// ErrServerErrorBroadcastTo is like ErrServerErrorBroadcast, but only sends the request to the direct endpoints of the instances selected by target
+	ErrServerErrorBroadcastTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
 	// Normal tests with broadcast option
-	NormalBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error)
-	NormalBroadcastEmptyTest(opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error)
-	NormalBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]protonats.ServiceError, error)
-	NormalBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]protonats.ServiceError, error)
+	NormalBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	// NormalBroadcastTestTestTo is like NormalBroadcastTestTest, but only sends the request to the direct endpoints of the instances selected by target
+	NormalBroadcastTestTestTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	NormalBroadcastEmptyTest(opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	// NormalBroadcastEmptyTestTo is like NormalBroadcastEmptyTest, but only sends the request to the direct endpoints of the instances selected by target
+	NormalBroadcastEmptyTestTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	NormalBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
+	// NormalBroadcastTestEmptyTo is like NormalBroadcastTestEmpty, but only sends the request to the direct endpoints of the instances selected by target
+	NormalBroadcastTestEmptyTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
+	NormalBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
+	// NormalBroadcastEmptyEmptyTo is like NormalBroadcastEmptyEmpty, but only sends the request to the direct endpoints of the instances selected by target
+	NormalBroadcastEmptyEmptyTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
 	// Leader option tests
 	LeaderOnlyTestTest(req *Test, opts ...protonats.CallOption) (*Test, error)
 	LeaderOnlyEmptyTest(opts ...protonats.CallOption) (*Test, error)
 	LeaderOnlyTestEmpty(req *Test, opts ...protonats.CallOption) error
 	LeaderOnlyEmptyEmpty(opts ...protonats.CallOption) error
 	// Leader with broadcast option tests
-	LeaderOnlyBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error)
-	LeaderOnlyBroadcastEmptyTest(opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error)
-	LeaderOnlyBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]protonats.ServiceError, error)
-	LeaderOnlyBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]protonats.ServiceError, error)
+	LeaderOnlyBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	// LeaderOnlyBroadcastTestTestTo is like LeaderOnlyBroadcastTestTest, but only sends the request to the direct endpoints of the instances selected by target
+	LeaderOnlyBroadcastTestTestTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	LeaderOnlyBroadcastEmptyTest(opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	// LeaderOnlyBroadcastEmptyTestTo is like LeaderOnlyBroadcastEmptyTest, but only sends the request to the direct endpoints of the instances selected by target
+	LeaderOnlyBroadcastEmptyTestTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	LeaderOnlyBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
+	// LeaderOnlyBroadcastTestEmptyTo is like LeaderOnlyBroadcastTestEmpty, but only sends the request to the direct endpoints of the instances selected by target
+	LeaderOnlyBroadcastTestEmptyTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
+	LeaderOnlyBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
+	// LeaderOnlyBroadcastEmptyEmptyTo is like LeaderOnlyBroadcastEmptyEmpty, but only sends the request to the direct endpoints of the instances selected by target
+	LeaderOnlyBroadcastEmptyEmptyTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
 	// Follower option tests
 	FollowerOnlyTestTest(req *Test, opts ...protonats.CallOption) (*Test, error)
 	FollowerOnlyEmptyTest(opts ...protonats.CallOption) (*Test, error)
 	FollowerOnlyTestEmpty(req *Test, opts ...protonats.CallOption) error
 	FollowerOnlyEmptyEmpty(opts ...protonats.CallOption) error
 	// Follower with broadcast option tests
-	FollowerOnlyBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error)
-	FollowerOnlyBroadcastEmptyTest(opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error)
-	FollowerOnlyBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]protonats.ServiceError, error)
-	FollowerOnlyBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]protonats.ServiceError, error)
+	FollowerOnlyBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	// FollowerOnlyBroadcastTestTestTo is like FollowerOnlyBroadcastTestTest, but only sends the request to the direct endpoints of the instances selected by target
+	FollowerOnlyBroadcastTestTestTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	FollowerOnlyBroadcastEmptyTest(opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	// FollowerOnlyBroadcastEmptyTestTo is like FollowerOnlyBroadcastEmptyTest, but only sends the request to the direct endpoints of the instances selected by target
+	FollowerOnlyBroadcastEmptyTestTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error)
+	FollowerOnlyBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
+	// FollowerOnlyBroadcastTestEmptyTo is like FollowerOnlyBroadcastTestEmpty, but only sends the request to the direct endpoints of the instances selected by target
+	FollowerOnlyBroadcastTestEmptyTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
+	FollowerOnlyBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
+	// FollowerOnlyBroadcastEmptyEmptyTo is like FollowerOnlyBroadcastEmptyEmpty, but only sends the request to the direct endpoints of the instances selected by target
+	FollowerOnlyBroadcastEmptyEmptyTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error)
 	// Special cases
 	ThreeSecondDelay(opts ...protonats.CallOption) error
+	PooledDelay(opts ...protonats.CallOption) error
+	RateLimited(req *Test, opts ...protonats.CallOption) (*Test, error)
+	AdminOnly(req *Test, opts ...protonats.CallOption) (*Test, error)
+	Durable(req *Test, opts ...protonats.CallOption) (*gonats.DurableCall[*Test], error)
+	// DurableReplyTo is like Durable, but also publishes the result to the subject reply
+	DurableReplyTo(reply string, req *Test, opts ...protonats.CallOption) (*gonats.DurableCall[*Test], error)
+	OneWay(req *Test, opts ...protonats.CallOption) error
+	// HTTP gateway binding tests
+	HTTPGet(req *HTTPTest, opts ...protonats.CallOption) (*Test, error)
+	HTTPPost(req *HTTPTest, opts ...protonats.CallOption) (*Test, error)
+	// Leader returns the instance ID of the current leader of this service, to which calls with gonats.WithLeader are pinned
+	Leader(opts ...protonats.CallOption) (string, error)
 	SetTimeout(time.Duration)
+	// SetBalancer sends all following calls to the direct endpoint of the instance picked by balancer, or to the queue group if it is nil
+	SetBalancer(balancer gonats.Balancer)
+	// SetSigner signs all following requests with the NKey signer, or stops signing them if it is nil
+	SetSigner(signer nkeys.KeyPair)
+	// SetXKey encrypts all following requests and their responses end to end with the public xkey of the service, or stops encrypting them if it is empty
+	SetXKey(xkey string)
+	// EnableEncryption encrypts all following requests and their responses end to end with the xkey advertised by the instances of this service
+	EnableEncryption(opts ...protonats.CallOption) error
 	// ListInstances returns a list containing all instances of this service
 	// This is a convenience method that calls protonats.Ping with no options
 	ListInstances() ([]*protonats.Ping, error)
@@ -70,45 +122,98 @@ type TestServiceNATSClient interface {
 	Stats(opts ...protonats.CallOption) ([]*micro.Stats, error)
 	// Info returns the info of either all instances or a specific instance of this service
 	Info(opts ...protonats.CallOption) ([]*micro.Info, error)
+	// Reflect returns the descriptors of the proto file declaring this service and all of its dependencies from either any or a specific instance of this service
+	Reflect(opts ...protonats.CallOption) (*descriptorpb.FileDescriptorSet, error)
 }
 
 type testServiceNATSClient struct {
-	nc      *nats_go.Conn
-	timeout time.Duration
+	nc        *nats_go.Conn
+	timeout   time.Duration
+	config    gonats.ClientConfig
+	balancing gonats.Balancing
+	leader    gonats.LeaderCache
 }
 
 func (c *testServiceNATSClient) SetTimeout(timeout time.Duration) {
 	c.timeout = timeout
 }
 
+func (c *testServiceNATSClient) SetBalancer(balancer gonats.Balancer) {
+	c.balancing.Set(balancer)
+}
+
+func (c *testServiceNATSClient) instances(subject string) ([]gonats.Instance, error) {
+	results, err := request(c.nc, c.timeout, c.config.Unencrypted(), "$SRV.INFO.TestService", nil, nil, func(data []byte, rtt time.Duration) (*micro.Info, error) {
+		var obj micro.Info
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	})
+	return gonats.ServingInstances(results, subject), err
+}
+
+func (c *testServiceNATSClient) SetSigner(signer nkeys.KeyPair) {
+	c.config.Signer = signer
+}
+
+func (c *testServiceNATSClient) SetXKey(xkey string) {
+	c.config.XKey = xkey
+}
+
+func (c *testServiceNATSClient) EnableEncryption(opts ...protonats.CallOption) error {
+	infos, err := c.Info(opts...)
+	if err != nil {
+		return err
+	}
+	xkey, err := gonats.ServiceXKey(infos)
+	if err != nil {
+		return err
+	}
+	c.config.XKey = xkey
+	return nil
+}
+
 func (c *testServiceNATSClient) ListInstances() ([]*protonats.Ping, error) {
 	return c.Ping()
 }
 
 func (c *testServiceNATSClient) Stats(opts ...protonats.CallOption) ([]*micro.Stats, error) {
-	objs, _, err := request(c.nc, c.timeout, "$SRV.STATS.TestService", nil, func(data []byte, rtt time.Duration) (*micro.Stats, error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	results, err := request(c.nc, c.timeout, c.config.Unencrypted(), "$SRV.STATS.TestService", nil, nil, func(data []byte, rtt time.Duration) (*micro.Stats, error) {
 		var obj micro.Stats
 		if err := json.Unmarshal(data, &obj); err != nil {
 			return nil, err
 		}
 		return &obj, nil
 	}, opts...)
-	return objs, err
+	return gonats.Responses(results), err
 }
 
 func (c *testServiceNATSClient) Info(opts ...protonats.CallOption) ([]*micro.Info, error) {
-	objs, _, err := request(c.nc, c.timeout, "$SRV.INFO.TestService", nil, func(data []byte, rtt time.Duration) (*micro.Info, error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	results, err := request(c.nc, c.timeout, c.config.Unencrypted(), "$SRV.INFO.TestService", nil, nil, func(data []byte, rtt time.Duration) (*micro.Info, error) {
 		var obj micro.Info
 		if err := json.Unmarshal(data, &obj); err != nil {
 			return nil, err
 		}
 		return &obj, nil
 	}, opts...)
-	return objs, err
+	return gonats.Responses(results), err
 }
 
 func (c *testServiceNATSClient) Ping(opts ...protonats.CallOption) ([]*protonats.Ping, error) {
-	objs, _, err := request(c.nc, c.timeout, "$SRV.PING.TestService", nil, func(data []byte, rtt time.Duration) (*protonats.Ping, error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	results, err := request(c.nc, c.timeout, c.config.Unencrypted(), "$SRV.PING.TestService", nil, nil, func(data []byte, rtt time.Duration) (*protonats.Ping, error) {
 		var obj protonats.Ping
 		if err := json.Unmarshal(data, &obj); err != nil {
 			return nil, err
@@ -116,17 +221,65 @@ func (c *testServiceNATSClient) Ping(opts ...protonats.CallOption) ([]*protonats
 		obj.RTT = rtt
 		return &obj, nil
 	}, opts...)
-	return objs, err
+	return gonats.Responses(results), err
 }
 
-func (c *testServiceNATSClient) handleWithRetry(req proto.Message, subject string, out proto.Message, opts ...protonats.CallOption) (err error) {
+func (c *testServiceNATSClient) Reflect(opts ...protonats.CallOption) (*descriptorpb.FileDescriptorSet, error) {
+	var response descriptorpb.FileDescriptorSet
+	if err := c.handleWithRetry(c.config.Unencrypted(), nil, gonats.ReflectionSubject("TestService"), false, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *testServiceNATSClient) Leader(opts ...protonats.CallOption) (string, error) {
+	results, err := request(c.nc, c.timeout, c.config.Unencrypted(), gonats.RoleSubject("TestService"), nil, nil, func(data []byte, rtt time.Duration) (gonats.Role, error) {
+		return gonats.Role(data), nil
+	}, append(opts[:len(opts):len(opts)], protonats.WithInstanceID(""))...)
+	if err != nil {
+		return "", err
+	}
+	id, err := gonats.LeaderOf(results)
+	if err != nil {
+		return "", err
+	}
+	c.leader.Set(id)
+	return id, nil
+}
+
+// resolveLeader replaces the instance ID set by gonats.WithLeader in opts with the ID of the current leader.
+func (c *testServiceNATSClient) resolveLeader(opts []protonats.CallOption) ([]protonats.CallOption, error) {
+	return gonats.ResolveLeader(opts, func() (string, error) { return c.Leader(opts...) })
+}
+
+func (c *testServiceNATSClient) handleWithRetry(config gonats.ClientConfig, req proto.Message, subject string, balanced bool, out proto.Message, opts ...protonats.CallOption) (err error) {
 	options := impl.ProcessCallOptions(opts...)
 	timeout := options.GetTimeoutOr(c.timeout)
+	balanced = balanced && options.InstanceID == ""
+	pinned := options.InstanceID == gonats.LeaderInstance
+	var rediscovered bool
 
 	var tries int
 	for {
-		err = c.handle(options.Context, req, options.Subject(subject), out, timeout)
-		if err == nil || !errors.Is(err, nats_go.ErrNoResponders) {
+		if pinned {
+			if options.InstanceID, err = c.leader.Get(func() (string, error) { return c.Leader(opts...) }); err != nil {
+				return
+			}
+		}
+		if balanced {
+			options.InstanceID = c.balancing.Pick(options.Subject(subject), c.instances)
+		}
+		err = c.handle(options.Context, config, req, options.Subject(subject), out, timeout)
+		if balanced {
+			c.balancing.Done(options.InstanceID, err)
+		}
+		// A former leader is forgotten, and the call repeated once with the current one
+		if pinned && c.leader.Failed(options.InstanceID, err) && !rediscovered && (options.Context == nil || options.Context.Err() == nil) {
+			rediscovered = true
+			continue
+		}
+		retryAfter, rateLimited := gonats.RetryAfter(err)
+		if err == nil || !rateLimited && !errors.Is(err, nats_go.ErrNoResponders) {
 			return
 		}
 		tries++
@@ -138,46 +291,100 @@ func (c *testServiceNATSClient) handleWithRetry(req proto.Message, subject strin
 			return
 		}
 		if tries >= options.Retries {
-			err = errors.New("Failed to call service after max tries: " + err.Error())
+			err = fmt.Errorf("Failed to call service after max tries: %w", err)
 			return
 		}
-		time.Sleep(options.RetryDelay)
+		time.Sleep(max(options.RetryDelay, retryAfter))
 	}
 }
-func (c *testServiceNATSClient) handle(ctx context.Context, req proto.Message, subject string, out proto.Message, timeout time.Duration) (err error) {
+func (c *testServiceNATSClient) handle(ctx context.Context, config gonats.ClientConfig, req proto.Message, subject string, out proto.Message, timeout time.Duration) (err error) {
 	var data []byte
 	if req != nil {
 		if data, err = proto.Marshal(req); err != nil {
 			return protonats.ErrMarshallingFailed
 		}
 	}
-	var msg *nats_go.Msg
+	sealer, err := gonats.NewSealer(config.XKey)
+	if err != nil {
+		return err
+	}
+	msg, err := gonats.NewRequest(c.nc, subject, data, config.Signer, sealer)
+	if err != nil {
+		return err
+	}
+	gonats.SetTimeout(msg, ctx, timeout)
 	if ctx == nil {
-		msg, err = c.nc.Request(subject, data, timeout)
+		msg, err = c.nc.RequestMsg(msg, timeout)
 	} else {
-		msg, err = c.nc.RequestWithContext(ctx, subject, data)
+		msg, err = c.nc.RequestMsgWithContext(ctx, msg)
 	}
 	if err != nil {
 		return err
 	}
+	if data, err = sealer.Open(msg); err != nil {
+		return err
+	}
 	if errMsg, errCode := msg.Header.Get(micro.ErrorHeader), msg.Header.Get(micro.ErrorCodeHeader); len(errMsg) > 0 && len(errCode) > 0 {
-		if len(msg.Data) == 0 {
-			return protonats.ServiceError{errCode, errMsg, ""}
-		}
-		return protonats.ServiceError{errCode, errMsg, string(msg.Data)}
+		return gonats.WithRetryAfter(protonats.ServiceError{Code: errCode, Description: errMsg, Details: string(data)}, msg.Header)
 	}
 	if out != nil {
-		if err = proto.Unmarshal(msg.Data, out); err != nil {
+		if err = proto.Unmarshal(data, out); err != nil {
 			return protonats.ErrUnmarshallingFailed
 		}
 	}
 	return nil
 }
 
-func request[T any](conn *nats_go.Conn, timeout time.Duration, subject string, data []byte, collector func([]byte, time.Duration) (*T, error), opts ...protonats.CallOption) ([]*T, []protonats.ServiceError, error) {
+// publish sends req to subject without waiting for a response, returning once it is flushed to the server.
+func (c *testServiceNATSClient) publish(req proto.Message, subject string, opts ...protonats.CallOption) (err error) {
+	var data []byte
+	if req != nil {
+		if data, err = proto.Marshal(req); err != nil {
+			return protonats.ErrMarshallingFailed
+		}
+	}
+	if opts, err = c.resolveLeader(opts); err != nil {
+		return err
+	}
+	options := impl.ProcessCallOptions(opts...)
+	sealer, err := gonats.NewSealer(c.config.XKey)
+	if err != nil {
+		return err
+	}
+	msg, err := gonats.NewRequest(c.nc, options.Subject(subject), data, c.config.Signer, sealer)
+	if err != nil {
+		return err
+	}
+	if err = c.nc.PublishMsg(msg); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(options.Ctx(), options.GetTimeoutOr(c.timeout))
+	defer cancel()
+	return c.nc.FlushWithContext(ctx)
+}
+
+// request sends data to all instances listening on subject and collects their responses until the timeout or the finisher is hit.
+// If targets is not nil, data is only sent to the direct endpoints of the given instances instead.
+func request[T any](conn *nats_go.Conn, timeout time.Duration, config gonats.ClientConfig, subject string, targets []string, data []byte, collector func([]byte, time.Duration) (T, error), opts ...protonats.CallOption) ([]gonats.BroadcastResult[T], error) {
 	options := impl.ProcessCallOptions(opts...)
 	timeout = options.GetTimeoutOr(timeout)
 
+	subjects := []string{options.Subject(subject)}
+	if targets != nil {
+		if len(targets) == 0 {
+			return []gonats.BroadcastResult[T]{}, nil
+		}
+		subjects = make([]string, len(targets))
+		for i, id := range targets {
+			subjects[i] = impl.ProcessCallOptions(append(opts[:len(opts):len(opts)], protonats.WithInstanceID(id))...).Subject(subject)
+		}
+	}
+
+	sealer, err := gonats.NewSealer(config.XKey)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(options.Ctx(), timeout)
 	defer cancel()
 
@@ -192,10 +399,9 @@ func request[T any](conn *nats_go.Conn, timeout time.Duration, subject string, d
 		}
 	}()
 	var start time.Time
-	res := []*T{}
+	results := []gonats.BroadcastResult[T]{}
 	mu := sync.Mutex{}
-	errCh := make(chan error)
-	serviceErrs := []protonats.ServiceError{}
+	errCh := make(chan error, 1)
 	var finisher *time.Timer
 	if !options.DisableFinisher {
 		finisher = time.NewTimer(timeout)
@@ -208,13 +414,19 @@ func request[T any](conn *nats_go.Conn, timeout time.Duration, subject string, d
 			}
 		}()
 	}
+	fail := func(err error) {
+		select {
+		case errCh <- err:
+		default:
+		}
+	}
 	sub, err := conn.Subscribe(conn.NewRespInbox(), func(msg *nats_go.Msg) {
 		mu.Lock()
 		defer mu.Unlock()
 
 		rtt := time.Since(start)
 		if msg.Header.Get("Status") == "503" {
-			errCh <- nats_go.ErrNoResponders
+			fail(nats_go.ErrNoResponders)
 			return
 		}
 
@@ -222,38 +434,56 @@ func request[T any](conn *nats_go.Conn, timeout time.Duration, subject string, d
 			finisher.Reset(250 * time.Millisecond)
 		}
 
-		if errMsg, errCode := msg.Header.Get(micro.ErrorHeader), msg.Header.Get(micro.ErrorCodeHeader); len(errMsg) > 0 && len(errCode) > 0 {
-			if len(msg.Data) == 0 {
-				serviceErrs = append(serviceErrs, protonats.ServiceError{Code: errCode, Description: errMsg})
-			} else {
-				serviceErrs = append(serviceErrs, protonats.ServiceError{Code: errCode, Description: errMsg, Details: string(msg.Data)})
-			}
+		data, err := sealer.Open(msg)
+		if err != nil {
+			fail(err)
 			return
 		}
-		if collector != nil {
-			if col, err := collector(msg.Data, rtt); err != nil {
-				errCh <- err
-			} else {
-				res = append(res, col)
+		result := gonats.BroadcastResult[T]{InstanceID: msg.Header.Get(gonats.InstanceHeader), RTT: rtt}
+		if errMsg, errCode := msg.Header.Get(micro.ErrorHeader), msg.Header.Get(micro.ErrorCodeHeader); len(errMsg) > 0 && len(errCode) > 0 {
+			result.Err = protonats.ServiceError{Code: errCode, Description: errMsg, Details: string(data)}
+		} else if collector != nil {
+			col, err := collector(data, rtt)
+			if err != nil {
+				fail(err)
+				return
 			}
+			result.Response = col
+		}
+		results = append(results, result)
+		if targets != nil && len(results) == len(targets) {
+			cancel()
 		}
 	})
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	defer sub.Unsubscribe()
 
+	msgs := make([]*nats_go.Msg, len(subjects))
+	for i, subject := range subjects {
+		if msgs[i], err = gonats.NewRequest(conn, subject, data, config.Signer, sealer); err != nil {
+			return nil, err
+		}
+		gonats.SetTimeout(msgs[i], ctx, timeout)
+		msgs[i].Reply = sub.Subject
+	}
 	start = time.Now()
-	err = conn.PublishRequest(options.Subject(subject), sub.Subject, data)
-	if err != nil {
-		return nil, nil, err
+	for _, msg := range msgs {
+		if err = conn.PublishMsg(msg); err != nil {
+			return nil, err
+		}
 	}
 
 	select {
 	case err = <-errCh:
-		return nil, serviceErrs, err
+		mu.Lock()
+		defer mu.Unlock()
+		return results, err
 	case <-ctx.Done():
-		return res, serviceErrs, nil
+		mu.Lock()
+		defer mu.Unlock()
+		return results, nil
 	}
 }
 
@@ -264,7 +494,7 @@ func NewTestServiceNATSClient(nc *nats_go.Conn) TestServiceNATSClient {
 func (c *testServiceNATSClient) NormalTestTest(req *Test, opts ...protonats.CallOption) (*Test, error) {
 	var response Test
 
-	if err := c.handleWithRetry(req, "service.TestService.NormalTestTest", &response, opts...); err != nil {
+	if err := c.handleWithRetry(c.config, req, "service.TestService.NormalTestTest", true, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -273,21 +503,21 @@ func (c *testServiceNATSClient) NormalTestTest(req *Test, opts ...protonats.Call
 func (c *testServiceNATSClient) NormalEmptyTest(opts ...protonats.CallOption) (*Test, error) {
 	var response Test
 
-	if err := c.handleWithRetry(nil, "service.TestService.NormalEmptyTest", &response, opts...); err != nil {
+	if err := c.handleWithRetry(c.config, nil, "service.TestService.NormalEmptyTest", true, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
 func (c *testServiceNATSClient) NormalTestEmpty(req *Test, opts ...protonats.CallOption) error {
-	if err := c.handleWithRetry(req, "service.TestService.NormalTestEmpty", nil, opts...); err != nil {
+	if err := c.handleWithRetry(c.config, req, "service.TestService.NormalTestEmpty", true, nil, opts...); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (c *testServiceNATSClient) NormalEmptyEmpty(opts ...protonats.CallOption) error {
-	if err := c.handleWithRetry(nil, "service.TestService.NormalEmptyEmpty", nil, opts...); err != nil {
+	if err := c.handleWithRetry(c.config, nil, "service.TestService.NormalEmptyEmpty", true, nil, opts...); err != nil {
 		return err
 	}
 	return nil
@@ -296,7 +526,7 @@ func (c *testServiceNATSClient) NormalEmptyEmpty(opts ...protonats.CallOption) e
 func (c *testServiceNATSClient) ErrServiceError(req *Test, opts ...protonats.CallOption) (*Test, error) {
 	var response Test
 
-	if err := c.handleWithRetry(req, "service.TestService.ErrServiceError", &response, opts...); err != nil {
+	if err := c.handleWithRetry(c.config, req, "service.TestService.ErrServiceError", true, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -305,78 +535,105 @@ func (c *testServiceNATSClient) ErrServiceError(req *Test, opts ...protonats.Cal
 func (c *testServiceNATSClient) ErrServerError(req *Test, opts ...protonats.CallOption) (*Test, error) {
 	var response Test
 
-	if err := c.handleWithRetry(req, "service.TestService.ErrServerError", &response, opts...); err != nil {
+	if err := c.handleWithRetry(c.config, req, "service.TestService.ErrServerError", true, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *testServiceNATSClient) ErrServiceErrorBroadcast(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error) {
+func (c *testServiceNATSClient) ErrServiceErrorBroadcast(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
 	var data []byte
 	if req != nil {
 		var err error
 		if data, err = proto.Marshal(req); err != nil {
-			return nil, nil, protonats.ErrMarshallingFailed
+			return nil, protonats.ErrMarshallingFailed
 		}
 	}
-	objs, serviceErrs, err := request(c.nc, c.timeout, "service.TestService.ErrServiceErrorBroadcast", data, func(data []byte, rtt time.Duration) (*Test, error) {
+	return request(c.nc, c.timeout, c.config, "service.TestService.ErrServiceErrorBroadcast", nil, data, func(data []byte, rtt time.Duration) (*Test, error) {
 		var obj Test
 		if err := proto.Unmarshal(data, &obj); err != nil {
 			return nil, err
 		}
 		return &obj, nil
 	}, opts...)
-	return objs, serviceErrs, err
 }
 
-func (c *testServiceNATSClient) ErrServerErrorBroadcast(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error) {
+func (c *testServiceNATSClient) ErrServiceErrorBroadcastTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
 	var data []byte
 	if req != nil {
 		var err error
 		if data, err = proto.Marshal(req); err != nil {
-			return nil, nil, protonats.ErrMarshallingFailed
+			return nil, protonats.ErrMarshallingFailed
 		}
 	}
-	objs, serviceErrs, err := request(c.nc, c.timeout, "service.TestService.ErrServerErrorBroadcast", data, func(data []byte, rtt time.Duration) (*Test, error) {
+	return request(c.nc, c.timeout, c.config, "service.TestService.ErrServiceErrorBroadcast", targets, data, func(data []byte, rtt time.Duration) (*Test, error) {
 		var obj Test
 		if err := proto.Unmarshal(data, &obj); err != nil {
 			return nil, err
 		}
 		return &obj, nil
 	}, opts...)
-	return objs, serviceErrs, err
 }
 
-func (c *testServiceNATSClient) NormalBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error) {
+func (c *testServiceNATSClient) ErrServerErrorBroadcast(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
 	var data []byte
 	if req != nil {
 		var err error
 		if data, err = proto.Marshal(req); err != nil {
-			return nil, nil, protonats.ErrMarshallingFailed
+			return nil, protonats.ErrMarshallingFailed
 		}
 	}
-	objs, serviceErrs, err := request(c.nc, c.timeout, "service.TestService.NormalBroadcastTestTest", data, func(data []byte, rtt time.Duration) (*Test, error) {
+	return request(c.nc, c.timeout, c.config, "service.TestService.ErrServerErrorBroadcast", nil, data, func(data []byte, rtt time.Duration) (*Test, error) {
 		var obj Test
 		if err := proto.Unmarshal(data, &obj); err != nil {
 			return nil, err
 		}
 		return &obj, nil
 	}, opts...)
-	return objs, serviceErrs, err
 }
 
-func (c *testServiceNATSClient) NormalBroadcastEmptyTest(opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error) {
-	objs, serviceErrs, err := request(c.nc, c.timeout, "service.TestService.NormalBroadcastEmptyTest", nil, func(data []byte, rtt time.Duration) (*Test, error) {
+func (c *testServiceNATSClient) ErrServerErrorBroadcastTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = proto.Marshal(req); err != nil {
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	return request(c.nc, c.timeout, c.config, "service.TestService.ErrServerErrorBroadcast", targets, data, func(data []byte, rtt time.Duration) (*Test, error) {
 		var obj Test
 		if err := proto.Unmarshal(data, &obj); err != nil {
 			return nil, err
 		}
 		return &obj, nil
 	}, opts...)
-	return objs, serviceErrs, err
 }
 
-func (c *testServiceNATSClient) NormalBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]protonats.ServiceError, error) {
+func (c *testServiceNATSClient) NormalBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
 	var data []byte
 	if req != nil {
 		var err error
@@ -384,77 +641,90 @@ func (c *testServiceNATSClient) NormalBroadcastTestEmpty(req *Test, opts ...prot
 			return nil, protonats.ErrMarshallingFailed
 		}
 	}
-	_, serviceErrs, err := request[struct{}](c.nc, c.timeout, "service.TestService.NormalBroadcastTestEmpty", data, nil, opts...)
-	return serviceErrs, err
-}
-
-func (c *testServiceNATSClient) NormalBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]protonats.ServiceError, error) {
-	_, serviceErrs, err := request[struct{}](c.nc, c.timeout, "service.TestService.NormalBroadcastEmptyEmpty", nil, nil, opts...)
-	return serviceErrs, err
-}
-
-func (c *testServiceNATSClient) LeaderOnlyTestTest(req *Test, opts ...protonats.CallOption) (*Test, error) {
-	var response Test
-
-	if err := c.handleWithRetry(req, "service.TestService.LeaderOnlyTestTest", &response, opts...); err != nil {
-		return nil, err
-	}
-	return &response, nil
+	return request(c.nc, c.timeout, c.config, "service.TestService.NormalBroadcastTestTest", nil, data, func(data []byte, rtt time.Duration) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	}, opts...)
 }
 
-func (c *testServiceNATSClient) LeaderOnlyEmptyTest(opts ...protonats.CallOption) (*Test, error) {
-	var response Test
-
-	if err := c.handleWithRetry(nil, "service.TestService.LeaderOnlyEmptyTest", &response, opts...); err != nil {
+func (c *testServiceNATSClient) NormalBroadcastTestTestTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
 		return nil, err
 	}
-	return &response, nil
-}
-
-func (c *testServiceNATSClient) LeaderOnlyTestEmpty(req *Test, opts ...protonats.CallOption) error {
-	if err := c.handleWithRetry(req, "service.TestService.LeaderOnlyTestEmpty", nil, opts...); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (c *testServiceNATSClient) LeaderOnlyEmptyEmpty(opts ...protonats.CallOption) error {
-	if err := c.handleWithRetry(nil, "service.TestService.LeaderOnlyEmptyEmpty", nil, opts...); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (c *testServiceNATSClient) LeaderOnlyBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error) {
 	var data []byte
 	if req != nil {
 		var err error
 		if data, err = proto.Marshal(req); err != nil {
-			return nil, nil, protonats.ErrMarshallingFailed
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	return request(c.nc, c.timeout, c.config, "service.TestService.NormalBroadcastTestTest", targets, data, func(data []byte, rtt time.Duration) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, err
 		}
+		return &obj, nil
+	}, opts...)
+}
+
+func (c *testServiceNATSClient) NormalBroadcastEmptyTest(opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
 	}
-	objs, serviceErrs, err := request(c.nc, c.timeout, "service.TestService.LeaderOnlyBroadcastTestTest", data, func(data []byte, rtt time.Duration) (*Test, error) {
+	return request(c.nc, c.timeout, c.config, "service.TestService.NormalBroadcastEmptyTest", nil, nil, func(data []byte, rtt time.Duration) (*Test, error) {
 		var obj Test
 		if err := proto.Unmarshal(data, &obj); err != nil {
 			return nil, err
 		}
 		return &obj, nil
 	}, opts...)
-	return objs, serviceErrs, err
 }
 
-func (c *testServiceNATSClient) LeaderOnlyBroadcastEmptyTest(opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error) {
-	objs, serviceErrs, err := request(c.nc, c.timeout, "service.TestService.LeaderOnlyBroadcastEmptyTest", nil, func(data []byte, rtt time.Duration) (*Test, error) {
+func (c *testServiceNATSClient) NormalBroadcastEmptyTestTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request(c.nc, c.timeout, c.config, "service.TestService.NormalBroadcastEmptyTest", targets, nil, func(data []byte, rtt time.Duration) (*Test, error) {
 		var obj Test
 		if err := proto.Unmarshal(data, &obj); err != nil {
 			return nil, err
 		}
 		return &obj, nil
 	}, opts...)
-	return objs, serviceErrs, err
 }
 
-func (c *testServiceNATSClient) LeaderOnlyBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]protonats.ServiceError, error) {
+func (c *testServiceNATSClient) NormalBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = proto.Marshal(req); err != nil {
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.NormalBroadcastTestEmpty", nil, data, nil, opts...)
+}
+
+func (c *testServiceNATSClient) NormalBroadcastTestEmptyTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
 	var data []byte
 	if req != nil {
 		var err error
@@ -462,77 +732,87 @@ func (c *testServiceNATSClient) LeaderOnlyBroadcastTestEmpty(req *Test, opts ...
 			return nil, protonats.ErrMarshallingFailed
 		}
 	}
-	_, serviceErrs, err := request[struct{}](c.nc, c.timeout, "service.TestService.LeaderOnlyBroadcastTestEmpty", data, nil, opts...)
-	return serviceErrs, err
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.NormalBroadcastTestEmpty", targets, data, nil, opts...)
+}
+
+func (c *testServiceNATSClient) NormalBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.NormalBroadcastEmptyEmpty", nil, nil, nil, opts...)
 }
 
-func (c *testServiceNATSClient) LeaderOnlyBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]protonats.ServiceError, error) {
-	_, serviceErrs, err := request[struct{}](c.nc, c.timeout, "service.TestService.LeaderOnlyBroadcastEmptyEmpty", nil, nil, opts...)
-	return serviceErrs, err
+func (c *testServiceNATSClient) NormalBroadcastEmptyEmptyTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.NormalBroadcastEmptyEmpty", targets, nil, nil, opts...)
 }
 
-func (c *testServiceNATSClient) FollowerOnlyTestTest(req *Test, opts ...protonats.CallOption) (*Test, error) {
+func (c *testServiceNATSClient) LeaderOnlyTestTest(req *Test, opts ...protonats.CallOption) (*Test, error) {
 	var response Test
 
-	if err := c.handleWithRetry(req, "service.TestService.FollowerOnlyTestTest", &response, opts...); err != nil {
+	if err := c.handleWithRetry(c.config, req, "service.TestService.LeaderOnlyTestTest", false, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *testServiceNATSClient) FollowerOnlyEmptyTest(opts ...protonats.CallOption) (*Test, error) {
+func (c *testServiceNATSClient) LeaderOnlyEmptyTest(opts ...protonats.CallOption) (*Test, error) {
 	var response Test
 
-	if err := c.handleWithRetry(nil, "service.TestService.FollowerOnlyEmptyTest", &response, opts...); err != nil {
+	if err := c.handleWithRetry(c.config, nil, "service.TestService.LeaderOnlyEmptyTest", false, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *testServiceNATSClient) FollowerOnlyTestEmpty(req *Test, opts ...protonats.CallOption) error {
-	if err := c.handleWithRetry(req, "service.TestService.FollowerOnlyTestEmpty", nil, opts...); err != nil {
+func (c *testServiceNATSClient) LeaderOnlyTestEmpty(req *Test, opts ...protonats.CallOption) error {
+	if err := c.handleWithRetry(c.config, req, "service.TestService.LeaderOnlyTestEmpty", false, nil, opts...); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *testServiceNATSClient) FollowerOnlyEmptyEmpty(opts ...protonats.CallOption) error {
-	if err := c.handleWithRetry(nil, "service.TestService.FollowerOnlyEmptyEmpty", nil, opts...); err != nil {
+func (c *testServiceNATSClient) LeaderOnlyEmptyEmpty(opts ...protonats.CallOption) error {
+	if err := c.handleWithRetry(c.config, nil, "service.TestService.LeaderOnlyEmptyEmpty", false, nil, opts...); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *testServiceNATSClient) FollowerOnlyBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error) {
+func (c *testServiceNATSClient) LeaderOnlyBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
 	var data []byte
 	if req != nil {
 		var err error
 		if data, err = proto.Marshal(req); err != nil {
-			return nil, nil, protonats.ErrMarshallingFailed
+			return nil, protonats.ErrMarshallingFailed
 		}
 	}
-	objs, serviceErrs, err := request(c.nc, c.timeout, "service.TestService.FollowerOnlyBroadcastTestTest", data, func(data []byte, rtt time.Duration) (*Test, error) {
-		var obj Test
-		if err := proto.Unmarshal(data, &obj); err != nil {
-			return nil, err
-		}
-		return &obj, nil
-	}, opts...)
-	return objs, serviceErrs, err
-}
-
-func (c *testServiceNATSClient) FollowerOnlyBroadcastEmptyTest(opts ...protonats.CallOption) ([]*Test, []protonats.ServiceError, error) {
-	objs, serviceErrs, err := request(c.nc, c.timeout, "service.TestService.FollowerOnlyBroadcastEmptyTest", nil, func(data []byte, rtt time.Duration) (*Test, error) {
+	return request(c.nc, c.timeout, c.config, "service.TestService.LeaderOnlyBroadcastTestTest", nil, data, func(data []byte, rtt time.Duration) (*Test, error) {
 		var obj Test
 		if err := proto.Unmarshal(data, &obj); err != nil {
 			return nil, err
 		}
 		return &obj, nil
 	}, opts...)
-	return objs, serviceErrs, err
 }
 
-func (c *testServiceNATSClient) FollowerOnlyBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]protonats.ServiceError, error) {
+func (c *testServiceNATSClient) LeaderOnlyBroadcastTestTestTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
 	var data []byte
 	if req != nil {
 		var err error
@@ -540,22 +820,332 @@ func (c *testServiceNATSClient) FollowerOnlyBroadcastTestEmpty(req *Test, opts .
 			return nil, protonats.ErrMarshallingFailed
 		}
 	}
-	_, serviceErrs, err := request[struct{}](c.nc, c.timeout, "service.TestService.FollowerOnlyBroadcastTestEmpty", data, nil, opts...)
-	return serviceErrs, err
+	return request(c.nc, c.timeout, c.config, "service.TestService.LeaderOnlyBroadcastTestTest", targets, data, func(data []byte, rtt time.Duration) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	}, opts...)
 }
 
-func (c *testServiceNATSClient) FollowerOnlyBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]protonats.ServiceError, error) {
-	_, serviceErrs, err := request[struct{}](c.nc, c.timeout, "service.TestService.FollowerOnlyBroadcastEmptyEmpty", nil, nil, opts...)
-	return serviceErrs, err
+func (c *testServiceNATSClient) LeaderOnlyBroadcastEmptyTest(opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	return request(c.nc, c.timeout, c.config, "service.TestService.LeaderOnlyBroadcastEmptyTest", nil, nil, func(data []byte, rtt time.Duration) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	}, opts...)
 }
 
-func (c *testServiceNATSClient) ThreeSecondDelay(opts ...protonats.CallOption) error {
-	if err := c.handleWithRetry(nil, "service.TestService.ThreeSecondDelay", nil, opts...); err != nil {
-		return err
+func (c *testServiceNATSClient) LeaderOnlyBroadcastEmptyTestTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request(c.nc, c.timeout, c.config, "service.TestService.LeaderOnlyBroadcastEmptyTest", targets, nil, func(data []byte, rtt time.Duration) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	}, opts...)
+}
+
+func (c *testServiceNATSClient) LeaderOnlyBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = proto.Marshal(req); err != nil {
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.LeaderOnlyBroadcastTestEmpty", nil, data, nil, opts...)
+}
+
+func (c *testServiceNATSClient) LeaderOnlyBroadcastTestEmptyTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = proto.Marshal(req); err != nil {
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.LeaderOnlyBroadcastTestEmpty", targets, data, nil, opts...)
+}
+
+func (c *testServiceNATSClient) LeaderOnlyBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.LeaderOnlyBroadcastEmptyEmpty", nil, nil, nil, opts...)
+}
+
+func (c *testServiceNATSClient) LeaderOnlyBroadcastEmptyEmptyTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.LeaderOnlyBroadcastEmptyEmpty", targets, nil, nil, opts...)
+}
+
+func (c *testServiceNATSClient) FollowerOnlyTestTest(req *Test, opts ...protonats.CallOption) (*Test, error) {
+	var response Test
+
+	if err := c.handleWithRetry(c.config, req, "service.TestService.FollowerOnlyTestTest", false, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *testServiceNATSClient) FollowerOnlyEmptyTest(opts ...protonats.CallOption) (*Test, error) {
+	var response Test
+
+	if err := c.handleWithRetry(c.config, nil, "service.TestService.FollowerOnlyEmptyTest", false, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *testServiceNATSClient) FollowerOnlyTestEmpty(req *Test, opts ...protonats.CallOption) error {
+	if err := c.handleWithRetry(c.config, req, "service.TestService.FollowerOnlyTestEmpty", false, nil, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *testServiceNATSClient) FollowerOnlyEmptyEmpty(opts ...protonats.CallOption) error {
+	if err := c.handleWithRetry(c.config, nil, "service.TestService.FollowerOnlyEmptyEmpty", false, nil, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *testServiceNATSClient) FollowerOnlyBroadcastTestTest(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = proto.Marshal(req); err != nil {
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	return request(c.nc, c.timeout, c.config, "service.TestService.FollowerOnlyBroadcastTestTest", nil, data, func(data []byte, rtt time.Duration) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	}, opts...)
+}
+
+func (c *testServiceNATSClient) FollowerOnlyBroadcastTestTestTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = proto.Marshal(req); err != nil {
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	return request(c.nc, c.timeout, c.config, "service.TestService.FollowerOnlyBroadcastTestTest", targets, data, func(data []byte, rtt time.Duration) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	}, opts...)
+}
+
+func (c *testServiceNATSClient) FollowerOnlyBroadcastEmptyTest(opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	return request(c.nc, c.timeout, c.config, "service.TestService.FollowerOnlyBroadcastEmptyTest", nil, nil, func(data []byte, rtt time.Duration) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	}, opts...)
+}
+
+func (c *testServiceNATSClient) FollowerOnlyBroadcastEmptyTestTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastResult[*Test], error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request(c.nc, c.timeout, c.config, "service.TestService.FollowerOnlyBroadcastEmptyTest", targets, nil, func(data []byte, rtt time.Duration) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return &obj, nil
+	}, opts...)
+}
+
+func (c *testServiceNATSClient) FollowerOnlyBroadcastTestEmpty(req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = proto.Marshal(req); err != nil {
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.FollowerOnlyBroadcastTestEmpty", nil, data, nil, opts...)
+}
+
+func (c *testServiceNATSClient) FollowerOnlyBroadcastTestEmptyTo(target gonats.Target, req *Test, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = proto.Marshal(req); err != nil {
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.FollowerOnlyBroadcastTestEmpty", targets, data, nil, opts...)
+}
+
+func (c *testServiceNATSClient) FollowerOnlyBroadcastEmptyEmpty(opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	opts, err := c.resolveLeader(opts)
+	if err != nil {
+		return nil, err
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.FollowerOnlyBroadcastEmptyEmpty", nil, nil, nil, opts...)
+}
+
+func (c *testServiceNATSClient) FollowerOnlyBroadcastEmptyEmptyTo(target gonats.Target, opts ...protonats.CallOption) ([]gonats.BroadcastAck, error) {
+	targets, err := target.Resolve(func() ([]*micro.Info, error) {
+		return c.Info(gonats.AllInstances(opts)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request[struct{}](c.nc, c.timeout, c.config, "service.TestService.FollowerOnlyBroadcastEmptyEmpty", targets, nil, nil, opts...)
+}
+
+func (c *testServiceNATSClient) ThreeSecondDelay(opts ...protonats.CallOption) error {
+	if err := c.handleWithRetry(c.config, nil, "service.TestService.ThreeSecondDelay", true, nil, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *testServiceNATSClient) PooledDelay(opts ...protonats.CallOption) error {
+	if err := c.handleWithRetry(c.config, nil, "service.TestService.PooledDelay", true, nil, opts...); err != nil {
+		return err
 	}
 	return nil
 }
 
+func (c *testServiceNATSClient) RateLimited(req *Test, opts ...protonats.CallOption) (*Test, error) {
+	var response Test
+
+	if err := c.handleWithRetry(c.config, req, "service.TestService.RateLimited", true, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *testServiceNATSClient) AdminOnly(req *Test, opts ...protonats.CallOption) (*Test, error) {
+	var response Test
+
+	if err := c.handleWithRetry(c.config, req, "service.TestService.AdminOnly", true, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *testServiceNATSClient) Durable(req *Test, opts ...protonats.CallOption) (*gonats.DurableCall[*Test], error) {
+	return c.DurableReplyTo("", req, opts...)
+}
+
+func (c *testServiceNATSClient) DurableReplyTo(reply string, req *Test, opts ...protonats.CallOption) (*gonats.DurableCall[*Test], error) {
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = proto.Marshal(req); err != nil {
+			return nil, protonats.ErrMarshallingFailed
+		}
+	}
+	if _, err := gonats.ResolveLeader(opts, nil); err != nil {
+		return nil, err
+	}
+	options := impl.ProcessCallOptions(opts...)
+	ctx, cancel := context.WithTimeout(options.Ctx(), options.GetTimeoutOr(c.timeout))
+	defer cancel()
+	return gonats.PublishDurable(ctx, c.nc, c.config, "TestService", "Durable", options.ExtraSubject, reply, data, func(data []byte) (*Test, error) {
+		var obj Test
+		if err := proto.Unmarshal(data, &obj); err != nil {
+			return nil, protonats.ErrUnmarshallingFailed
+		}
+		return &obj, nil
+	})
+}
+
+func (c *testServiceNATSClient) OneWay(req *Test, opts ...protonats.CallOption) error {
+	return c.publish(req, "service.TestService.OneWay", opts...)
+}
+
+func (c *testServiceNATSClient) HTTPGet(req *HTTPTest, opts ...protonats.CallOption) (*Test, error) {
+	var response Test
+
+	if err := c.handleWithRetry(c.config, req, "service.TestService.HTTPGet", true, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *testServiceNATSClient) HTTPPost(req *HTTPTest, opts ...protonats.CallOption) (*Test, error) {
+	var response Test
+
+	if err := c.handleWithRetry(c.config, req, "service.TestService.HTTPPost", true, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
 //endregion
 
 // region Server
@@ -577,6 +1167,14 @@ type TestServiceNATSServer interface {
 	NormalBroadcastEmptyEmpty() error
 	// Special cases
 	ThreeSecondDelay() error
+	PooledDelay() error
+	RateLimited(req *Test) (*Test, error)
+	AdminOnly(req *Test) (*Test, error)
+	Durable(req *Test) (*Test, error)
+	OneWay(req *Test) error
+	// HTTP gateway binding tests
+	HTTPGet(req *HTTPTest) (*Test, error)
+	HTTPPost(req *HTTPTest) (*Test, error)
 	TestServiceNATSLeaderServer
 	TestServiceNATSFollowerServer
 }
@@ -607,899 +1205,2297 @@ type TestServiceNATSFollowerServer interface {
 	FollowerOnlyBroadcastEmptyEmpty() error
 }
 
-type TestServiceId interface {
-	SetTestServiceId(string)
+// UnimplementedTestServiceNATSServer can be embedded to have forward compatible implementations of TestServiceNATSServer.
+// Every method returns a ServerError with code "501".
+type UnimplementedTestServiceNATSServer struct{}
+
+func (UnimplementedTestServiceNATSServer) NormalTestTest(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("NormalTestTest")
+}
+
+func (UnimplementedTestServiceNATSServer) NormalEmptyTest() (*Test, error) {
+	return nil, gonats.Unimplemented("NormalEmptyTest")
+}
+
+func (UnimplementedTestServiceNATSServer) NormalTestEmpty(*Test) error {
+	return gonats.Unimplemented("NormalTestEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) NormalEmptyEmpty() error {
+	return gonats.Unimplemented("NormalEmptyEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) ErrServiceError(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("ErrServiceError")
+}
+
+func (UnimplementedTestServiceNATSServer) ErrServerError(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("ErrServerError")
+}
+
+func (UnimplementedTestServiceNATSServer) ErrServiceErrorBroadcast(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("ErrServiceErrorBroadcast")
+}
+
+func (UnimplementedTestServiceNATSServer) ErrServerErrorBroadcast(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("ErrServerErrorBroadcast")
+}
+
+func (UnimplementedTestServiceNATSServer) NormalBroadcastTestTest(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("NormalBroadcastTestTest")
+}
+
+func (UnimplementedTestServiceNATSServer) NormalBroadcastEmptyTest() (*Test, error) {
+	return nil, gonats.Unimplemented("NormalBroadcastEmptyTest")
+}
+
+func (UnimplementedTestServiceNATSServer) NormalBroadcastTestEmpty(*Test) error {
+	return gonats.Unimplemented("NormalBroadcastTestEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) NormalBroadcastEmptyEmpty() error {
+	return gonats.Unimplemented("NormalBroadcastEmptyEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) LeaderOnlyTestTest(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("LeaderOnlyTestTest")
 }
 
-func NewTestServiceNATSServer(nc *nats_go.Conn, server TestServiceNATSServer, opts ...protonats.ServerOption) micro.Service {
-	service, options, err := impl.NewService("TestService", nc, server, opts...)
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
-	}
-	if setId, ok := server.(TestServiceId); ok {
-		setId.SetTestServiceId(service.Info().ID)
+func (UnimplementedTestServiceNATSServer) LeaderOnlyEmptyTest() (*Test, error) {
+	return nil, gonats.Unimplemented("LeaderOnlyEmptyTest")
+}
+
+func (UnimplementedTestServiceNATSServer) LeaderOnlyTestEmpty(*Test) error {
+	return gonats.Unimplemented("LeaderOnlyTestEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) LeaderOnlyEmptyEmpty() error {
+	return gonats.Unimplemented("LeaderOnlyEmptyEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) LeaderOnlyBroadcastTestTest(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("LeaderOnlyBroadcastTestTest")
+}
+
+func (UnimplementedTestServiceNATSServer) LeaderOnlyBroadcastEmptyTest() (*Test, error) {
+	return nil, gonats.Unimplemented("LeaderOnlyBroadcastEmptyTest")
+}
+
+func (UnimplementedTestServiceNATSServer) LeaderOnlyBroadcastTestEmpty(*Test) error {
+	return gonats.Unimplemented("LeaderOnlyBroadcastTestEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) LeaderOnlyBroadcastEmptyEmpty() error {
+	return gonats.Unimplemented("LeaderOnlyBroadcastEmptyEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) FollowerOnlyTestTest(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("FollowerOnlyTestTest")
+}
+
+func (UnimplementedTestServiceNATSServer) FollowerOnlyEmptyTest() (*Test, error) {
+	return nil, gonats.Unimplemented("FollowerOnlyEmptyTest")
+}
+
+func (UnimplementedTestServiceNATSServer) FollowerOnlyTestEmpty(*Test) error {
+	return gonats.Unimplemented("FollowerOnlyTestEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) FollowerOnlyEmptyEmpty() error {
+	return gonats.Unimplemented("FollowerOnlyEmptyEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) FollowerOnlyBroadcastTestTest(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("FollowerOnlyBroadcastTestTest")
+}
+
+func (UnimplementedTestServiceNATSServer) FollowerOnlyBroadcastEmptyTest() (*Test, error) {
+	return nil, gonats.Unimplemented("FollowerOnlyBroadcastEmptyTest")
+}
+
+func (UnimplementedTestServiceNATSServer) FollowerOnlyBroadcastTestEmpty(*Test) error {
+	return gonats.Unimplemented("FollowerOnlyBroadcastTestEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) FollowerOnlyBroadcastEmptyEmpty() error {
+	return gonats.Unimplemented("FollowerOnlyBroadcastEmptyEmpty")
+}
+
+func (UnimplementedTestServiceNATSServer) ThreeSecondDelay() error {
+	return gonats.Unimplemented("ThreeSecondDelay")
+}
+
+func (UnimplementedTestServiceNATSServer) PooledDelay() error {
+	return gonats.Unimplemented("PooledDelay")
+}
+
+func (UnimplementedTestServiceNATSServer) RateLimited(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("RateLimited")
+}
+
+func (UnimplementedTestServiceNATSServer) AdminOnly(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("AdminOnly")
+}
+
+func (UnimplementedTestServiceNATSServer) Durable(*Test) (*Test, error) {
+	return nil, gonats.Unimplemented("Durable")
+}
+
+func (UnimplementedTestServiceNATSServer) OneWay(*Test) error {
+	return gonats.Unimplemented("OneWay")
+}
+
+func (UnimplementedTestServiceNATSServer) HTTPGet(*HTTPTest) (*Test, error) {
+	return nil, gonats.Unimplemented("HTTPGet")
+}
+
+func (UnimplementedTestServiceNATSServer) HTTPPost(*HTTPTest) (*Test, error) {
+	return nil, gonats.Unimplemented("HTTPPost")
+}
+
+// _TestServiceServiceConfig contains the service options declared for TestService
+var _TestServiceServiceConfig = gonats.ServiceConfig{
+	Version:     "1.2.3",
+	Description: "Service used for testing the generated code",
+	Metadata:    map[string]string{"zone": "test"},
+}
+
+type TestServiceId interface {
+	SetTestServiceId(string)
+}
+
+// TestServiceStats can be implemented by a server to add custom data to the stats of every endpoint.
+type TestServiceStats interface {
+	Stats(*micro.Endpoint) any
+}
+
+// TestServiceDone can be implemented by a server to be notified when the service is stopped.
+type TestServiceDone interface {
+	Done(micro.Service)
+}
+
+// TestServiceErr can be implemented by a server to be notified about asynchronous errors of the service subscriptions.
+type TestServiceErr interface {
+	Err(micro.Service, *micro.NATSError)
+}
+
+// TestServiceNormalTestTestWithRequest can be implemented by a server to get the request of NormalTestTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, NormalTestTestWithRequest is called instead of NormalTestTest.
+type TestServiceNormalTestTestWithRequest interface {
+	NormalTestTestWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceNormalEmptyTestWithRequest can be implemented by a server to get the request of NormalEmptyTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, NormalEmptyTestWithRequest is called instead of NormalEmptyTest.
+type TestServiceNormalEmptyTestWithRequest interface {
+	NormalEmptyTestWithRequest(request micro.Request) (*Test, error)
+}
+
+// TestServiceNormalTestEmptyWithRequest can be implemented by a server to get the request of NormalTestEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, NormalTestEmptyWithRequest is called instead of NormalTestEmpty.
+type TestServiceNormalTestEmptyWithRequest interface {
+	NormalTestEmptyWithRequest(request micro.Request, req *Test) error
+}
+
+// TestServiceNormalEmptyEmptyWithRequest can be implemented by a server to get the request of NormalEmptyEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, NormalEmptyEmptyWithRequest is called instead of NormalEmptyEmpty.
+type TestServiceNormalEmptyEmptyWithRequest interface {
+	NormalEmptyEmptyWithRequest(request micro.Request) error
+}
+
+// TestServiceErrServiceErrorWithRequest can be implemented by a server to get the request of ErrServiceError, e.g. its signer with gonats.SignerOf.
+// If it is implemented, ErrServiceErrorWithRequest is called instead of ErrServiceError.
+type TestServiceErrServiceErrorWithRequest interface {
+	ErrServiceErrorWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceErrServerErrorWithRequest can be implemented by a server to get the request of ErrServerError, e.g. its signer with gonats.SignerOf.
+// If it is implemented, ErrServerErrorWithRequest is called instead of ErrServerError.
+type TestServiceErrServerErrorWithRequest interface {
+	ErrServerErrorWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceErrServiceErrorBroadcastWithRequest can be implemented by a server to get the request of ErrServiceErrorBroadcast, e.g. its signer with gonats.SignerOf.
+// If it is implemented, ErrServiceErrorBroadcastWithRequest is called instead of ErrServiceErrorBroadcast.
+type TestServiceErrServiceErrorBroadcastWithRequest interface {
+	ErrServiceErrorBroadcastWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceErrServerErrorBroadcastWithRequest can be implemented by a server to get the request of ErrServerErrorBroadcast, e.g. its signer with gonats.SignerOf.
+// If it is implemented, ErrServerErrorBroadcastWithRequest is called instead of ErrServerErrorBroadcast.
+type TestServiceErrServerErrorBroadcastWithRequest interface {
+	ErrServerErrorBroadcastWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceNormalBroadcastTestTestWithRequest can be implemented by a server to get the request of NormalBroadcastTestTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, NormalBroadcastTestTestWithRequest is called instead of NormalBroadcastTestTest.
+type TestServiceNormalBroadcastTestTestWithRequest interface {
+	NormalBroadcastTestTestWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceNormalBroadcastEmptyTestWithRequest can be implemented by a server to get the request of NormalBroadcastEmptyTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, NormalBroadcastEmptyTestWithRequest is called instead of NormalBroadcastEmptyTest.
+type TestServiceNormalBroadcastEmptyTestWithRequest interface {
+	NormalBroadcastEmptyTestWithRequest(request micro.Request) (*Test, error)
+}
+
+// TestServiceNormalBroadcastTestEmptyWithRequest can be implemented by a server to get the request of NormalBroadcastTestEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, NormalBroadcastTestEmptyWithRequest is called instead of NormalBroadcastTestEmpty.
+type TestServiceNormalBroadcastTestEmptyWithRequest interface {
+	NormalBroadcastTestEmptyWithRequest(request micro.Request, req *Test) error
+}
+
+// TestServiceNormalBroadcastEmptyEmptyWithRequest can be implemented by a server to get the request of NormalBroadcastEmptyEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, NormalBroadcastEmptyEmptyWithRequest is called instead of NormalBroadcastEmptyEmpty.
+type TestServiceNormalBroadcastEmptyEmptyWithRequest interface {
+	NormalBroadcastEmptyEmptyWithRequest(request micro.Request) error
+}
+
+// TestServiceLeaderOnlyTestTestWithRequest can be implemented by a server to get the request of LeaderOnlyTestTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, LeaderOnlyTestTestWithRequest is called instead of LeaderOnlyTestTest.
+type TestServiceLeaderOnlyTestTestWithRequest interface {
+	LeaderOnlyTestTestWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceLeaderOnlyEmptyTestWithRequest can be implemented by a server to get the request of LeaderOnlyEmptyTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, LeaderOnlyEmptyTestWithRequest is called instead of LeaderOnlyEmptyTest.
+type TestServiceLeaderOnlyEmptyTestWithRequest interface {
+	LeaderOnlyEmptyTestWithRequest(request micro.Request) (*Test, error)
+}
+
+// TestServiceLeaderOnlyTestEmptyWithRequest can be implemented by a server to get the request of LeaderOnlyTestEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, LeaderOnlyTestEmptyWithRequest is called instead of LeaderOnlyTestEmpty.
+type TestServiceLeaderOnlyTestEmptyWithRequest interface {
+	LeaderOnlyTestEmptyWithRequest(request micro.Request, req *Test) error
+}
+
+// TestServiceLeaderOnlyEmptyEmptyWithRequest can be implemented by a server to get the request of LeaderOnlyEmptyEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, LeaderOnlyEmptyEmptyWithRequest is called instead of LeaderOnlyEmptyEmpty.
+type TestServiceLeaderOnlyEmptyEmptyWithRequest interface {
+	LeaderOnlyEmptyEmptyWithRequest(request micro.Request) error
+}
+
+// TestServiceLeaderOnlyBroadcastTestTestWithRequest can be implemented by a server to get the request of LeaderOnlyBroadcastTestTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, LeaderOnlyBroadcastTestTestWithRequest is called instead of LeaderOnlyBroadcastTestTest.
+type TestServiceLeaderOnlyBroadcastTestTestWithRequest interface {
+	LeaderOnlyBroadcastTestTestWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceLeaderOnlyBroadcastEmptyTestWithRequest can be implemented by a server to get the request of LeaderOnlyBroadcastEmptyTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, LeaderOnlyBroadcastEmptyTestWithRequest is called instead of LeaderOnlyBroadcastEmptyTest.
+type TestServiceLeaderOnlyBroadcastEmptyTestWithRequest interface {
+	LeaderOnlyBroadcastEmptyTestWithRequest(request micro.Request) (*Test, error)
+}
+
+// TestServiceLeaderOnlyBroadcastTestEmptyWithRequest can be implemented by a server to get the request of LeaderOnlyBroadcastTestEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, LeaderOnlyBroadcastTestEmptyWithRequest is called instead of LeaderOnlyBroadcastTestEmpty.
+type TestServiceLeaderOnlyBroadcastTestEmptyWithRequest interface {
+	LeaderOnlyBroadcastTestEmptyWithRequest(request micro.Request, req *Test) error
+}
+
+// TestServiceLeaderOnlyBroadcastEmptyEmptyWithRequest can be implemented by a server to get the request of LeaderOnlyBroadcastEmptyEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, LeaderOnlyBroadcastEmptyEmptyWithRequest is called instead of LeaderOnlyBroadcastEmptyEmpty.
+type TestServiceLeaderOnlyBroadcastEmptyEmptyWithRequest interface {
+	LeaderOnlyBroadcastEmptyEmptyWithRequest(request micro.Request) error
+}
+
+// TestServiceFollowerOnlyTestTestWithRequest can be implemented by a server to get the request of FollowerOnlyTestTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, FollowerOnlyTestTestWithRequest is called instead of FollowerOnlyTestTest.
+type TestServiceFollowerOnlyTestTestWithRequest interface {
+	FollowerOnlyTestTestWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceFollowerOnlyEmptyTestWithRequest can be implemented by a server to get the request of FollowerOnlyEmptyTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, FollowerOnlyEmptyTestWithRequest is called instead of FollowerOnlyEmptyTest.
+type TestServiceFollowerOnlyEmptyTestWithRequest interface {
+	FollowerOnlyEmptyTestWithRequest(request micro.Request) (*Test, error)
+}
+
+// TestServiceFollowerOnlyTestEmptyWithRequest can be implemented by a server to get the request of FollowerOnlyTestEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, FollowerOnlyTestEmptyWithRequest is called instead of FollowerOnlyTestEmpty.
+type TestServiceFollowerOnlyTestEmptyWithRequest interface {
+	FollowerOnlyTestEmptyWithRequest(request micro.Request, req *Test) error
+}
+
+// TestServiceFollowerOnlyEmptyEmptyWithRequest can be implemented by a server to get the request of FollowerOnlyEmptyEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, FollowerOnlyEmptyEmptyWithRequest is called instead of FollowerOnlyEmptyEmpty.
+type TestServiceFollowerOnlyEmptyEmptyWithRequest interface {
+	FollowerOnlyEmptyEmptyWithRequest(request micro.Request) error
+}
+
+// TestServiceFollowerOnlyBroadcastTestTestWithRequest can be implemented by a server to get the request of FollowerOnlyBroadcastTestTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, FollowerOnlyBroadcastTestTestWithRequest is called instead of FollowerOnlyBroadcastTestTest.
+type TestServiceFollowerOnlyBroadcastTestTestWithRequest interface {
+	FollowerOnlyBroadcastTestTestWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceFollowerOnlyBroadcastEmptyTestWithRequest can be implemented by a server to get the request of FollowerOnlyBroadcastEmptyTest, e.g. its signer with gonats.SignerOf.
+// If it is implemented, FollowerOnlyBroadcastEmptyTestWithRequest is called instead of FollowerOnlyBroadcastEmptyTest.
+type TestServiceFollowerOnlyBroadcastEmptyTestWithRequest interface {
+	FollowerOnlyBroadcastEmptyTestWithRequest(request micro.Request) (*Test, error)
+}
+
+// TestServiceFollowerOnlyBroadcastTestEmptyWithRequest can be implemented by a server to get the request of FollowerOnlyBroadcastTestEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, FollowerOnlyBroadcastTestEmptyWithRequest is called instead of FollowerOnlyBroadcastTestEmpty.
+type TestServiceFollowerOnlyBroadcastTestEmptyWithRequest interface {
+	FollowerOnlyBroadcastTestEmptyWithRequest(request micro.Request, req *Test) error
+}
+
+// TestServiceFollowerOnlyBroadcastEmptyEmptyWithRequest can be implemented by a server to get the request of FollowerOnlyBroadcastEmptyEmpty, e.g. its signer with gonats.SignerOf.
+// If it is implemented, FollowerOnlyBroadcastEmptyEmptyWithRequest is called instead of FollowerOnlyBroadcastEmptyEmpty.
+type TestServiceFollowerOnlyBroadcastEmptyEmptyWithRequest interface {
+	FollowerOnlyBroadcastEmptyEmptyWithRequest(request micro.Request) error
+}
+
+// TestServiceThreeSecondDelayWithRequest can be implemented by a server to get the request of ThreeSecondDelay, e.g. its signer with gonats.SignerOf.
+// If it is implemented, ThreeSecondDelayWithRequest is called instead of ThreeSecondDelay.
+type TestServiceThreeSecondDelayWithRequest interface {
+	ThreeSecondDelayWithRequest(request micro.Request) error
+}
+
+// TestServicePooledDelayWithRequest can be implemented by a server to get the request of PooledDelay, e.g. its signer with gonats.SignerOf.
+// If it is implemented, PooledDelayWithRequest is called instead of PooledDelay.
+type TestServicePooledDelayWithRequest interface {
+	PooledDelayWithRequest(request micro.Request) error
+}
+
+// TestServiceRateLimitedWithRequest can be implemented by a server to get the request of RateLimited, e.g. its signer with gonats.SignerOf.
+// If it is implemented, RateLimitedWithRequest is called instead of RateLimited.
+type TestServiceRateLimitedWithRequest interface {
+	RateLimitedWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceAdminOnlyWithRequest can be implemented by a server to get the request of AdminOnly, e.g. its signer with gonats.SignerOf.
+// If it is implemented, AdminOnlyWithRequest is called instead of AdminOnly.
+type TestServiceAdminOnlyWithRequest interface {
+	AdminOnlyWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceDurableWithRequest can be implemented by a server to get the request of Durable, e.g. its signer with gonats.SignerOf.
+// If it is implemented, DurableWithRequest is called instead of Durable.
+type TestServiceDurableWithRequest interface {
+	DurableWithRequest(request micro.Request, req *Test) (*Test, error)
+}
+
+// TestServiceOneWayWithRequest can be implemented by a server to get the request of OneWay, e.g. its signer with gonats.SignerOf.
+// If it is implemented, OneWayWithRequest is called instead of OneWay.
+type TestServiceOneWayWithRequest interface {
+	OneWayWithRequest(request micro.Request, req *Test) error
+}
+
+// TestServiceHTTPGetWithRequest can be implemented by a server to get the request of HTTPGet, e.g. its signer with gonats.SignerOf.
+// If it is implemented, HTTPGetWithRequest is called instead of HTTPGet.
+type TestServiceHTTPGetWithRequest interface {
+	HTTPGetWithRequest(request micro.Request, req *HTTPTest) (*Test, error)
+}
+
+// TestServiceHTTPPostWithRequest can be implemented by a server to get the request of HTTPPost, e.g. its signer with gonats.SignerOf.
+// If it is implemented, HTTPPostWithRequest is called instead of HTTPPost.
+type TestServiceHTTPPostWithRequest interface {
+	HTTPPostWithRequest(request micro.Request, req *HTTPTest) (*Test, error)
+}
+
+// TestServiceRoleChanged can be implemented by a server to be notified when the role of the instance changes,
+// by gonats.WithLeaderElection or SetRole, after the endpoints of the methods with a consensus_target were switched.
+type TestServiceRoleChanged interface {
+	RoleChanged(gonats.Role)
+}
+
+// NewTestServiceNATSServer serves server on nc. The role of the instance can be switched with gonats.RoleOf(service) if it was created with gonats.WithRole.
+func NewTestServiceNATSServer(nc *nats_go.Conn, server TestServiceNATSServer, opts ...protonats.ServerOption) (micro.Service, error) {
+	inFlight := gonats.NewInFlight(server)
+	service, options, err := gonats.NewService("TestService", nc, inFlight, _TestServiceServiceConfig, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if setId, ok := server.(TestServiceId); ok {
+		setId.SetTestServiceId(service.Info().ID)
+	}
+	if err := _registerTestServiceReflection(service, options); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	if err := _registerTestServiceRole(service, options, inFlight); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	if err := _newTestServiceServer(service, server, options, inFlight); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	if !options.WithoutLeaderFunctions {
+		if err := _newTestServiceLeaderServer(service, server, options, inFlight); err != nil {
+			_ = service.Stop()
+			return nil, err
+		}
+	} else if inFlight.ForwardsToLeader() {
+		if err := _forwardTestServiceLeaderServer(service, options, inFlight); err != nil {
+			_ = service.Stop()
+			return nil, err
+		}
+	}
+	if !options.WithoutFollowerFunctions {
+		if err := _newTestServiceFollowerServer(service, server, options, inFlight); err != nil {
+			_ = service.Stop()
+			return nil, err
+		}
+	}
+	return service, nil
+}
+
+func _registerTestServiceReflection(service micro.Service, opts *impl.ServerOpts) error {
+	handler, err := gonats.ReflectionHandler("protonats.go.test.TestService", gonats.WithInstanceHeader(service.Info().ID))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint(gonats.ReflectionEndpoint, handler, opts.Subject(gonats.ReflectionSubject("TestService"), ""))
+	if err != nil {
+		return err
+	}
+	return service.AddEndpoint(gonats.ReflectionEndpoint+"-Direct", handler, opts.Subject(gonats.ReflectionSubject("TestService"), service.Info().ID))
+}
+
+func _registerTestServiceRole(service micro.Service, opts *impl.ServerOpts, inFlight *gonats.InFlight) error {
+	err := service.AddEndpoint(gonats.RoleEndpoint, inFlight.RoleHandler(), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject(gonats.RoleSubject("TestService"), ""))
+	if err != nil {
+		return err
+	}
+	return service.AddEndpoint(gonats.RoleEndpoint+"-Direct", inFlight.RoleHandler(), opts.Subject(gonats.RoleSubject("TestService"), service.Info().ID))
+}
+
+func _newTestServiceServer(service micro.Service, server TestServiceNATSServer, opts *impl.ServerOpts, inFlight *gonats.InFlight) error {
+	var err error
+	_ = err
+	idHeader := gonats.WithInstanceHeader(service.Info().ID)
+	_ = idHeader
+
+	// Register the service's methods
+	NormalTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceNormalTestTestWithRequest); ok {
+			response, err = withRequest.NormalTestTestWithRequest(request, &req)
+		} else {
+			response, err = server.NormalTestTest(&req)
+		}
+		if err != nil {
+			if protonats.IsServiceError(err) {
+				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
+			}
+			var serverErr protonats.ServerError
+			if errors.As(err, &serverErr) {
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
+			} else {
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
+			}
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, idHeader)
+	})
+	NormalTestTestConfig := gonats.EndpointConfig{
+		Method: "NormalTestTest",
+	}
+	err = service.AddEndpoint("NormalTestTest", inFlight.Handler("NormalTestTest", NormalTestTestHandler, NormalTestTestConfig), opts.Subject("service.TestService.NormalTestTest", ""), micro.WithEndpointMetadata(map[string]string{"description": "Replies with the request and the instance ID", "kind": "normal"}))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalTestTest-Direct", inFlight.Handler("NormalTestTest-Direct", NormalTestTestHandler, NormalTestTestConfig), opts.Subject("service.TestService.NormalTestTest", service.Info().ID), micro.WithEndpointMetadata(map[string]string{"description": "Replies with the request and the instance ID", "kind": "normal"}))
+	if err != nil {
+		return err
+	}
+
+	NormalEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceNormalEmptyTestWithRequest); ok {
+			response, err = withRequest.NormalEmptyTestWithRequest(request)
+		} else {
+			response, err = server.NormalEmptyTest()
+		}
+		if err != nil {
+			if protonats.IsServiceError(err) {
+				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
+			}
+			var serverErr protonats.ServerError
+			if errors.As(err, &serverErr) {
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
+			} else {
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
+			}
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, idHeader)
+	})
+	NormalEmptyTestConfig := gonats.EndpointConfig{
+		Method: "NormalEmptyTest",
+	}
+	err = service.AddEndpoint("NormalEmptyTest", inFlight.Handler("NormalEmptyTest", NormalEmptyTestHandler, NormalEmptyTestConfig), opts.Subject("service.TestService.NormalEmptyTest", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalEmptyTest-Direct", inFlight.Handler("NormalEmptyTest-Direct", NormalEmptyTestHandler, NormalEmptyTestConfig), opts.Subject("service.TestService.NormalEmptyTest", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	NormalTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		var err error
+		if withRequest, ok := server.(TestServiceNormalTestEmptyWithRequest); ok {
+			err = withRequest.NormalTestEmptyWithRequest(request, &req)
+		} else {
+			err = server.NormalTestEmpty(&req)
+		}
+		if err != nil {
+			if protonats.IsServiceError(err) {
+				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
+			}
+			var serverErr protonats.ServerError
+			if errors.As(err, &serverErr) {
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
+			} else {
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
+			}
+			return
+		}
+
+		request.Respond(nil, idHeader)
+	})
+	NormalTestEmptyConfig := gonats.EndpointConfig{
+		Method: "NormalTestEmpty",
+	}
+	err = service.AddEndpoint("NormalTestEmpty", inFlight.Handler("NormalTestEmpty", NormalTestEmptyHandler, NormalTestEmptyConfig), opts.Subject("service.TestService.NormalTestEmpty", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalTestEmpty-Direct", inFlight.Handler("NormalTestEmpty-Direct", NormalTestEmptyHandler, NormalTestEmptyConfig), opts.Subject("service.TestService.NormalTestEmpty", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	NormalEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+		var err error
+		if withRequest, ok := server.(TestServiceNormalEmptyEmptyWithRequest); ok {
+			err = withRequest.NormalEmptyEmptyWithRequest(request)
+		} else {
+			err = server.NormalEmptyEmpty()
+		}
+		if err != nil {
+			if protonats.IsServiceError(err) {
+				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
+			}
+			var serverErr protonats.ServerError
+			if errors.As(err, &serverErr) {
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
+			} else {
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
+			}
+			return
+		}
+
+		request.Respond(nil, idHeader)
+	})
+	NormalEmptyEmptyConfig := gonats.EndpointConfig{
+		Method: "NormalEmptyEmpty",
 	}
-	_newTestServiceServer(service, server, options)
-
-	if !options.WithoutLeaderFunctions {
-		_newTestServiceLeaderServer(service, server, options)
+	err = service.AddEndpoint("NormalEmptyEmpty", inFlight.Handler("NormalEmptyEmpty", NormalEmptyEmptyHandler, NormalEmptyEmptyConfig), opts.Subject("service.TestService.NormalEmptyEmpty", ""))
+	if err != nil {
+		return err
 	}
-	if !options.WithoutFollowerFunctions {
-		_newTestServiceFollowerServer(service, server, options)
+	err = service.AddEndpoint("NormalEmptyEmpty-Direct", inFlight.Handler("NormalEmptyEmpty-Direct", NormalEmptyEmptyHandler, NormalEmptyEmptyConfig), opts.Subject("service.TestService.NormalEmptyEmpty", service.Info().ID))
+	if err != nil {
+		return err
 	}
-	return service
-}
-func _newTestServiceServer(service micro.Service, server TestServiceNATSServer, opts *impl.ServerOpts) {
-	var err error
-	_ = err
 
-	// Register the service's methods
-	NormalTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
+	ErrServiceErrorHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		response, err := server.NormalTestTest(&req)
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceErrServiceErrorWithRequest); ok {
+			response, err = withRequest.ErrServiceErrorWithRequest(request, &req)
+		} else {
+			response, err = server.ErrServiceError(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("NormalTestTest", NormalTestTestHandler, opts.Subject("service.TestService.NormalTestTest", ""))
+	ErrServiceErrorConfig := gonats.EndpointConfig{
+		Method: "ErrServiceError",
+	}
+	err = service.AddEndpoint("ErrServiceError", inFlight.Handler("ErrServiceError", ErrServiceErrorHandler, ErrServiceErrorConfig), opts.Subject("service.TestService.ErrServiceError", ""))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
-	err = service.AddEndpoint("NormalTestTest-Direct", NormalTestTestHandler, opts.Subject("service.TestService.NormalTestTest", service.Info().ID))
+	err = service.AddEndpoint("ErrServiceError-Direct", inFlight.Handler("ErrServiceError-Direct", ErrServiceErrorHandler, ErrServiceErrorConfig), opts.Subject("service.TestService.ErrServiceError", service.Info().ID))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
 
-	NormalEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
-		response, err := server.NormalEmptyTest()
+	ErrServerErrorHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceErrServerErrorWithRequest); ok {
+			response, err = withRequest.ErrServerErrorWithRequest(request, &req)
+		} else {
+			response, err = server.ErrServerError(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("NormalEmptyTest", NormalEmptyTestHandler, opts.Subject("service.TestService.NormalEmptyTest", ""))
+	ErrServerErrorConfig := gonats.EndpointConfig{
+		Method: "ErrServerError",
+	}
+	err = service.AddEndpoint("ErrServerError", inFlight.Handler("ErrServerError", ErrServerErrorHandler, ErrServerErrorConfig), opts.Subject("service.TestService.ErrServerError", ""))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
-	err = service.AddEndpoint("NormalEmptyTest-Direct", NormalEmptyTestHandler, opts.Subject("service.TestService.NormalEmptyTest", service.Info().ID))
+	err = service.AddEndpoint("ErrServerError-Direct", inFlight.Handler("ErrServerError-Direct", ErrServerErrorHandler, ErrServerErrorConfig), opts.Subject("service.TestService.ErrServerError", service.Info().ID))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
 
-	NormalTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+	ErrServiceErrorBroadcastHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		err := server.NormalTestEmpty(&req)
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceErrServiceErrorBroadcastWithRequest); ok {
+			response, err = withRequest.ErrServiceErrorBroadcastWithRequest(request, &req)
+		} else {
+			response, err = server.ErrServiceErrorBroadcast(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("NormalTestEmpty", NormalTestEmptyHandler, opts.Subject("service.TestService.NormalTestEmpty", ""))
+	ErrServiceErrorBroadcastConfig := gonats.EndpointConfig{
+		Method: "ErrServiceErrorBroadcast",
+	}
+	err = service.AddEndpoint("ErrServiceErrorBroadcast-Broadcast", inFlight.Handler("ErrServiceErrorBroadcast-Broadcast", ErrServiceErrorBroadcastHandler, ErrServiceErrorBroadcastConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.ErrServiceErrorBroadcast", ""))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
-	err = service.AddEndpoint("NormalTestEmpty-Direct", NormalTestEmptyHandler, opts.Subject("service.TestService.NormalTestEmpty", service.Info().ID))
+	err = service.AddEndpoint("ErrServiceErrorBroadcast-Direct", inFlight.Handler("ErrServiceErrorBroadcast-Direct", ErrServiceErrorBroadcastHandler, ErrServiceErrorBroadcastConfig), opts.Subject("service.TestService.ErrServiceErrorBroadcast", service.Info().ID))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
 
-	NormalEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
-		err := server.NormalEmptyEmpty()
+	ErrServerErrorBroadcastHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceErrServerErrorBroadcastWithRequest); ok {
+			response, err = withRequest.ErrServerErrorBroadcastWithRequest(request, &req)
+		} else {
+			response, err = server.ErrServerErrorBroadcast(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("NormalEmptyEmpty", NormalEmptyEmptyHandler, opts.Subject("service.TestService.NormalEmptyEmpty", ""))
+	ErrServerErrorBroadcastConfig := gonats.EndpointConfig{
+		Method: "ErrServerErrorBroadcast",
+	}
+	err = service.AddEndpoint("ErrServerErrorBroadcast-Broadcast", inFlight.Handler("ErrServerErrorBroadcast-Broadcast", ErrServerErrorBroadcastHandler, ErrServerErrorBroadcastConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.ErrServerErrorBroadcast", ""))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
-	err = service.AddEndpoint("NormalEmptyEmpty-Direct", NormalEmptyEmptyHandler, opts.Subject("service.TestService.NormalEmptyEmpty", service.Info().ID))
+	err = service.AddEndpoint("ErrServerErrorBroadcast-Direct", inFlight.Handler("ErrServerErrorBroadcast-Direct", ErrServerErrorBroadcastHandler, ErrServerErrorBroadcastConfig), opts.Subject("service.TestService.ErrServerErrorBroadcast", service.Info().ID))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
 
-	ErrServiceErrorHandler := micro.HandlerFunc(func(request micro.Request) {
+	NormalBroadcastTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		response, err := server.ErrServiceError(&req)
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceNormalBroadcastTestTestWithRequest); ok {
+			response, err = withRequest.NormalBroadcastTestTestWithRequest(request, &req)
+		} else {
+			response, err = server.NormalBroadcastTestTest(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("ErrServiceError", ErrServiceErrorHandler, opts.Subject("service.TestService.ErrServiceError", ""))
+	NormalBroadcastTestTestConfig := gonats.EndpointConfig{
+		Method: "NormalBroadcastTestTest",
+	}
+	err = service.AddEndpoint("NormalBroadcastTestTest-Broadcast", inFlight.Handler("NormalBroadcastTestTest-Broadcast", NormalBroadcastTestTestHandler, NormalBroadcastTestTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastTestTest", ""))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
-	err = service.AddEndpoint("ErrServiceError-Direct", ErrServiceErrorHandler, opts.Subject("service.TestService.ErrServiceError", service.Info().ID))
+	err = service.AddEndpoint("NormalBroadcastTestTest-Direct", inFlight.Handler("NormalBroadcastTestTest-Direct", NormalBroadcastTestTestHandler, NormalBroadcastTestTestConfig), opts.Subject("service.TestService.NormalBroadcastTestTest", service.Info().ID))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
 
-	ErrServerErrorHandler := micro.HandlerFunc(func(request micro.Request) {
-		var req Test
-		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
-			return
+	NormalBroadcastEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceNormalBroadcastEmptyTestWithRequest); ok {
+			response, err = withRequest.NormalBroadcastEmptyTestWithRequest(request)
+		} else {
+			response, err = server.NormalBroadcastEmptyTest()
 		}
-
-		response, err := server.ErrServerError(&req)
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("ErrServerError", ErrServerErrorHandler, opts.Subject("service.TestService.ErrServerError", ""))
+	NormalBroadcastEmptyTestConfig := gonats.EndpointConfig{
+		Method: "NormalBroadcastEmptyTest",
+	}
+	err = service.AddEndpoint("NormalBroadcastEmptyTest-Broadcast", inFlight.Handler("NormalBroadcastEmptyTest-Broadcast", NormalBroadcastEmptyTestHandler, NormalBroadcastEmptyTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastEmptyTest", ""))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
-	err = service.AddEndpoint("ErrServerError-Direct", ErrServerErrorHandler, opts.Subject("service.TestService.ErrServerError", service.Info().ID))
+	err = service.AddEndpoint("NormalBroadcastEmptyTest-Direct", inFlight.Handler("NormalBroadcastEmptyTest-Direct", NormalBroadcastEmptyTestHandler, NormalBroadcastEmptyTestConfig), opts.Subject("service.TestService.NormalBroadcastEmptyTest", service.Info().ID))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
 
-	ErrServiceErrorBroadcastHandler := micro.HandlerFunc(func(request micro.Request) {
+	NormalBroadcastTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		response, err := server.ErrServiceErrorBroadcast(&req)
+		var err error
+		if withRequest, ok := server.(TestServiceNormalBroadcastTestEmptyWithRequest); ok {
+			err = withRequest.NormalBroadcastTestEmptyWithRequest(request, &req)
+		} else {
+			err = server.NormalBroadcastTestEmpty(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		data, err := proto.Marshal(response)
+		request.Respond(nil, idHeader)
+	})
+	NormalBroadcastTestEmptyConfig := gonats.EndpointConfig{
+		Method: "NormalBroadcastTestEmpty",
+	}
+	err = service.AddEndpoint("NormalBroadcastTestEmpty-Broadcast", inFlight.Handler("NormalBroadcastTestEmpty-Broadcast", NormalBroadcastTestEmptyHandler, NormalBroadcastTestEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastTestEmpty", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalBroadcastTestEmpty-Direct", inFlight.Handler("NormalBroadcastTestEmpty-Direct", NormalBroadcastTestEmptyHandler, NormalBroadcastTestEmptyConfig), opts.Subject("service.TestService.NormalBroadcastTestEmpty", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	NormalBroadcastEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+		var err error
+		if withRequest, ok := server.(TestServiceNormalBroadcastEmptyEmptyWithRequest); ok {
+			err = withRequest.NormalBroadcastEmptyEmptyWithRequest(request)
+		} else {
+			err = server.NormalBroadcastEmptyEmpty()
+		}
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			if protonats.IsServiceError(err) {
+				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
+			}
+			var serverErr protonats.ServerError
+			if errors.As(err, &serverErr) {
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
+			} else {
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
+			}
 			return
 		}
-		request.Respond(data)
+
+		request.Respond(nil, idHeader)
 	})
-	err = service.AddEndpoint("ErrServiceErrorBroadcast-Broadcast", ErrServiceErrorBroadcastHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.ErrServiceErrorBroadcast", ""))
+	NormalBroadcastEmptyEmptyConfig := gonats.EndpointConfig{
+		Method: "NormalBroadcastEmptyEmpty",
+	}
+	err = service.AddEndpoint("NormalBroadcastEmptyEmpty-Broadcast", inFlight.Handler("NormalBroadcastEmptyEmpty-Broadcast", NormalBroadcastEmptyEmptyHandler, NormalBroadcastEmptyEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastEmptyEmpty", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalBroadcastEmptyEmpty-Direct", inFlight.Handler("NormalBroadcastEmptyEmpty-Direct", NormalBroadcastEmptyEmptyHandler, NormalBroadcastEmptyEmptyConfig), opts.Subject("service.TestService.NormalBroadcastEmptyEmpty", service.Info().ID))
+	if err != nil {
+		return err
+	}
 
-	ErrServerErrorBroadcastHandler := micro.HandlerFunc(func(request micro.Request) {
+	ThreeSecondDelayHandler := micro.HandlerFunc(func(request micro.Request) {
+		var err error
+		if withRequest, ok := server.(TestServiceThreeSecondDelayWithRequest); ok {
+			err = withRequest.ThreeSecondDelayWithRequest(request)
+		} else {
+			err = server.ThreeSecondDelay()
+		}
+		if err != nil {
+			if protonats.IsServiceError(err) {
+				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
+			}
+			var serverErr protonats.ServerError
+			if errors.As(err, &serverErr) {
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
+			} else {
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
+			}
+			return
+		}
+
+		request.Respond(nil, idHeader)
+	})
+	ThreeSecondDelayConfig := gonats.EndpointConfig{
+		Method: "ThreeSecondDelay",
+	}
+	err = service.AddEndpoint("ThreeSecondDelay", inFlight.Handler("ThreeSecondDelay", ThreeSecondDelayHandler, ThreeSecondDelayConfig), opts.Subject("service.TestService.ThreeSecondDelay", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("ThreeSecondDelay-Direct", inFlight.Handler("ThreeSecondDelay-Direct", ThreeSecondDelayHandler, ThreeSecondDelayConfig), opts.Subject("service.TestService.ThreeSecondDelay", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	PooledDelayHandler := micro.HandlerFunc(func(request micro.Request) {
+		var err error
+		if withRequest, ok := server.(TestServicePooledDelayWithRequest); ok {
+			err = withRequest.PooledDelayWithRequest(request)
+		} else {
+			err = server.PooledDelay()
+		}
+		if err != nil {
+			if protonats.IsServiceError(err) {
+				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
+			}
+			var serverErr protonats.ServerError
+			if errors.As(err, &serverErr) {
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
+			} else {
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
+			}
+			return
+		}
+
+		request.Respond(nil, idHeader)
+	})
+	PooledDelayConfig := gonats.EndpointConfig{
+		Method: "PooledDelay",
+		Pool:   &gonats.WorkerPool{Workers: 2, Queue: 1},
+	}
+	err = service.AddEndpoint("PooledDelay", inFlight.Handler("PooledDelay", PooledDelayHandler, PooledDelayConfig), opts.Subject("service.TestService.PooledDelay", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("PooledDelay-Direct", inFlight.Handler("PooledDelay-Direct", PooledDelayHandler, PooledDelayConfig), opts.Subject("service.TestService.PooledDelay", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	RateLimitedHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		response, err := server.ErrServerErrorBroadcast(&req)
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceRateLimitedWithRequest); ok {
+			response, err = withRequest.RateLimitedWithRequest(request, &req)
+		} else {
+			response, err = server.RateLimited(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("ErrServerErrorBroadcast-Broadcast", ErrServerErrorBroadcastHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.ErrServerErrorBroadcast", ""))
+	RateLimitedConfig := gonats.EndpointConfig{
+		Method:    "RateLimited",
+		RateLimit: &gonats.RateLimit{Rps: 1, Burst: 2},
+	}
+	err = service.AddEndpoint("RateLimited", inFlight.Handler("RateLimited", RateLimitedHandler, RateLimitedConfig), opts.Subject("service.TestService.RateLimited", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("RateLimited-Direct", inFlight.Handler("RateLimited-Direct", RateLimitedHandler, RateLimitedConfig), opts.Subject("service.TestService.RateLimited", service.Info().ID))
+	if err != nil {
+		return err
+	}
 
-	NormalBroadcastTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
+	AdminOnlyHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		response, err := server.NormalBroadcastTestTest(&req)
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceAdminOnlyWithRequest); ok {
+			response, err = withRequest.AdminOnlyWithRequest(request, &req)
+		} else {
+			response, err = server.AdminOnly(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("NormalBroadcastTestTest-Broadcast", NormalBroadcastTestTestHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastTestTest", ""))
+	AdminOnlyConfig := gonats.EndpointConfig{
+		Method: "AdminOnly",
+		Roles:  []string{"admin"},
+	}
+	err = service.AddEndpoint("AdminOnly", inFlight.Handler("AdminOnly", AdminOnlyHandler, AdminOnlyConfig), opts.Subject("service.TestService.AdminOnly", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("AdminOnly-Direct", inFlight.Handler("AdminOnly-Direct", AdminOnlyHandler, AdminOnlyConfig), opts.Subject("service.TestService.AdminOnly", service.Info().ID))
+	if err != nil {
+		return err
+	}
 
-	NormalBroadcastEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
-		response, err := server.NormalBroadcastEmptyTest()
+	DurableHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceDurableWithRequest); ok {
+			response, err = withRequest.DurableWithRequest(request, &req)
+		} else {
+			response, err = server.Durable(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("NormalBroadcastEmptyTest-Broadcast", NormalBroadcastEmptyTestHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastEmptyTest", ""))
+	DurableConfig := gonats.EndpointConfig{
+		Method: "Durable",
+	}
+	err = inFlight.Durable("TestService", "Durable", DurableHandler, DurableConfig)
+	if err != nil {
+		return err
+	}
 
-	NormalBroadcastTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+	OneWayHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		err := server.NormalBroadcastTestEmpty(&req)
+		var err error
+		if withRequest, ok := server.(TestServiceOneWayWithRequest); ok {
+			err = withRequest.OneWayWithRequest(request, &req)
+		} else {
+			err = server.OneWay(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		request.Respond(nil, idHeader)
 	})
-	err = service.AddEndpoint("NormalBroadcastTestEmpty-Broadcast", NormalBroadcastTestEmptyHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastTestEmpty", ""))
+	OneWayConfig := gonats.EndpointConfig{
+		Method: "OneWay",
+		OneWay: true,
+	}
+	err = service.AddEndpoint("OneWay", inFlight.Handler("OneWay", OneWayHandler, OneWayConfig), opts.Subject("service.TestService.OneWay", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("OneWay-Direct", inFlight.Handler("OneWay-Direct", OneWayHandler, OneWayConfig), opts.Subject("service.TestService.OneWay", service.Info().ID))
+	if err != nil {
+		return err
+	}
 
-	NormalBroadcastEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
-		err := server.NormalBroadcastEmptyEmpty()
+	HTTPGetHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req HTTPTest
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceHTTPGetWithRequest); ok {
+			response, err = withRequest.HTTPGetWithRequest(request, &req)
+		} else {
+			response, err = server.HTTPGet(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("NormalBroadcastEmptyEmpty-Broadcast", NormalBroadcastEmptyEmptyHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastEmptyEmpty", ""))
+	HTTPGetConfig := gonats.EndpointConfig{
+		Method: "HTTPGet",
+	}
+	err = service.AddEndpoint("HTTPGet", inFlight.Handler("HTTPGet", HTTPGetHandler, HTTPGetConfig), opts.Subject("service.TestService.HTTPGet", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("HTTPGet-Direct", inFlight.Handler("HTTPGet-Direct", HTTPGetHandler, HTTPGetConfig), opts.Subject("service.TestService.HTTPGet", service.Info().ID))
+	if err != nil {
+		return err
+	}
 
-	ThreeSecondDelayHandler := micro.HandlerFunc(func(request micro.Request) {
-		err := server.ThreeSecondDelay()
+	HTTPPostHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req HTTPTest
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceHTTPPostWithRequest); ok {
+			response, err = withRequest.HTTPPostWithRequest(request, &req)
+		} else {
+			response, err = server.HTTPPost(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("ThreeSecondDelay", ThreeSecondDelayHandler, opts.Subject("service.TestService.ThreeSecondDelay", ""))
+	HTTPPostConfig := gonats.EndpointConfig{
+		Method: "HTTPPost",
+	}
+	err = service.AddEndpoint("HTTPPost", inFlight.Handler("HTTPPost", HTTPPostHandler, HTTPPostConfig), opts.Subject("service.TestService.HTTPPost", ""))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
-	err = service.AddEndpoint("ThreeSecondDelay-Direct", ThreeSecondDelayHandler, opts.Subject("service.TestService.ThreeSecondDelay", service.Info().ID))
+	err = service.AddEndpoint("HTTPPost-Direct", inFlight.Handler("HTTPPost-Direct", HTTPPostHandler, HTTPPostConfig), opts.Subject("service.TestService.HTTPPost", service.Info().ID))
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return err
 	}
 
+	return nil
 }
 
-func NewTestServiceNATSLeaderServer(nc *nats_go.Conn, server TestServiceNATSLeaderServer, opts ...protonats.ServerOption) micro.Service {
-	service, options, err := impl.NewService("TestService", nc, server, opts...)
+func NewTestServiceNATSLeaderServer(nc *nats_go.Conn, server TestServiceNATSLeaderServer, opts ...protonats.ServerOption) (micro.Service, error) {
+	inFlight := gonats.NewInFlight(server)
+	service, options, err := gonats.NewService("TestService", nc, inFlight, _TestServiceServiceConfig, opts...)
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return nil, err
 	}
 	if setId, ok := server.(TestServiceId); ok {
 		setId.SetTestServiceId(service.Info().ID)
 	}
-	_newTestServiceLeaderServer(service, server, options)
-	return service
+	if err := _registerTestServiceReflection(service, options); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	if err := _registerTestServiceRole(service, options, inFlight); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	if err := _newTestServiceLeaderServer(service, server, options, inFlight); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	return service, nil
 }
 
-func _newTestServiceLeaderServer(service micro.Service, server TestServiceNATSLeaderServer, opts *impl.ServerOpts) {
+func _newTestServiceLeaderServer(service micro.Service, server TestServiceNATSLeaderServer, opts *impl.ServerOpts, inFlight *gonats.InFlight) error {
 	var err error
 	_ = err
+	idHeader := gonats.WithInstanceHeader(service.Info().ID)
 	LeaderOnlyTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		response, err := server.LeaderOnlyTestTest(&req)
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceLeaderOnlyTestTestWithRequest); ok {
+			response, err = withRequest.LeaderOnlyTestTestWithRequest(request, &req)
+		} else {
+			response, err = server.LeaderOnlyTestTest(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("LeaderOnlyTestTest", LeaderOnlyTestTestHandler, opts.Subject("service.TestService.LeaderOnlyTestTest", ""))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	LeaderOnlyTestTestConfig := gonats.EndpointConfig{
+		Method: "LeaderOnlyTestTest",
 	}
-	err = service.AddEndpoint("LeaderOnlyTestTest-Direct", LeaderOnlyTestTestHandler, opts.Subject("service.TestService.LeaderOnlyTestTest", service.Info().ID))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyTestTest", inFlight.Handler("LeaderOnlyTestTest", LeaderOnlyTestTestHandler, LeaderOnlyTestTestConfig), "service.TestService.LeaderOnlyTestTest", "", "")
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyTestTest-Direct", inFlight.Handler("LeaderOnlyTestTest-Direct", LeaderOnlyTestTestHandler, LeaderOnlyTestTestConfig), "service.TestService.LeaderOnlyTestTest", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+		if inFlight.ForwardsToLeader() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyTestTest", inFlight.Forward("service.TestService.LeaderOnlyTestTest"), "service.TestService.LeaderOnlyTestTest", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyTestTest-Direct", inFlight.Forward("service.TestService.LeaderOnlyTestTest"), "service.TestService.LeaderOnlyTestTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		err = service.AddEndpoint("LeaderOnlyTestTest", inFlight.Handler("LeaderOnlyTestTest", LeaderOnlyTestTestHandler, LeaderOnlyTestTestConfig), opts.Subject("service.TestService.LeaderOnlyTestTest", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("LeaderOnlyTestTest-Direct", inFlight.Handler("LeaderOnlyTestTest-Direct", LeaderOnlyTestTestHandler, LeaderOnlyTestTestConfig), opts.Subject("service.TestService.LeaderOnlyTestTest", service.Info().ID))
+		if err != nil {
+			return err
+		}
 	}
 
 	LeaderOnlyEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
-		response, err := server.LeaderOnlyEmptyTest()
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceLeaderOnlyEmptyTestWithRequest); ok {
+			response, err = withRequest.LeaderOnlyEmptyTestWithRequest(request)
+		} else {
+			response, err = server.LeaderOnlyEmptyTest()
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("LeaderOnlyEmptyTest", LeaderOnlyEmptyTestHandler, opts.Subject("service.TestService.LeaderOnlyEmptyTest", ""))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	LeaderOnlyEmptyTestConfig := gonats.EndpointConfig{
+		Method: "LeaderOnlyEmptyTest",
 	}
-	err = service.AddEndpoint("LeaderOnlyEmptyTest-Direct", LeaderOnlyEmptyTestHandler, opts.Subject("service.TestService.LeaderOnlyEmptyTest", service.Info().ID))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyEmptyTest", inFlight.Handler("LeaderOnlyEmptyTest", LeaderOnlyEmptyTestHandler, LeaderOnlyEmptyTestConfig), "service.TestService.LeaderOnlyEmptyTest", "", "")
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyEmptyTest-Direct", inFlight.Handler("LeaderOnlyEmptyTest-Direct", LeaderOnlyEmptyTestHandler, LeaderOnlyEmptyTestConfig), "service.TestService.LeaderOnlyEmptyTest", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+		if inFlight.ForwardsToLeader() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyEmptyTest", inFlight.Forward("service.TestService.LeaderOnlyEmptyTest"), "service.TestService.LeaderOnlyEmptyTest", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyEmptyTest-Direct", inFlight.Forward("service.TestService.LeaderOnlyEmptyTest"), "service.TestService.LeaderOnlyEmptyTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		err = service.AddEndpoint("LeaderOnlyEmptyTest", inFlight.Handler("LeaderOnlyEmptyTest", LeaderOnlyEmptyTestHandler, LeaderOnlyEmptyTestConfig), opts.Subject("service.TestService.LeaderOnlyEmptyTest", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("LeaderOnlyEmptyTest-Direct", inFlight.Handler("LeaderOnlyEmptyTest-Direct", LeaderOnlyEmptyTestHandler, LeaderOnlyEmptyTestConfig), opts.Subject("service.TestService.LeaderOnlyEmptyTest", service.Info().ID))
+		if err != nil {
+			return err
+		}
 	}
 
 	LeaderOnlyTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		err := server.LeaderOnlyTestEmpty(&req)
+		var err error
+		if withRequest, ok := server.(TestServiceLeaderOnlyTestEmptyWithRequest); ok {
+			err = withRequest.LeaderOnlyTestEmptyWithRequest(request, &req)
+		} else {
+			err = server.LeaderOnlyTestEmpty(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		request.Respond(nil, idHeader)
 	})
-	err = service.AddEndpoint("LeaderOnlyTestEmpty", LeaderOnlyTestEmptyHandler, opts.Subject("service.TestService.LeaderOnlyTestEmpty", ""))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	LeaderOnlyTestEmptyConfig := gonats.EndpointConfig{
+		Method: "LeaderOnlyTestEmpty",
 	}
-	err = service.AddEndpoint("LeaderOnlyTestEmpty-Direct", LeaderOnlyTestEmptyHandler, opts.Subject("service.TestService.LeaderOnlyTestEmpty", service.Info().ID))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyTestEmpty", inFlight.Handler("LeaderOnlyTestEmpty", LeaderOnlyTestEmptyHandler, LeaderOnlyTestEmptyConfig), "service.TestService.LeaderOnlyTestEmpty", "", "")
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyTestEmpty-Direct", inFlight.Handler("LeaderOnlyTestEmpty-Direct", LeaderOnlyTestEmptyHandler, LeaderOnlyTestEmptyConfig), "service.TestService.LeaderOnlyTestEmpty", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+		if inFlight.ForwardsToLeader() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyTestEmpty", inFlight.Forward("service.TestService.LeaderOnlyTestEmpty"), "service.TestService.LeaderOnlyTestEmpty", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyTestEmpty-Direct", inFlight.Forward("service.TestService.LeaderOnlyTestEmpty"), "service.TestService.LeaderOnlyTestEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		err = service.AddEndpoint("LeaderOnlyTestEmpty", inFlight.Handler("LeaderOnlyTestEmpty", LeaderOnlyTestEmptyHandler, LeaderOnlyTestEmptyConfig), opts.Subject("service.TestService.LeaderOnlyTestEmpty", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("LeaderOnlyTestEmpty-Direct", inFlight.Handler("LeaderOnlyTestEmpty-Direct", LeaderOnlyTestEmptyHandler, LeaderOnlyTestEmptyConfig), opts.Subject("service.TestService.LeaderOnlyTestEmpty", service.Info().ID))
+		if err != nil {
+			return err
+		}
 	}
 
 	LeaderOnlyEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
-		err := server.LeaderOnlyEmptyEmpty()
+		var err error
+		if withRequest, ok := server.(TestServiceLeaderOnlyEmptyEmptyWithRequest); ok {
+			err = withRequest.LeaderOnlyEmptyEmptyWithRequest(request)
+		} else {
+			err = server.LeaderOnlyEmptyEmpty()
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		request.Respond(nil, idHeader)
 	})
-	err = service.AddEndpoint("LeaderOnlyEmptyEmpty", LeaderOnlyEmptyEmptyHandler, opts.Subject("service.TestService.LeaderOnlyEmptyEmpty", ""))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	LeaderOnlyEmptyEmptyConfig := gonats.EndpointConfig{
+		Method: "LeaderOnlyEmptyEmpty",
 	}
-	err = service.AddEndpoint("LeaderOnlyEmptyEmpty-Direct", LeaderOnlyEmptyEmptyHandler, opts.Subject("service.TestService.LeaderOnlyEmptyEmpty", service.Info().ID))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyEmptyEmpty", inFlight.Handler("LeaderOnlyEmptyEmpty", LeaderOnlyEmptyEmptyHandler, LeaderOnlyEmptyEmptyConfig), "service.TestService.LeaderOnlyEmptyEmpty", "", "")
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyEmptyEmpty-Direct", inFlight.Handler("LeaderOnlyEmptyEmpty-Direct", LeaderOnlyEmptyEmptyHandler, LeaderOnlyEmptyEmptyConfig), "service.TestService.LeaderOnlyEmptyEmpty", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+		if inFlight.ForwardsToLeader() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyEmptyEmpty", inFlight.Forward("service.TestService.LeaderOnlyEmptyEmpty"), "service.TestService.LeaderOnlyEmptyEmpty", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyEmptyEmpty-Direct", inFlight.Forward("service.TestService.LeaderOnlyEmptyEmpty"), "service.TestService.LeaderOnlyEmptyEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		err = service.AddEndpoint("LeaderOnlyEmptyEmpty", inFlight.Handler("LeaderOnlyEmptyEmpty", LeaderOnlyEmptyEmptyHandler, LeaderOnlyEmptyEmptyConfig), opts.Subject("service.TestService.LeaderOnlyEmptyEmpty", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("LeaderOnlyEmptyEmpty-Direct", inFlight.Handler("LeaderOnlyEmptyEmpty-Direct", LeaderOnlyEmptyEmptyHandler, LeaderOnlyEmptyEmptyConfig), opts.Subject("service.TestService.LeaderOnlyEmptyEmpty", service.Info().ID))
+		if err != nil {
+			return err
+		}
 	}
 
 	LeaderOnlyBroadcastTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		response, err := server.LeaderOnlyBroadcastTestTest(&req)
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceLeaderOnlyBroadcastTestTestWithRequest); ok {
+			response, err = withRequest.LeaderOnlyBroadcastTestTestWithRequest(request, &req)
+		} else {
+			response, err = server.LeaderOnlyBroadcastTestTest(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("LeaderOnlyBroadcastTestTest-Broadcast", LeaderOnlyBroadcastTestTestHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastTestTest", ""))
+	LeaderOnlyBroadcastTestTestConfig := gonats.EndpointConfig{
+		Method: "LeaderOnlyBroadcastTestTest",
+	}
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastTestTest-Broadcast", inFlight.Handler("LeaderOnlyBroadcastTestTest-Broadcast", LeaderOnlyBroadcastTestTestHandler, LeaderOnlyBroadcastTestTestConfig), "service.TestService.LeaderOnlyBroadcastTestTest", "", nuid.Next())
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastTestTest-Direct", inFlight.Handler("LeaderOnlyBroadcastTestTest-Direct", LeaderOnlyBroadcastTestTestHandler, LeaderOnlyBroadcastTestTestConfig), "service.TestService.LeaderOnlyBroadcastTestTest", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("LeaderOnlyBroadcastTestTest-Broadcast", inFlight.Handler("LeaderOnlyBroadcastTestTest-Broadcast", LeaderOnlyBroadcastTestTestHandler, LeaderOnlyBroadcastTestTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastTestTest", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("LeaderOnlyBroadcastTestTest-Direct", inFlight.Handler("LeaderOnlyBroadcastTestTest-Direct", LeaderOnlyBroadcastTestTestHandler, LeaderOnlyBroadcastTestTestConfig), opts.Subject("service.TestService.LeaderOnlyBroadcastTestTest", service.Info().ID))
+		if err != nil {
+			return err
+		}
+	}
 
 	LeaderOnlyBroadcastEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
-		response, err := server.LeaderOnlyBroadcastEmptyTest()
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceLeaderOnlyBroadcastEmptyTestWithRequest); ok {
+			response, err = withRequest.LeaderOnlyBroadcastEmptyTestWithRequest(request)
+		} else {
+			response, err = server.LeaderOnlyBroadcastEmptyTest()
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("LeaderOnlyBroadcastEmptyTest-Broadcast", LeaderOnlyBroadcastEmptyTestHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyTest", ""))
+	LeaderOnlyBroadcastEmptyTestConfig := gonats.EndpointConfig{
+		Method: "LeaderOnlyBroadcastEmptyTest",
+	}
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastEmptyTest-Broadcast", inFlight.Handler("LeaderOnlyBroadcastEmptyTest-Broadcast", LeaderOnlyBroadcastEmptyTestHandler, LeaderOnlyBroadcastEmptyTestConfig), "service.TestService.LeaderOnlyBroadcastEmptyTest", "", nuid.Next())
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastEmptyTest-Direct", inFlight.Handler("LeaderOnlyBroadcastEmptyTest-Direct", LeaderOnlyBroadcastEmptyTestHandler, LeaderOnlyBroadcastEmptyTestConfig), "service.TestService.LeaderOnlyBroadcastEmptyTest", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("LeaderOnlyBroadcastEmptyTest-Broadcast", inFlight.Handler("LeaderOnlyBroadcastEmptyTest-Broadcast", LeaderOnlyBroadcastEmptyTestHandler, LeaderOnlyBroadcastEmptyTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyTest", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("LeaderOnlyBroadcastEmptyTest-Direct", inFlight.Handler("LeaderOnlyBroadcastEmptyTest-Direct", LeaderOnlyBroadcastEmptyTestHandler, LeaderOnlyBroadcastEmptyTestConfig), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyTest", service.Info().ID))
+		if err != nil {
+			return err
+		}
+	}
 
 	LeaderOnlyBroadcastTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		err := server.LeaderOnlyBroadcastTestEmpty(&req)
+		var err error
+		if withRequest, ok := server.(TestServiceLeaderOnlyBroadcastTestEmptyWithRequest); ok {
+			err = withRequest.LeaderOnlyBroadcastTestEmptyWithRequest(request, &req)
+		} else {
+			err = server.LeaderOnlyBroadcastTestEmpty(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		request.Respond(nil, idHeader)
 	})
-	err = service.AddEndpoint("LeaderOnlyBroadcastTestEmpty-Broadcast", LeaderOnlyBroadcastTestEmptyHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastTestEmpty", ""))
+	LeaderOnlyBroadcastTestEmptyConfig := gonats.EndpointConfig{
+		Method: "LeaderOnlyBroadcastTestEmpty",
+	}
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastTestEmpty-Broadcast", inFlight.Handler("LeaderOnlyBroadcastTestEmpty-Broadcast", LeaderOnlyBroadcastTestEmptyHandler, LeaderOnlyBroadcastTestEmptyConfig), "service.TestService.LeaderOnlyBroadcastTestEmpty", "", nuid.Next())
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastTestEmpty-Direct", inFlight.Handler("LeaderOnlyBroadcastTestEmpty-Direct", LeaderOnlyBroadcastTestEmptyHandler, LeaderOnlyBroadcastTestEmptyConfig), "service.TestService.LeaderOnlyBroadcastTestEmpty", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("LeaderOnlyBroadcastTestEmpty-Broadcast", inFlight.Handler("LeaderOnlyBroadcastTestEmpty-Broadcast", LeaderOnlyBroadcastTestEmptyHandler, LeaderOnlyBroadcastTestEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastTestEmpty", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("LeaderOnlyBroadcastTestEmpty-Direct", inFlight.Handler("LeaderOnlyBroadcastTestEmpty-Direct", LeaderOnlyBroadcastTestEmptyHandler, LeaderOnlyBroadcastTestEmptyConfig), opts.Subject("service.TestService.LeaderOnlyBroadcastTestEmpty", service.Info().ID))
+		if err != nil {
+			return err
+		}
+	}
 
 	LeaderOnlyBroadcastEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
-		err := server.LeaderOnlyBroadcastEmptyEmpty()
+		var err error
+		if withRequest, ok := server.(TestServiceLeaderOnlyBroadcastEmptyEmptyWithRequest); ok {
+			err = withRequest.LeaderOnlyBroadcastEmptyEmptyWithRequest(request)
+		} else {
+			err = server.LeaderOnlyBroadcastEmptyEmpty()
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		request.Respond(nil, idHeader)
 	})
-	err = service.AddEndpoint("LeaderOnlyBroadcastEmptyEmpty-Broadcast", LeaderOnlyBroadcastEmptyEmptyHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyEmpty", ""))
+	LeaderOnlyBroadcastEmptyEmptyConfig := gonats.EndpointConfig{
+		Method: "LeaderOnlyBroadcastEmptyEmpty",
+	}
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastEmptyEmpty-Broadcast", inFlight.Handler("LeaderOnlyBroadcastEmptyEmpty-Broadcast", LeaderOnlyBroadcastEmptyEmptyHandler, LeaderOnlyBroadcastEmptyEmptyConfig), "service.TestService.LeaderOnlyBroadcastEmptyEmpty", "", nuid.Next())
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastEmptyEmpty-Direct", inFlight.Handler("LeaderOnlyBroadcastEmptyEmpty-Direct", LeaderOnlyBroadcastEmptyEmptyHandler, LeaderOnlyBroadcastEmptyEmptyConfig), "service.TestService.LeaderOnlyBroadcastEmptyEmpty", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("LeaderOnlyBroadcastEmptyEmpty-Broadcast", inFlight.Handler("LeaderOnlyBroadcastEmptyEmpty-Broadcast", LeaderOnlyBroadcastEmptyEmptyHandler, LeaderOnlyBroadcastEmptyEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyEmpty", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("LeaderOnlyBroadcastEmptyEmpty-Direct", inFlight.Handler("LeaderOnlyBroadcastEmptyEmpty-Direct", LeaderOnlyBroadcastEmptyEmptyHandler, LeaderOnlyBroadcastEmptyEmptyConfig), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyEmpty", service.Info().ID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
+func _forwardTestServiceLeaderServer(service micro.Service, opts *impl.ServerOpts, inFlight *gonats.InFlight) error {
+	var err error
+	err = service.AddEndpoint("LeaderOnlyTestTest", inFlight.Forward("service.TestService.LeaderOnlyTestTest"), opts.Subject("service.TestService.LeaderOnlyTestTest", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("LeaderOnlyTestTest-Direct", inFlight.Forward("service.TestService.LeaderOnlyTestTest"), opts.Subject("service.TestService.LeaderOnlyTestTest", service.Info().ID))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("LeaderOnlyEmptyTest", inFlight.Forward("service.TestService.LeaderOnlyEmptyTest"), opts.Subject("service.TestService.LeaderOnlyEmptyTest", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("LeaderOnlyEmptyTest-Direct", inFlight.Forward("service.TestService.LeaderOnlyEmptyTest"), opts.Subject("service.TestService.LeaderOnlyEmptyTest", service.Info().ID))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("LeaderOnlyTestEmpty", inFlight.Forward("service.TestService.LeaderOnlyTestEmpty"), opts.Subject("service.TestService.LeaderOnlyTestEmpty", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("LeaderOnlyTestEmpty-Direct", inFlight.Forward("service.TestService.LeaderOnlyTestEmpty"), opts.Subject("service.TestService.LeaderOnlyTestEmpty", service.Info().ID))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("LeaderOnlyEmptyEmpty", inFlight.Forward("service.TestService.LeaderOnlyEmptyEmpty"), opts.Subject("service.TestService.LeaderOnlyEmptyEmpty", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("LeaderOnlyEmptyEmpty-Direct", inFlight.Forward("service.TestService.LeaderOnlyEmptyEmpty"), opts.Subject("service.TestService.LeaderOnlyEmptyEmpty", service.Info().ID))
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
-func NewTestServiceNATSFollowerServer(nc *nats_go.Conn, server TestServiceNATSFollowerServer, opts ...protonats.ServerOption) micro.Service {
-	service, options, err := impl.NewService("TestService", nc, server, opts...)
+func NewTestServiceNATSFollowerServer(nc *nats_go.Conn, server TestServiceNATSFollowerServer, opts ...protonats.ServerOption) (micro.Service, error) {
+	inFlight := gonats.NewInFlight(server)
+	service, options, err := gonats.NewService("TestService", nc, inFlight, _TestServiceServiceConfig, opts...)
 	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+		return nil, err
 	}
 	if setId, ok := server.(TestServiceId); ok {
 		setId.SetTestServiceId(service.Info().ID)
 	}
-	_newTestServiceFollowerServer(service, server, options)
-	return service
+	if err := _registerTestServiceReflection(service, options); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	if err := _registerTestServiceRole(service, options, inFlight); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	if err := _newTestServiceFollowerServer(service, server, options, inFlight); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	return service, nil
 }
 
-func _newTestServiceFollowerServer(service micro.Service, server TestServiceNATSFollowerServer, opts *impl.ServerOpts) {
+func _newTestServiceFollowerServer(service micro.Service, server TestServiceNATSFollowerServer, opts *impl.ServerOpts, inFlight *gonats.InFlight) error {
 	var err error
 	_ = err
+	idHeader := gonats.WithInstanceHeader(service.Info().ID)
 	FollowerOnlyTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		response, err := server.FollowerOnlyTestTest(&req)
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceFollowerOnlyTestTestWithRequest); ok {
+			response, err = withRequest.FollowerOnlyTestTestWithRequest(request, &req)
+		} else {
+			response, err = server.FollowerOnlyTestTest(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("FollowerOnlyTestTest", FollowerOnlyTestTestHandler, opts.Subject("service.TestService.FollowerOnlyTestTest", ""))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	FollowerOnlyTestTestConfig := gonats.EndpointConfig{
+		Method: "FollowerOnlyTestTest",
 	}
-	err = service.AddEndpoint("FollowerOnlyTestTest-Direct", FollowerOnlyTestTestHandler, opts.Subject("service.TestService.FollowerOnlyTestTest", service.Info().ID))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyTestTest", inFlight.Handler("FollowerOnlyTestTest", FollowerOnlyTestTestHandler, FollowerOnlyTestTestConfig), "service.TestService.FollowerOnlyTestTest", "", "")
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyTestTest-Direct", inFlight.Handler("FollowerOnlyTestTest-Direct", FollowerOnlyTestTestHandler, FollowerOnlyTestTestConfig), "service.TestService.FollowerOnlyTestTest", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("FollowerOnlyTestTest", inFlight.Handler("FollowerOnlyTestTest", FollowerOnlyTestTestHandler, FollowerOnlyTestTestConfig), opts.Subject("service.TestService.FollowerOnlyTestTest", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("FollowerOnlyTestTest-Direct", inFlight.Handler("FollowerOnlyTestTest-Direct", FollowerOnlyTestTestHandler, FollowerOnlyTestTestConfig), opts.Subject("service.TestService.FollowerOnlyTestTest", service.Info().ID))
+		if err != nil {
+			return err
+		}
 	}
 
 	FollowerOnlyEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
-		response, err := server.FollowerOnlyEmptyTest()
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceFollowerOnlyEmptyTestWithRequest); ok {
+			response, err = withRequest.FollowerOnlyEmptyTestWithRequest(request)
+		} else {
+			response, err = server.FollowerOnlyEmptyTest()
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("FollowerOnlyEmptyTest", FollowerOnlyEmptyTestHandler, opts.Subject("service.TestService.FollowerOnlyEmptyTest", ""))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	FollowerOnlyEmptyTestConfig := gonats.EndpointConfig{
+		Method: "FollowerOnlyEmptyTest",
 	}
-	err = service.AddEndpoint("FollowerOnlyEmptyTest-Direct", FollowerOnlyEmptyTestHandler, opts.Subject("service.TestService.FollowerOnlyEmptyTest", service.Info().ID))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyEmptyTest", inFlight.Handler("FollowerOnlyEmptyTest", FollowerOnlyEmptyTestHandler, FollowerOnlyEmptyTestConfig), "service.TestService.FollowerOnlyEmptyTest", "", "")
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyEmptyTest-Direct", inFlight.Handler("FollowerOnlyEmptyTest-Direct", FollowerOnlyEmptyTestHandler, FollowerOnlyEmptyTestConfig), "service.TestService.FollowerOnlyEmptyTest", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("FollowerOnlyEmptyTest", inFlight.Handler("FollowerOnlyEmptyTest", FollowerOnlyEmptyTestHandler, FollowerOnlyEmptyTestConfig), opts.Subject("service.TestService.FollowerOnlyEmptyTest", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("FollowerOnlyEmptyTest-Direct", inFlight.Handler("FollowerOnlyEmptyTest-Direct", FollowerOnlyEmptyTestHandler, FollowerOnlyEmptyTestConfig), opts.Subject("service.TestService.FollowerOnlyEmptyTest", service.Info().ID))
+		if err != nil {
+			return err
+		}
 	}
 
 	FollowerOnlyTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		err := server.FollowerOnlyTestEmpty(&req)
+		var err error
+		if withRequest, ok := server.(TestServiceFollowerOnlyTestEmptyWithRequest); ok {
+			err = withRequest.FollowerOnlyTestEmptyWithRequest(request, &req)
+		} else {
+			err = server.FollowerOnlyTestEmpty(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		request.Respond(nil, idHeader)
 	})
-	err = service.AddEndpoint("FollowerOnlyTestEmpty", FollowerOnlyTestEmptyHandler, opts.Subject("service.TestService.FollowerOnlyTestEmpty", ""))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	FollowerOnlyTestEmptyConfig := gonats.EndpointConfig{
+		Method: "FollowerOnlyTestEmpty",
 	}
-	err = service.AddEndpoint("FollowerOnlyTestEmpty-Direct", FollowerOnlyTestEmptyHandler, opts.Subject("service.TestService.FollowerOnlyTestEmpty", service.Info().ID))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyTestEmpty", inFlight.Handler("FollowerOnlyTestEmpty", FollowerOnlyTestEmptyHandler, FollowerOnlyTestEmptyConfig), "service.TestService.FollowerOnlyTestEmpty", "", "")
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyTestEmpty-Direct", inFlight.Handler("FollowerOnlyTestEmpty-Direct", FollowerOnlyTestEmptyHandler, FollowerOnlyTestEmptyConfig), "service.TestService.FollowerOnlyTestEmpty", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("FollowerOnlyTestEmpty", inFlight.Handler("FollowerOnlyTestEmpty", FollowerOnlyTestEmptyHandler, FollowerOnlyTestEmptyConfig), opts.Subject("service.TestService.FollowerOnlyTestEmpty", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("FollowerOnlyTestEmpty-Direct", inFlight.Handler("FollowerOnlyTestEmpty-Direct", FollowerOnlyTestEmptyHandler, FollowerOnlyTestEmptyConfig), opts.Subject("service.TestService.FollowerOnlyTestEmpty", service.Info().ID))
+		if err != nil {
+			return err
+		}
 	}
 
 	FollowerOnlyEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
-		err := server.FollowerOnlyEmptyEmpty()
+		var err error
+		if withRequest, ok := server.(TestServiceFollowerOnlyEmptyEmptyWithRequest); ok {
+			err = withRequest.FollowerOnlyEmptyEmptyWithRequest(request)
+		} else {
+			err = server.FollowerOnlyEmptyEmpty()
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		request.Respond(nil, idHeader)
 	})
-	err = service.AddEndpoint("FollowerOnlyEmptyEmpty", FollowerOnlyEmptyEmptyHandler, opts.Subject("service.TestService.FollowerOnlyEmptyEmpty", ""))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	FollowerOnlyEmptyEmptyConfig := gonats.EndpointConfig{
+		Method: "FollowerOnlyEmptyEmpty",
 	}
-	err = service.AddEndpoint("FollowerOnlyEmptyEmpty-Direct", FollowerOnlyEmptyEmptyHandler, opts.Subject("service.TestService.FollowerOnlyEmptyEmpty", service.Info().ID))
-	if err != nil {
-		panic(err) // TODO: Update this to proper error handling
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyEmptyEmpty", inFlight.Handler("FollowerOnlyEmptyEmpty", FollowerOnlyEmptyEmptyHandler, FollowerOnlyEmptyEmptyConfig), "service.TestService.FollowerOnlyEmptyEmpty", "", "")
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyEmptyEmpty-Direct", inFlight.Handler("FollowerOnlyEmptyEmpty-Direct", FollowerOnlyEmptyEmptyHandler, FollowerOnlyEmptyEmptyConfig), "service.TestService.FollowerOnlyEmptyEmpty", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("FollowerOnlyEmptyEmpty", inFlight.Handler("FollowerOnlyEmptyEmpty", FollowerOnlyEmptyEmptyHandler, FollowerOnlyEmptyEmptyConfig), opts.Subject("service.TestService.FollowerOnlyEmptyEmpty", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("FollowerOnlyEmptyEmpty-Direct", inFlight.Handler("FollowerOnlyEmptyEmpty-Direct", FollowerOnlyEmptyEmptyHandler, FollowerOnlyEmptyEmptyConfig), opts.Subject("service.TestService.FollowerOnlyEmptyEmpty", service.Info().ID))
+		if err != nil {
+			return err
+		}
 	}
 
 	FollowerOnlyBroadcastTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		response, err := server.FollowerOnlyBroadcastTestTest(&req)
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceFollowerOnlyBroadcastTestTestWithRequest); ok {
+			response, err = withRequest.FollowerOnlyBroadcastTestTestWithRequest(request, &req)
+		} else {
+			response, err = server.FollowerOnlyBroadcastTestTest(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("FollowerOnlyBroadcastTestTest-Broadcast", FollowerOnlyBroadcastTestTestHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastTestTest", ""))
+	FollowerOnlyBroadcastTestTestConfig := gonats.EndpointConfig{
+		Method: "FollowerOnlyBroadcastTestTest",
+	}
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastTestTest-Broadcast", inFlight.Handler("FollowerOnlyBroadcastTestTest-Broadcast", FollowerOnlyBroadcastTestTestHandler, FollowerOnlyBroadcastTestTestConfig), "service.TestService.FollowerOnlyBroadcastTestTest", "", nuid.Next())
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastTestTest-Direct", inFlight.Handler("FollowerOnlyBroadcastTestTest-Direct", FollowerOnlyBroadcastTestTestHandler, FollowerOnlyBroadcastTestTestConfig), "service.TestService.FollowerOnlyBroadcastTestTest", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("FollowerOnlyBroadcastTestTest-Broadcast", inFlight.Handler("FollowerOnlyBroadcastTestTest-Broadcast", FollowerOnlyBroadcastTestTestHandler, FollowerOnlyBroadcastTestTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastTestTest", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("FollowerOnlyBroadcastTestTest-Direct", inFlight.Handler("FollowerOnlyBroadcastTestTest-Direct", FollowerOnlyBroadcastTestTestHandler, FollowerOnlyBroadcastTestTestConfig), opts.Subject("service.TestService.FollowerOnlyBroadcastTestTest", service.Info().ID))
+		if err != nil {
+			return err
+		}
+	}
 
 	FollowerOnlyBroadcastEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
-		response, err := server.FollowerOnlyBroadcastEmptyTest()
+		var response *Test
+		var err error
+		if withRequest, ok := server.(TestServiceFollowerOnlyBroadcastEmptyTestWithRequest); ok {
+			response, err = withRequest.FollowerOnlyBroadcastEmptyTestWithRequest(request)
+		} else {
+			response, err = server.FollowerOnlyBroadcastEmptyTest()
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
 		data, err := proto.Marshal(response)
 		if err != nil {
-			request.Error("560", "Failed to marshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
-		request.Respond(data)
+		request.Respond(data, idHeader)
 	})
-	err = service.AddEndpoint("FollowerOnlyBroadcastEmptyTest-Broadcast", FollowerOnlyBroadcastEmptyTestHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyTest", ""))
+	FollowerOnlyBroadcastEmptyTestConfig := gonats.EndpointConfig{
+		Method: "FollowerOnlyBroadcastEmptyTest",
+	}
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastEmptyTest-Broadcast", inFlight.Handler("FollowerOnlyBroadcastEmptyTest-Broadcast", FollowerOnlyBroadcastEmptyTestHandler, FollowerOnlyBroadcastEmptyTestConfig), "service.TestService.FollowerOnlyBroadcastEmptyTest", "", nuid.Next())
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastEmptyTest-Direct", inFlight.Handler("FollowerOnlyBroadcastEmptyTest-Direct", FollowerOnlyBroadcastEmptyTestHandler, FollowerOnlyBroadcastEmptyTestConfig), "service.TestService.FollowerOnlyBroadcastEmptyTest", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("FollowerOnlyBroadcastEmptyTest-Broadcast", inFlight.Handler("FollowerOnlyBroadcastEmptyTest-Broadcast", FollowerOnlyBroadcastEmptyTestHandler, FollowerOnlyBroadcastEmptyTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyTest", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("FollowerOnlyBroadcastEmptyTest-Direct", inFlight.Handler("FollowerOnlyBroadcastEmptyTest-Direct", FollowerOnlyBroadcastEmptyTestHandler, FollowerOnlyBroadcastEmptyTestConfig), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyTest", service.Info().ID))
+		if err != nil {
+			return err
+		}
+	}
 
 	FollowerOnlyBroadcastTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
 		var req Test
 		if err := proto.Unmarshal(request.Data(), &req); err != nil {
-			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()))
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
 			return
 		}
 
-		err := server.FollowerOnlyBroadcastTestEmpty(&req)
+		var err error
+		if withRequest, ok := server.(TestServiceFollowerOnlyBroadcastTestEmptyWithRequest); ok {
+			err = withRequest.FollowerOnlyBroadcastTestEmptyWithRequest(request, &req)
+		} else {
+			err = server.FollowerOnlyBroadcastTestEmpty(&req)
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		request.Respond(nil, idHeader)
 	})
-	err = service.AddEndpoint("FollowerOnlyBroadcastTestEmpty-Broadcast", FollowerOnlyBroadcastTestEmptyHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastTestEmpty", ""))
+	FollowerOnlyBroadcastTestEmptyConfig := gonats.EndpointConfig{
+		Method: "FollowerOnlyBroadcastTestEmpty",
+	}
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastTestEmpty-Broadcast", inFlight.Handler("FollowerOnlyBroadcastTestEmpty-Broadcast", FollowerOnlyBroadcastTestEmptyHandler, FollowerOnlyBroadcastTestEmptyConfig), "service.TestService.FollowerOnlyBroadcastTestEmpty", "", nuid.Next())
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastTestEmpty-Direct", inFlight.Handler("FollowerOnlyBroadcastTestEmpty-Direct", FollowerOnlyBroadcastTestEmptyHandler, FollowerOnlyBroadcastTestEmptyConfig), "service.TestService.FollowerOnlyBroadcastTestEmpty", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("FollowerOnlyBroadcastTestEmpty-Broadcast", inFlight.Handler("FollowerOnlyBroadcastTestEmpty-Broadcast", FollowerOnlyBroadcastTestEmptyHandler, FollowerOnlyBroadcastTestEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastTestEmpty", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("FollowerOnlyBroadcastTestEmpty-Direct", inFlight.Handler("FollowerOnlyBroadcastTestEmpty-Direct", FollowerOnlyBroadcastTestEmptyHandler, FollowerOnlyBroadcastTestEmptyConfig), opts.Subject("service.TestService.FollowerOnlyBroadcastTestEmpty", service.Info().ID))
+		if err != nil {
+			return err
+		}
+	}
 
 	FollowerOnlyBroadcastEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
-		err := server.FollowerOnlyBroadcastEmptyEmpty()
+		var err error
+		if withRequest, ok := server.(TestServiceFollowerOnlyBroadcastEmptyEmptyWithRequest); ok {
+			err = withRequest.FollowerOnlyBroadcastEmptyEmptyWithRequest(request)
+		} else {
+			err = server.FollowerOnlyBroadcastEmptyEmpty()
+		}
 		if err != nil {
 			if protonats.IsServiceError(err) {
 				slog.Warn("Server implementations should not return ServiceError, use go_nats.NewServerError instead", "error", err)
 			}
 			var serverErr protonats.ServerError
 			if errors.As(err, &serverErr) {
-				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders())
+				request.Error(serverErr.Code, serverErr.Description, serverErr.GetWrapped(), serverErr.GetOptHeaders(), idHeader)
 			} else {
-				request.Error("500", "Internal server error", []byte(err.Error()))
+				request.Error("500", "Internal server error", []byte(err.Error()), idHeader)
 			}
 			return
 		}
 
-		request.Respond(nil)
+		request.Respond(nil, idHeader)
+	})
+	FollowerOnlyBroadcastEmptyEmptyConfig := gonats.EndpointConfig{
+		Method: "FollowerOnlyBroadcastEmptyEmpty",
+	}
+	if inFlight.DynamicRoles() {
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastEmptyEmpty-Broadcast", inFlight.Handler("FollowerOnlyBroadcastEmptyEmpty-Broadcast", FollowerOnlyBroadcastEmptyEmptyHandler, FollowerOnlyBroadcastEmptyEmptyConfig), "service.TestService.FollowerOnlyBroadcastEmptyEmpty", "", nuid.Next())
+		if err != nil {
+			return err
+		}
+		err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastEmptyEmpty-Direct", inFlight.Handler("FollowerOnlyBroadcastEmptyEmpty-Direct", FollowerOnlyBroadcastEmptyEmptyHandler, FollowerOnlyBroadcastEmptyEmptyConfig), "service.TestService.FollowerOnlyBroadcastEmptyEmpty", service.Info().ID, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = service.AddEndpoint("FollowerOnlyBroadcastEmptyEmpty-Broadcast", inFlight.Handler("FollowerOnlyBroadcastEmptyEmpty-Broadcast", FollowerOnlyBroadcastEmptyEmptyHandler, FollowerOnlyBroadcastEmptyEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyEmpty", ""))
+		if err != nil {
+			return err
+		}
+		err = service.AddEndpoint("FollowerOnlyBroadcastEmptyEmpty-Direct", inFlight.Handler("FollowerOnlyBroadcastEmptyEmpty-Direct", FollowerOnlyBroadcastEmptyEmptyHandler, FollowerOnlyBroadcastEmptyEmptyConfig), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyEmpty", service.Info().ID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//endregion
+//region Events
+
+// OrderCreatedSubjectPattern is the subject pattern OrderCreated events are published to.
+const OrderCreatedSubjectPattern = "orders.{region}.created"
+
+// OrderCreatedSubject returns the subject event is published to, filling the tokens of OrderCreatedSubjectPattern from its fields.
+func OrderCreatedSubject(event *OrderCreated) (string, error) {
+	return gonats.EventSubject(OrderCreatedSubjectPattern, map[string]string{
+		"region": fmt.Sprint(event.GetRegion()),
 	})
-	err = service.AddEndpoint("FollowerOnlyBroadcastEmptyEmpty-Broadcast", FollowerOnlyBroadcastEmptyEmptyHandler, micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyEmpty", ""))
+}
+
+// PublishOrderCreated publishes event to the subject returned by OrderCreatedSubject.
+func PublishOrderCreated(nc *nats_go.Conn, event *OrderCreated) error {
+	subject, err := OrderCreatedSubject(event)
+	if err != nil {
+		return err
+	}
+	return gonats.PublishEvent(nc, subject, event)
+}
 
+// SubscribeOrderCreated calls handler for every OrderCreated event.
+// Use gonats.WithToken to only receive events with specific values of the tokens of OrderCreatedSubjectPattern.
+func SubscribeOrderCreated(nc *nats_go.Conn, handler func(event *OrderCreated, msg *nats_go.Msg), opts ...gonats.SubscribeOption) (*nats_go.Subscription, error) {
+	return gonats.SubscribeEvents(nc, OrderCreatedSubjectPattern, func() *OrderCreated { return new(OrderCreated) }, handler, opts...)
 }
 
 //endregion
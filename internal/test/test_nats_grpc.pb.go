@@ -0,0 +1,1895 @@
+// Code generated by protoc-gen-go-nats. DO NOT EDIT.
+// Versions:
+// - protoc-gen-go-nats v0.1.14+dirty
+// - protoc        v5.29.3
+// source: test.proto
+
+package test
+
+import (
+	context "context"
+	nats_go "github.com/nats-io/nats.go"
+	micro "github.com/nats-io/nats.go/micro"
+	nuid "github.com/nats-io/nuid"
+	proto "google.golang.org/protobuf/proto"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	gonats "xiam.li/go-protonats/gonats"
+	grpcnats "xiam.li/go-protonats/gonats/grpcnats"
+	impl "xiam.li/protonats/go/impl"
+	protonats "xiam.li/protonats/go/protonats"
+)
+
+// region gRPC Adapter
+// NewTestServiceNATSServerFromGRPC serves a gRPC implementation of TestService, as generated by protoc-gen-go-grpc, over NATS.
+// The NATS headers of a request are passed to the implementation as incoming metadata, and the metadata it sets
+// using grpc.SetHeader or grpc.SetTrailer is sent as NATS headers of the response.
+// The context passed to the implementation ends once the client stopped waiting for the response.
+// gRPC status errors are translated into the matching ServerError codes.
+func NewTestServiceNATSServerFromGRPC(nc *nats_go.Conn, server TestServiceServer, opts ...protonats.ServerOption) (micro.Service, error) {
+	inFlight := gonats.NewInFlight(server)
+	service, options, err := gonats.NewService("TestService", nc, inFlight, _TestServiceServiceConfig, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if setId, ok := server.(TestServiceId); ok {
+		setId.SetTestServiceId(service.Info().ID)
+	}
+	if err := _registerTestServiceReflection(service, options); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	if err := _registerTestServiceRole(service, options, inFlight); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	if err := _newTestServiceGRPCServer(service, server, options, inFlight); err != nil {
+		_ = service.Stop()
+		return nil, err
+	}
+	return service, nil
+}
+
+func _newTestServiceGRPCServer(service micro.Service, server TestServiceServer, opts *impl.ServerOpts, inFlight *gonats.InFlight) error {
+	var err error
+	_ = err
+	idHeader := gonats.WithInstanceHeader(service.Info().ID)
+
+	NormalTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/NormalTestTest")
+		defer call.Cancel()
+		response, err := server.NormalTestTest(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	NormalTestTestConfig := gonats.EndpointConfig{
+		Method: "NormalTestTest",
+	}
+	err = service.AddEndpoint("NormalTestTest", inFlight.Handler("NormalTestTest", NormalTestTestHandler, NormalTestTestConfig), opts.Subject("service.TestService.NormalTestTest", ""), micro.WithEndpointMetadata(map[string]string{"description": "Replies with the request and the instance ID", "kind": "normal"}))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalTestTest-Direct", inFlight.Handler("NormalTestTest-Direct", NormalTestTestHandler, NormalTestTestConfig), opts.Subject("service.TestService.NormalTestTest", service.Info().ID), micro.WithEndpointMetadata(map[string]string{"description": "Replies with the request and the instance ID", "kind": "normal"}))
+	if err != nil {
+		return err
+	}
+
+	NormalEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req emptypb.Empty
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/NormalEmptyTest")
+		defer call.Cancel()
+		response, err := server.NormalEmptyTest(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	NormalEmptyTestConfig := gonats.EndpointConfig{
+		Method: "NormalEmptyTest",
+	}
+	err = service.AddEndpoint("NormalEmptyTest", inFlight.Handler("NormalEmptyTest", NormalEmptyTestHandler, NormalEmptyTestConfig), opts.Subject("service.TestService.NormalEmptyTest", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalEmptyTest-Direct", inFlight.Handler("NormalEmptyTest-Direct", NormalEmptyTestHandler, NormalEmptyTestConfig), opts.Subject("service.TestService.NormalEmptyTest", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	NormalTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/NormalTestEmpty")
+		defer call.Cancel()
+		response, err := server.NormalTestEmpty(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	NormalTestEmptyConfig := gonats.EndpointConfig{
+		Method: "NormalTestEmpty",
+	}
+	err = service.AddEndpoint("NormalTestEmpty", inFlight.Handler("NormalTestEmpty", NormalTestEmptyHandler, NormalTestEmptyConfig), opts.Subject("service.TestService.NormalTestEmpty", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalTestEmpty-Direct", inFlight.Handler("NormalTestEmpty-Direct", NormalTestEmptyHandler, NormalTestEmptyConfig), opts.Subject("service.TestService.NormalTestEmpty", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	NormalEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req emptypb.Empty
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/NormalEmptyEmpty")
+		defer call.Cancel()
+		response, err := server.NormalEmptyEmpty(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	NormalEmptyEmptyConfig := gonats.EndpointConfig{
+		Method: "NormalEmptyEmpty",
+	}
+	err = service.AddEndpoint("NormalEmptyEmpty", inFlight.Handler("NormalEmptyEmpty", NormalEmptyEmptyHandler, NormalEmptyEmptyConfig), opts.Subject("service.TestService.NormalEmptyEmpty", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalEmptyEmpty-Direct", inFlight.Handler("NormalEmptyEmpty-Direct", NormalEmptyEmptyHandler, NormalEmptyEmptyConfig), opts.Subject("service.TestService.NormalEmptyEmpty", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	ErrServiceErrorHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/ErrServiceError")
+		defer call.Cancel()
+		response, err := server.ErrServiceError(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	ErrServiceErrorConfig := gonats.EndpointConfig{
+		Method: "ErrServiceError",
+	}
+	err = service.AddEndpoint("ErrServiceError", inFlight.Handler("ErrServiceError", ErrServiceErrorHandler, ErrServiceErrorConfig), opts.Subject("service.TestService.ErrServiceError", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("ErrServiceError-Direct", inFlight.Handler("ErrServiceError-Direct", ErrServiceErrorHandler, ErrServiceErrorConfig), opts.Subject("service.TestService.ErrServiceError", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	ErrServerErrorHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/ErrServerError")
+		defer call.Cancel()
+		response, err := server.ErrServerError(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	ErrServerErrorConfig := gonats.EndpointConfig{
+		Method: "ErrServerError",
+	}
+	err = service.AddEndpoint("ErrServerError", inFlight.Handler("ErrServerError", ErrServerErrorHandler, ErrServerErrorConfig), opts.Subject("service.TestService.ErrServerError", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("ErrServerError-Direct", inFlight.Handler("ErrServerError-Direct", ErrServerErrorHandler, ErrServerErrorConfig), opts.Subject("service.TestService.ErrServerError", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	ErrServiceErrorBroadcastHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/ErrServiceErrorBroadcast")
+		defer call.Cancel()
+		response, err := server.ErrServiceErrorBroadcast(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	ErrServiceErrorBroadcastConfig := gonats.EndpointConfig{
+		Method: "ErrServiceErrorBroadcast",
+	}
+	err = service.AddEndpoint("ErrServiceErrorBroadcast-Broadcast", inFlight.Handler("ErrServiceErrorBroadcast-Broadcast", ErrServiceErrorBroadcastHandler, ErrServiceErrorBroadcastConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.ErrServiceErrorBroadcast", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("ErrServiceErrorBroadcast-Direct", inFlight.Handler("ErrServiceErrorBroadcast-Direct", ErrServiceErrorBroadcastHandler, ErrServiceErrorBroadcastConfig), opts.Subject("service.TestService.ErrServiceErrorBroadcast", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	ErrServerErrorBroadcastHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/ErrServerErrorBroadcast")
+		defer call.Cancel()
+		response, err := server.ErrServerErrorBroadcast(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	ErrServerErrorBroadcastConfig := gonats.EndpointConfig{
+		Method: "ErrServerErrorBroadcast",
+	}
+	err = service.AddEndpoint("ErrServerErrorBroadcast-Broadcast", inFlight.Handler("ErrServerErrorBroadcast-Broadcast", ErrServerErrorBroadcastHandler, ErrServerErrorBroadcastConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.ErrServerErrorBroadcast", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("ErrServerErrorBroadcast-Direct", inFlight.Handler("ErrServerErrorBroadcast-Direct", ErrServerErrorBroadcastHandler, ErrServerErrorBroadcastConfig), opts.Subject("service.TestService.ErrServerErrorBroadcast", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	NormalBroadcastTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/NormalBroadcastTestTest")
+		defer call.Cancel()
+		response, err := server.NormalBroadcastTestTest(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	NormalBroadcastTestTestConfig := gonats.EndpointConfig{
+		Method: "NormalBroadcastTestTest",
+	}
+	err = service.AddEndpoint("NormalBroadcastTestTest-Broadcast", inFlight.Handler("NormalBroadcastTestTest-Broadcast", NormalBroadcastTestTestHandler, NormalBroadcastTestTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastTestTest", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalBroadcastTestTest-Direct", inFlight.Handler("NormalBroadcastTestTest-Direct", NormalBroadcastTestTestHandler, NormalBroadcastTestTestConfig), opts.Subject("service.TestService.NormalBroadcastTestTest", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	NormalBroadcastEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req emptypb.Empty
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/NormalBroadcastEmptyTest")
+		defer call.Cancel()
+		response, err := server.NormalBroadcastEmptyTest(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	NormalBroadcastEmptyTestConfig := gonats.EndpointConfig{
+		Method: "NormalBroadcastEmptyTest",
+	}
+	err = service.AddEndpoint("NormalBroadcastEmptyTest-Broadcast", inFlight.Handler("NormalBroadcastEmptyTest-Broadcast", NormalBroadcastEmptyTestHandler, NormalBroadcastEmptyTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastEmptyTest", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalBroadcastEmptyTest-Direct", inFlight.Handler("NormalBroadcastEmptyTest-Direct", NormalBroadcastEmptyTestHandler, NormalBroadcastEmptyTestConfig), opts.Subject("service.TestService.NormalBroadcastEmptyTest", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	NormalBroadcastTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/NormalBroadcastTestEmpty")
+		defer call.Cancel()
+		response, err := server.NormalBroadcastTestEmpty(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	NormalBroadcastTestEmptyConfig := gonats.EndpointConfig{
+		Method: "NormalBroadcastTestEmpty",
+	}
+	err = service.AddEndpoint("NormalBroadcastTestEmpty-Broadcast", inFlight.Handler("NormalBroadcastTestEmpty-Broadcast", NormalBroadcastTestEmptyHandler, NormalBroadcastTestEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastTestEmpty", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalBroadcastTestEmpty-Direct", inFlight.Handler("NormalBroadcastTestEmpty-Direct", NormalBroadcastTestEmptyHandler, NormalBroadcastTestEmptyConfig), opts.Subject("service.TestService.NormalBroadcastTestEmpty", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	NormalBroadcastEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req emptypb.Empty
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/NormalBroadcastEmptyEmpty")
+		defer call.Cancel()
+		response, err := server.NormalBroadcastEmptyEmpty(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	NormalBroadcastEmptyEmptyConfig := gonats.EndpointConfig{
+		Method: "NormalBroadcastEmptyEmpty",
+	}
+	err = service.AddEndpoint("NormalBroadcastEmptyEmpty-Broadcast", inFlight.Handler("NormalBroadcastEmptyEmpty-Broadcast", NormalBroadcastEmptyEmptyHandler, NormalBroadcastEmptyEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.NormalBroadcastEmptyEmpty", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("NormalBroadcastEmptyEmpty-Direct", inFlight.Handler("NormalBroadcastEmptyEmpty-Direct", NormalBroadcastEmptyEmptyHandler, NormalBroadcastEmptyEmptyConfig), opts.Subject("service.TestService.NormalBroadcastEmptyEmpty", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	if !opts.WithoutLeaderFunctions {
+		LeaderOnlyTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req Test
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/LeaderOnlyTestTest")
+			defer call.Cancel()
+			response, err := server.LeaderOnlyTestTest(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		LeaderOnlyTestTestConfig := gonats.EndpointConfig{
+			Method: "LeaderOnlyTestTest",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyTestTest", inFlight.Handler("LeaderOnlyTestTest", LeaderOnlyTestTestHandler, LeaderOnlyTestTestConfig), "service.TestService.LeaderOnlyTestTest", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyTestTest-Direct", inFlight.Handler("LeaderOnlyTestTest-Direct", LeaderOnlyTestTestHandler, LeaderOnlyTestTestConfig), "service.TestService.LeaderOnlyTestTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+			if inFlight.ForwardsToLeader() {
+				err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyTestTest", inFlight.Forward("service.TestService.LeaderOnlyTestTest"), "service.TestService.LeaderOnlyTestTest", "", "")
+				if err != nil {
+					return err
+				}
+				err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyTestTest-Direct", inFlight.Forward("service.TestService.LeaderOnlyTestTest"), "service.TestService.LeaderOnlyTestTest", service.Info().ID, "")
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			err = service.AddEndpoint("LeaderOnlyTestTest", inFlight.Handler("LeaderOnlyTestTest", LeaderOnlyTestTestHandler, LeaderOnlyTestTestConfig), opts.Subject("service.TestService.LeaderOnlyTestTest", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("LeaderOnlyTestTest-Direct", inFlight.Handler("LeaderOnlyTestTest-Direct", LeaderOnlyTestTestHandler, LeaderOnlyTestTestConfig), opts.Subject("service.TestService.LeaderOnlyTestTest", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutLeaderFunctions {
+		LeaderOnlyEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req emptypb.Empty
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/LeaderOnlyEmptyTest")
+			defer call.Cancel()
+			response, err := server.LeaderOnlyEmptyTest(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		LeaderOnlyEmptyTestConfig := gonats.EndpointConfig{
+			Method: "LeaderOnlyEmptyTest",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyEmptyTest", inFlight.Handler("LeaderOnlyEmptyTest", LeaderOnlyEmptyTestHandler, LeaderOnlyEmptyTestConfig), "service.TestService.LeaderOnlyEmptyTest", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyEmptyTest-Direct", inFlight.Handler("LeaderOnlyEmptyTest-Direct", LeaderOnlyEmptyTestHandler, LeaderOnlyEmptyTestConfig), "service.TestService.LeaderOnlyEmptyTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+			if inFlight.ForwardsToLeader() {
+				err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyEmptyTest", inFlight.Forward("service.TestService.LeaderOnlyEmptyTest"), "service.TestService.LeaderOnlyEmptyTest", "", "")
+				if err != nil {
+					return err
+				}
+				err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyEmptyTest-Direct", inFlight.Forward("service.TestService.LeaderOnlyEmptyTest"), "service.TestService.LeaderOnlyEmptyTest", service.Info().ID, "")
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			err = service.AddEndpoint("LeaderOnlyEmptyTest", inFlight.Handler("LeaderOnlyEmptyTest", LeaderOnlyEmptyTestHandler, LeaderOnlyEmptyTestConfig), opts.Subject("service.TestService.LeaderOnlyEmptyTest", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("LeaderOnlyEmptyTest-Direct", inFlight.Handler("LeaderOnlyEmptyTest-Direct", LeaderOnlyEmptyTestHandler, LeaderOnlyEmptyTestConfig), opts.Subject("service.TestService.LeaderOnlyEmptyTest", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutLeaderFunctions {
+		LeaderOnlyTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req Test
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/LeaderOnlyTestEmpty")
+			defer call.Cancel()
+			response, err := server.LeaderOnlyTestEmpty(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		LeaderOnlyTestEmptyConfig := gonats.EndpointConfig{
+			Method: "LeaderOnlyTestEmpty",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyTestEmpty", inFlight.Handler("LeaderOnlyTestEmpty", LeaderOnlyTestEmptyHandler, LeaderOnlyTestEmptyConfig), "service.TestService.LeaderOnlyTestEmpty", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyTestEmpty-Direct", inFlight.Handler("LeaderOnlyTestEmpty-Direct", LeaderOnlyTestEmptyHandler, LeaderOnlyTestEmptyConfig), "service.TestService.LeaderOnlyTestEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+			if inFlight.ForwardsToLeader() {
+				err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyTestEmpty", inFlight.Forward("service.TestService.LeaderOnlyTestEmpty"), "service.TestService.LeaderOnlyTestEmpty", "", "")
+				if err != nil {
+					return err
+				}
+				err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyTestEmpty-Direct", inFlight.Forward("service.TestService.LeaderOnlyTestEmpty"), "service.TestService.LeaderOnlyTestEmpty", service.Info().ID, "")
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			err = service.AddEndpoint("LeaderOnlyTestEmpty", inFlight.Handler("LeaderOnlyTestEmpty", LeaderOnlyTestEmptyHandler, LeaderOnlyTestEmptyConfig), opts.Subject("service.TestService.LeaderOnlyTestEmpty", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("LeaderOnlyTestEmpty-Direct", inFlight.Handler("LeaderOnlyTestEmpty-Direct", LeaderOnlyTestEmptyHandler, LeaderOnlyTestEmptyConfig), opts.Subject("service.TestService.LeaderOnlyTestEmpty", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutLeaderFunctions {
+		LeaderOnlyEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req emptypb.Empty
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/LeaderOnlyEmptyEmpty")
+			defer call.Cancel()
+			response, err := server.LeaderOnlyEmptyEmpty(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		LeaderOnlyEmptyEmptyConfig := gonats.EndpointConfig{
+			Method: "LeaderOnlyEmptyEmpty",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyEmptyEmpty", inFlight.Handler("LeaderOnlyEmptyEmpty", LeaderOnlyEmptyEmptyHandler, LeaderOnlyEmptyEmptyConfig), "service.TestService.LeaderOnlyEmptyEmpty", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyEmptyEmpty-Direct", inFlight.Handler("LeaderOnlyEmptyEmpty-Direct", LeaderOnlyEmptyEmptyHandler, LeaderOnlyEmptyEmptyConfig), "service.TestService.LeaderOnlyEmptyEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+			if inFlight.ForwardsToLeader() {
+				err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyEmptyEmpty", inFlight.Forward("service.TestService.LeaderOnlyEmptyEmpty"), "service.TestService.LeaderOnlyEmptyEmpty", "", "")
+				if err != nil {
+					return err
+				}
+				err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "LeaderOnlyEmptyEmpty-Direct", inFlight.Forward("service.TestService.LeaderOnlyEmptyEmpty"), "service.TestService.LeaderOnlyEmptyEmpty", service.Info().ID, "")
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			err = service.AddEndpoint("LeaderOnlyEmptyEmpty", inFlight.Handler("LeaderOnlyEmptyEmpty", LeaderOnlyEmptyEmptyHandler, LeaderOnlyEmptyEmptyConfig), opts.Subject("service.TestService.LeaderOnlyEmptyEmpty", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("LeaderOnlyEmptyEmpty-Direct", inFlight.Handler("LeaderOnlyEmptyEmpty-Direct", LeaderOnlyEmptyEmptyHandler, LeaderOnlyEmptyEmptyConfig), opts.Subject("service.TestService.LeaderOnlyEmptyEmpty", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutLeaderFunctions {
+		LeaderOnlyBroadcastTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req Test
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/LeaderOnlyBroadcastTestTest")
+			defer call.Cancel()
+			response, err := server.LeaderOnlyBroadcastTestTest(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		LeaderOnlyBroadcastTestTestConfig := gonats.EndpointConfig{
+			Method: "LeaderOnlyBroadcastTestTest",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastTestTest-Broadcast", inFlight.Handler("LeaderOnlyBroadcastTestTest-Broadcast", LeaderOnlyBroadcastTestTestHandler, LeaderOnlyBroadcastTestTestConfig), "service.TestService.LeaderOnlyBroadcastTestTest", "", nuid.Next())
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastTestTest-Direct", inFlight.Handler("LeaderOnlyBroadcastTestTest-Direct", LeaderOnlyBroadcastTestTestHandler, LeaderOnlyBroadcastTestTestConfig), "service.TestService.LeaderOnlyBroadcastTestTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("LeaderOnlyBroadcastTestTest-Broadcast", inFlight.Handler("LeaderOnlyBroadcastTestTest-Broadcast", LeaderOnlyBroadcastTestTestHandler, LeaderOnlyBroadcastTestTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastTestTest", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("LeaderOnlyBroadcastTestTest-Direct", inFlight.Handler("LeaderOnlyBroadcastTestTest-Direct", LeaderOnlyBroadcastTestTestHandler, LeaderOnlyBroadcastTestTestConfig), opts.Subject("service.TestService.LeaderOnlyBroadcastTestTest", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutLeaderFunctions {
+		LeaderOnlyBroadcastEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req emptypb.Empty
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/LeaderOnlyBroadcastEmptyTest")
+			defer call.Cancel()
+			response, err := server.LeaderOnlyBroadcastEmptyTest(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		LeaderOnlyBroadcastEmptyTestConfig := gonats.EndpointConfig{
+			Method: "LeaderOnlyBroadcastEmptyTest",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastEmptyTest-Broadcast", inFlight.Handler("LeaderOnlyBroadcastEmptyTest-Broadcast", LeaderOnlyBroadcastEmptyTestHandler, LeaderOnlyBroadcastEmptyTestConfig), "service.TestService.LeaderOnlyBroadcastEmptyTest", "", nuid.Next())
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastEmptyTest-Direct", inFlight.Handler("LeaderOnlyBroadcastEmptyTest-Direct", LeaderOnlyBroadcastEmptyTestHandler, LeaderOnlyBroadcastEmptyTestConfig), "service.TestService.LeaderOnlyBroadcastEmptyTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("LeaderOnlyBroadcastEmptyTest-Broadcast", inFlight.Handler("LeaderOnlyBroadcastEmptyTest-Broadcast", LeaderOnlyBroadcastEmptyTestHandler, LeaderOnlyBroadcastEmptyTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyTest", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("LeaderOnlyBroadcastEmptyTest-Direct", inFlight.Handler("LeaderOnlyBroadcastEmptyTest-Direct", LeaderOnlyBroadcastEmptyTestHandler, LeaderOnlyBroadcastEmptyTestConfig), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyTest", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutLeaderFunctions {
+		LeaderOnlyBroadcastTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req Test
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/LeaderOnlyBroadcastTestEmpty")
+			defer call.Cancel()
+			response, err := server.LeaderOnlyBroadcastTestEmpty(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		LeaderOnlyBroadcastTestEmptyConfig := gonats.EndpointConfig{
+			Method: "LeaderOnlyBroadcastTestEmpty",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastTestEmpty-Broadcast", inFlight.Handler("LeaderOnlyBroadcastTestEmpty-Broadcast", LeaderOnlyBroadcastTestEmptyHandler, LeaderOnlyBroadcastTestEmptyConfig), "service.TestService.LeaderOnlyBroadcastTestEmpty", "", nuid.Next())
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastTestEmpty-Direct", inFlight.Handler("LeaderOnlyBroadcastTestEmpty-Direct", LeaderOnlyBroadcastTestEmptyHandler, LeaderOnlyBroadcastTestEmptyConfig), "service.TestService.LeaderOnlyBroadcastTestEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("LeaderOnlyBroadcastTestEmpty-Broadcast", inFlight.Handler("LeaderOnlyBroadcastTestEmpty-Broadcast", LeaderOnlyBroadcastTestEmptyHandler, LeaderOnlyBroadcastTestEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastTestEmpty", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("LeaderOnlyBroadcastTestEmpty-Direct", inFlight.Handler("LeaderOnlyBroadcastTestEmpty-Direct", LeaderOnlyBroadcastTestEmptyHandler, LeaderOnlyBroadcastTestEmptyConfig), opts.Subject("service.TestService.LeaderOnlyBroadcastTestEmpty", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutLeaderFunctions {
+		LeaderOnlyBroadcastEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req emptypb.Empty
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/LeaderOnlyBroadcastEmptyEmpty")
+			defer call.Cancel()
+			response, err := server.LeaderOnlyBroadcastEmptyEmpty(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		LeaderOnlyBroadcastEmptyEmptyConfig := gonats.EndpointConfig{
+			Method: "LeaderOnlyBroadcastEmptyEmpty",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastEmptyEmpty-Broadcast", inFlight.Handler("LeaderOnlyBroadcastEmptyEmpty-Broadcast", LeaderOnlyBroadcastEmptyEmptyHandler, LeaderOnlyBroadcastEmptyEmptyConfig), "service.TestService.LeaderOnlyBroadcastEmptyEmpty", "", nuid.Next())
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleLeader, "LeaderOnlyBroadcastEmptyEmpty-Direct", inFlight.Handler("LeaderOnlyBroadcastEmptyEmpty-Direct", LeaderOnlyBroadcastEmptyEmptyHandler, LeaderOnlyBroadcastEmptyEmptyConfig), "service.TestService.LeaderOnlyBroadcastEmptyEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("LeaderOnlyBroadcastEmptyEmpty-Broadcast", inFlight.Handler("LeaderOnlyBroadcastEmptyEmpty-Broadcast", LeaderOnlyBroadcastEmptyEmptyHandler, LeaderOnlyBroadcastEmptyEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyEmpty", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("LeaderOnlyBroadcastEmptyEmpty-Direct", inFlight.Handler("LeaderOnlyBroadcastEmptyEmpty-Direct", LeaderOnlyBroadcastEmptyEmptyHandler, LeaderOnlyBroadcastEmptyEmptyConfig), opts.Subject("service.TestService.LeaderOnlyBroadcastEmptyEmpty", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutFollowerFunctions {
+		FollowerOnlyTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req Test
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/FollowerOnlyTestTest")
+			defer call.Cancel()
+			response, err := server.FollowerOnlyTestTest(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		FollowerOnlyTestTestConfig := gonats.EndpointConfig{
+			Method: "FollowerOnlyTestTest",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyTestTest", inFlight.Handler("FollowerOnlyTestTest", FollowerOnlyTestTestHandler, FollowerOnlyTestTestConfig), "service.TestService.FollowerOnlyTestTest", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyTestTest-Direct", inFlight.Handler("FollowerOnlyTestTest-Direct", FollowerOnlyTestTestHandler, FollowerOnlyTestTestConfig), "service.TestService.FollowerOnlyTestTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("FollowerOnlyTestTest", inFlight.Handler("FollowerOnlyTestTest", FollowerOnlyTestTestHandler, FollowerOnlyTestTestConfig), opts.Subject("service.TestService.FollowerOnlyTestTest", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("FollowerOnlyTestTest-Direct", inFlight.Handler("FollowerOnlyTestTest-Direct", FollowerOnlyTestTestHandler, FollowerOnlyTestTestConfig), opts.Subject("service.TestService.FollowerOnlyTestTest", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutFollowerFunctions {
+		FollowerOnlyEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req emptypb.Empty
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/FollowerOnlyEmptyTest")
+			defer call.Cancel()
+			response, err := server.FollowerOnlyEmptyTest(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		FollowerOnlyEmptyTestConfig := gonats.EndpointConfig{
+			Method: "FollowerOnlyEmptyTest",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyEmptyTest", inFlight.Handler("FollowerOnlyEmptyTest", FollowerOnlyEmptyTestHandler, FollowerOnlyEmptyTestConfig), "service.TestService.FollowerOnlyEmptyTest", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyEmptyTest-Direct", inFlight.Handler("FollowerOnlyEmptyTest-Direct", FollowerOnlyEmptyTestHandler, FollowerOnlyEmptyTestConfig), "service.TestService.FollowerOnlyEmptyTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("FollowerOnlyEmptyTest", inFlight.Handler("FollowerOnlyEmptyTest", FollowerOnlyEmptyTestHandler, FollowerOnlyEmptyTestConfig), opts.Subject("service.TestService.FollowerOnlyEmptyTest", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("FollowerOnlyEmptyTest-Direct", inFlight.Handler("FollowerOnlyEmptyTest-Direct", FollowerOnlyEmptyTestHandler, FollowerOnlyEmptyTestConfig), opts.Subject("service.TestService.FollowerOnlyEmptyTest", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutFollowerFunctions {
+		FollowerOnlyTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req Test
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/FollowerOnlyTestEmpty")
+			defer call.Cancel()
+			response, err := server.FollowerOnlyTestEmpty(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		FollowerOnlyTestEmptyConfig := gonats.EndpointConfig{
+			Method: "FollowerOnlyTestEmpty",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyTestEmpty", inFlight.Handler("FollowerOnlyTestEmpty", FollowerOnlyTestEmptyHandler, FollowerOnlyTestEmptyConfig), "service.TestService.FollowerOnlyTestEmpty", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyTestEmpty-Direct", inFlight.Handler("FollowerOnlyTestEmpty-Direct", FollowerOnlyTestEmptyHandler, FollowerOnlyTestEmptyConfig), "service.TestService.FollowerOnlyTestEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("FollowerOnlyTestEmpty", inFlight.Handler("FollowerOnlyTestEmpty", FollowerOnlyTestEmptyHandler, FollowerOnlyTestEmptyConfig), opts.Subject("service.TestService.FollowerOnlyTestEmpty", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("FollowerOnlyTestEmpty-Direct", inFlight.Handler("FollowerOnlyTestEmpty-Direct", FollowerOnlyTestEmptyHandler, FollowerOnlyTestEmptyConfig), opts.Subject("service.TestService.FollowerOnlyTestEmpty", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutFollowerFunctions {
+		FollowerOnlyEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req emptypb.Empty
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/FollowerOnlyEmptyEmpty")
+			defer call.Cancel()
+			response, err := server.FollowerOnlyEmptyEmpty(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		FollowerOnlyEmptyEmptyConfig := gonats.EndpointConfig{
+			Method: "FollowerOnlyEmptyEmpty",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyEmptyEmpty", inFlight.Handler("FollowerOnlyEmptyEmpty", FollowerOnlyEmptyEmptyHandler, FollowerOnlyEmptyEmptyConfig), "service.TestService.FollowerOnlyEmptyEmpty", "", "")
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyEmptyEmpty-Direct", inFlight.Handler("FollowerOnlyEmptyEmpty-Direct", FollowerOnlyEmptyEmptyHandler, FollowerOnlyEmptyEmptyConfig), "service.TestService.FollowerOnlyEmptyEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("FollowerOnlyEmptyEmpty", inFlight.Handler("FollowerOnlyEmptyEmpty", FollowerOnlyEmptyEmptyHandler, FollowerOnlyEmptyEmptyConfig), opts.Subject("service.TestService.FollowerOnlyEmptyEmpty", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("FollowerOnlyEmptyEmpty-Direct", inFlight.Handler("FollowerOnlyEmptyEmpty-Direct", FollowerOnlyEmptyEmptyHandler, FollowerOnlyEmptyEmptyConfig), opts.Subject("service.TestService.FollowerOnlyEmptyEmpty", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutFollowerFunctions {
+		FollowerOnlyBroadcastTestTestHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req Test
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/FollowerOnlyBroadcastTestTest")
+			defer call.Cancel()
+			response, err := server.FollowerOnlyBroadcastTestTest(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		FollowerOnlyBroadcastTestTestConfig := gonats.EndpointConfig{
+			Method: "FollowerOnlyBroadcastTestTest",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastTestTest-Broadcast", inFlight.Handler("FollowerOnlyBroadcastTestTest-Broadcast", FollowerOnlyBroadcastTestTestHandler, FollowerOnlyBroadcastTestTestConfig), "service.TestService.FollowerOnlyBroadcastTestTest", "", nuid.Next())
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastTestTest-Direct", inFlight.Handler("FollowerOnlyBroadcastTestTest-Direct", FollowerOnlyBroadcastTestTestHandler, FollowerOnlyBroadcastTestTestConfig), "service.TestService.FollowerOnlyBroadcastTestTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("FollowerOnlyBroadcastTestTest-Broadcast", inFlight.Handler("FollowerOnlyBroadcastTestTest-Broadcast", FollowerOnlyBroadcastTestTestHandler, FollowerOnlyBroadcastTestTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastTestTest", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("FollowerOnlyBroadcastTestTest-Direct", inFlight.Handler("FollowerOnlyBroadcastTestTest-Direct", FollowerOnlyBroadcastTestTestHandler, FollowerOnlyBroadcastTestTestConfig), opts.Subject("service.TestService.FollowerOnlyBroadcastTestTest", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutFollowerFunctions {
+		FollowerOnlyBroadcastEmptyTestHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req emptypb.Empty
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/FollowerOnlyBroadcastEmptyTest")
+			defer call.Cancel()
+			response, err := server.FollowerOnlyBroadcastEmptyTest(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		FollowerOnlyBroadcastEmptyTestConfig := gonats.EndpointConfig{
+			Method: "FollowerOnlyBroadcastEmptyTest",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastEmptyTest-Broadcast", inFlight.Handler("FollowerOnlyBroadcastEmptyTest-Broadcast", FollowerOnlyBroadcastEmptyTestHandler, FollowerOnlyBroadcastEmptyTestConfig), "service.TestService.FollowerOnlyBroadcastEmptyTest", "", nuid.Next())
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastEmptyTest-Direct", inFlight.Handler("FollowerOnlyBroadcastEmptyTest-Direct", FollowerOnlyBroadcastEmptyTestHandler, FollowerOnlyBroadcastEmptyTestConfig), "service.TestService.FollowerOnlyBroadcastEmptyTest", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("FollowerOnlyBroadcastEmptyTest-Broadcast", inFlight.Handler("FollowerOnlyBroadcastEmptyTest-Broadcast", FollowerOnlyBroadcastEmptyTestHandler, FollowerOnlyBroadcastEmptyTestConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyTest", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("FollowerOnlyBroadcastEmptyTest-Direct", inFlight.Handler("FollowerOnlyBroadcastEmptyTest-Direct", FollowerOnlyBroadcastEmptyTestHandler, FollowerOnlyBroadcastEmptyTestConfig), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyTest", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutFollowerFunctions {
+		FollowerOnlyBroadcastTestEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req Test
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/FollowerOnlyBroadcastTestEmpty")
+			defer call.Cancel()
+			response, err := server.FollowerOnlyBroadcastTestEmpty(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		FollowerOnlyBroadcastTestEmptyConfig := gonats.EndpointConfig{
+			Method: "FollowerOnlyBroadcastTestEmpty",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastTestEmpty-Broadcast", inFlight.Handler("FollowerOnlyBroadcastTestEmpty-Broadcast", FollowerOnlyBroadcastTestEmptyHandler, FollowerOnlyBroadcastTestEmptyConfig), "service.TestService.FollowerOnlyBroadcastTestEmpty", "", nuid.Next())
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastTestEmpty-Direct", inFlight.Handler("FollowerOnlyBroadcastTestEmpty-Direct", FollowerOnlyBroadcastTestEmptyHandler, FollowerOnlyBroadcastTestEmptyConfig), "service.TestService.FollowerOnlyBroadcastTestEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("FollowerOnlyBroadcastTestEmpty-Broadcast", inFlight.Handler("FollowerOnlyBroadcastTestEmpty-Broadcast", FollowerOnlyBroadcastTestEmptyHandler, FollowerOnlyBroadcastTestEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastTestEmpty", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("FollowerOnlyBroadcastTestEmpty-Direct", inFlight.Handler("FollowerOnlyBroadcastTestEmpty-Direct", FollowerOnlyBroadcastTestEmptyHandler, FollowerOnlyBroadcastTestEmptyConfig), opts.Subject("service.TestService.FollowerOnlyBroadcastTestEmpty", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	if !opts.WithoutFollowerFunctions {
+		FollowerOnlyBroadcastEmptyEmptyHandler := micro.HandlerFunc(func(request micro.Request) {
+			var req emptypb.Empty
+			if err := proto.Unmarshal(request.Data(), &req); err != nil {
+				request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+
+			ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/FollowerOnlyBroadcastEmptyEmpty")
+			defer call.Cancel()
+			response, err := server.FollowerOnlyBroadcastEmptyEmpty(ctx, &req)
+			if err != nil {
+				grpcnats.RespondError(request, err, call.Headers(), idHeader)
+				return
+			}
+
+			data, err := proto.Marshal(response)
+			if err != nil {
+				request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+				return
+			}
+			request.Respond(data, call.Headers(), idHeader)
+		})
+		FollowerOnlyBroadcastEmptyEmptyConfig := gonats.EndpointConfig{
+			Method: "FollowerOnlyBroadcastEmptyEmpty",
+		}
+		if inFlight.DynamicRoles() {
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastEmptyEmpty-Broadcast", inFlight.Handler("FollowerOnlyBroadcastEmptyEmpty-Broadcast", FollowerOnlyBroadcastEmptyEmptyHandler, FollowerOnlyBroadcastEmptyEmptyConfig), "service.TestService.FollowerOnlyBroadcastEmptyEmpty", "", nuid.Next())
+			if err != nil {
+				return err
+			}
+			err = inFlight.AddRoleEndpoint(gonats.RoleFollower, "FollowerOnlyBroadcastEmptyEmpty-Direct", inFlight.Handler("FollowerOnlyBroadcastEmptyEmpty-Direct", FollowerOnlyBroadcastEmptyEmptyHandler, FollowerOnlyBroadcastEmptyEmptyConfig), "service.TestService.FollowerOnlyBroadcastEmptyEmpty", service.Info().ID, "")
+			if err != nil {
+				return err
+			}
+		} else {
+			err = service.AddEndpoint("FollowerOnlyBroadcastEmptyEmpty-Broadcast", inFlight.Handler("FollowerOnlyBroadcastEmptyEmpty-Broadcast", FollowerOnlyBroadcastEmptyEmptyHandler, FollowerOnlyBroadcastEmptyEmptyConfig), micro.WithEndpointQueueGroup(nuid.Next()), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyEmpty", ""))
+			if err != nil {
+				return err
+			}
+			err = service.AddEndpoint("FollowerOnlyBroadcastEmptyEmpty-Direct", inFlight.Handler("FollowerOnlyBroadcastEmptyEmpty-Direct", FollowerOnlyBroadcastEmptyEmptyHandler, FollowerOnlyBroadcastEmptyEmptyConfig), opts.Subject("service.TestService.FollowerOnlyBroadcastEmptyEmpty", service.Info().ID))
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	ThreeSecondDelayHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req emptypb.Empty
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/ThreeSecondDelay")
+		defer call.Cancel()
+		response, err := server.ThreeSecondDelay(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	ThreeSecondDelayConfig := gonats.EndpointConfig{
+		Method: "ThreeSecondDelay",
+	}
+	err = service.AddEndpoint("ThreeSecondDelay", inFlight.Handler("ThreeSecondDelay", ThreeSecondDelayHandler, ThreeSecondDelayConfig), opts.Subject("service.TestService.ThreeSecondDelay", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("ThreeSecondDelay-Direct", inFlight.Handler("ThreeSecondDelay-Direct", ThreeSecondDelayHandler, ThreeSecondDelayConfig), opts.Subject("service.TestService.ThreeSecondDelay", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	PooledDelayHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req emptypb.Empty
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/PooledDelay")
+		defer call.Cancel()
+		response, err := server.PooledDelay(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	PooledDelayConfig := gonats.EndpointConfig{
+		Method: "PooledDelay",
+		Pool:   &gonats.WorkerPool{Workers: 2, Queue: 1},
+	}
+	err = service.AddEndpoint("PooledDelay", inFlight.Handler("PooledDelay", PooledDelayHandler, PooledDelayConfig), opts.Subject("service.TestService.PooledDelay", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("PooledDelay-Direct", inFlight.Handler("PooledDelay-Direct", PooledDelayHandler, PooledDelayConfig), opts.Subject("service.TestService.PooledDelay", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	RateLimitedHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/RateLimited")
+		defer call.Cancel()
+		response, err := server.RateLimited(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	RateLimitedConfig := gonats.EndpointConfig{
+		Method:    "RateLimited",
+		RateLimit: &gonats.RateLimit{Rps: 1, Burst: 2},
+	}
+	err = service.AddEndpoint("RateLimited", inFlight.Handler("RateLimited", RateLimitedHandler, RateLimitedConfig), opts.Subject("service.TestService.RateLimited", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("RateLimited-Direct", inFlight.Handler("RateLimited-Direct", RateLimitedHandler, RateLimitedConfig), opts.Subject("service.TestService.RateLimited", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	AdminOnlyHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/AdminOnly")
+		defer call.Cancel()
+		response, err := server.AdminOnly(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	AdminOnlyConfig := gonats.EndpointConfig{
+		Method: "AdminOnly",
+		Roles:  []string{"admin"},
+	}
+	err = service.AddEndpoint("AdminOnly", inFlight.Handler("AdminOnly", AdminOnlyHandler, AdminOnlyConfig), opts.Subject("service.TestService.AdminOnly", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("AdminOnly-Direct", inFlight.Handler("AdminOnly-Direct", AdminOnlyHandler, AdminOnlyConfig), opts.Subject("service.TestService.AdminOnly", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	DurableHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/Durable")
+		defer call.Cancel()
+		response, err := server.Durable(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	DurableConfig := gonats.EndpointConfig{
+		Method: "Durable",
+	}
+	err = inFlight.Durable("TestService", "Durable", DurableHandler, DurableConfig)
+	if err != nil {
+		return err
+	}
+
+	OneWayHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req Test
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/OneWay")
+		defer call.Cancel()
+		response, err := server.OneWay(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	OneWayConfig := gonats.EndpointConfig{
+		Method: "OneWay",
+		OneWay: true,
+	}
+	err = service.AddEndpoint("OneWay", inFlight.Handler("OneWay", OneWayHandler, OneWayConfig), opts.Subject("service.TestService.OneWay", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("OneWay-Direct", inFlight.Handler("OneWay-Direct", OneWayHandler, OneWayConfig), opts.Subject("service.TestService.OneWay", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	HTTPGetHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req HTTPTest
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/HTTPGet")
+		defer call.Cancel()
+		response, err := server.HTTPGet(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	HTTPGetConfig := gonats.EndpointConfig{
+		Method: "HTTPGet",
+	}
+	err = service.AddEndpoint("HTTPGet", inFlight.Handler("HTTPGet", HTTPGetHandler, HTTPGetConfig), opts.Subject("service.TestService.HTTPGet", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("HTTPGet-Direct", inFlight.Handler("HTTPGet-Direct", HTTPGetHandler, HTTPGetConfig), opts.Subject("service.TestService.HTTPGet", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	HTTPPostHandler := micro.HandlerFunc(func(request micro.Request) {
+		var req HTTPTest
+		if err := proto.Unmarshal(request.Data(), &req); err != nil {
+			request.Error("560", "Failed to unmarshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+
+		ctx, call := grpcnats.NewCall(request, "/protonats.go.test.TestService/HTTPPost")
+		defer call.Cancel()
+		response, err := server.HTTPPost(ctx, &req)
+		if err != nil {
+			grpcnats.RespondError(request, err, call.Headers(), idHeader)
+			return
+		}
+
+		data, err := proto.Marshal(response)
+		if err != nil {
+			request.Error("560", "Failed to marshal proto message", []byte(err.Error()), idHeader)
+			return
+		}
+		request.Respond(data, call.Headers(), idHeader)
+	})
+	HTTPPostConfig := gonats.EndpointConfig{
+		Method: "HTTPPost",
+	}
+	err = service.AddEndpoint("HTTPPost", inFlight.Handler("HTTPPost", HTTPPostHandler, HTTPPostConfig), opts.Subject("service.TestService.HTTPPost", ""))
+	if err != nil {
+		return err
+	}
+	err = service.AddEndpoint("HTTPPost-Direct", inFlight.Handler("HTTPPost-Direct", HTTPPostHandler, HTTPPostConfig), opts.Subject("service.TestService.HTTPPost", service.Info().ID))
+	if err != nil {
+		return err
+	}
+
+	if opts.WithoutLeaderFunctions && inFlight.ForwardsToLeader() {
+		return _forwardTestServiceLeaderServer(service, opts, inFlight)
+	}
+	return nil
+}
+
+//endregion
+
+// region gRPC Bridge
+type _TestServiceGRPCBridge struct {
+	UnimplementedTestServiceServer
+	client TestServiceNATSClient
+}
+
+// NewTestServiceGRPCBridge returns a gRPC implementation of TestService, to be registered with RegisterTestServiceServer,
+// that forwards every unary call to the NATS service through client.
+// The deadline of the call is used as timeout, and a single instance can be selected with the "protonats-instance" metadata.
+// Broadcasting methods return the first response and send the IDs of all responding instances as "protonats-instance" header.
+// Errors are translated into the matching gRPC status codes. Streaming methods are not supported.
+func NewTestServiceGRPCBridge(client TestServiceNATSClient) TestServiceServer {
+	return &_TestServiceGRPCBridge{client: client}
+}
+
+func (b *_TestServiceGRPCBridge) NormalTestTest(ctx context.Context, req *Test) (*Test, error) {
+	resp, err := b.client.NormalTestTest(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) NormalEmptyTest(ctx context.Context, req *emptypb.Empty) (*Test, error) {
+	resp, err := b.client.NormalEmptyTest(grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) NormalTestEmpty(ctx context.Context, req *Test) (*emptypb.Empty, error) {
+	if err := b.client.NormalTestEmpty(req, grpcnats.CallOptions(ctx)...); err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) NormalEmptyEmpty(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := b.client.NormalEmptyEmpty(grpcnats.CallOptions(ctx)...); err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) ErrServiceError(ctx context.Context, req *Test) (*Test, error) {
+	resp, err := b.client.ErrServiceError(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) ErrServerError(ctx context.Context, req *Test) (*Test, error) {
+	resp, err := b.client.ErrServerError(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) ErrServiceErrorBroadcast(ctx context.Context, req *Test) (*Test, error) {
+	var results []gonats.BroadcastResult[*Test]
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.ErrServiceErrorBroadcastTo(target, req, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.ErrServiceErrorBroadcast(req, grpcnats.CallOptions(ctx)...)
+	}
+	return grpcnats.BroadcastResponse(ctx, results, err)
+}
+
+func (b *_TestServiceGRPCBridge) ErrServerErrorBroadcast(ctx context.Context, req *Test) (*Test, error) {
+	var results []gonats.BroadcastResult[*Test]
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.ErrServerErrorBroadcastTo(target, req, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.ErrServerErrorBroadcast(req, grpcnats.CallOptions(ctx)...)
+	}
+	return grpcnats.BroadcastResponse(ctx, results, err)
+}
+
+func (b *_TestServiceGRPCBridge) NormalBroadcastTestTest(ctx context.Context, req *Test) (*Test, error) {
+	var results []gonats.BroadcastResult[*Test]
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.NormalBroadcastTestTestTo(target, req, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.NormalBroadcastTestTest(req, grpcnats.CallOptions(ctx)...)
+	}
+	return grpcnats.BroadcastResponse(ctx, results, err)
+}
+
+func (b *_TestServiceGRPCBridge) NormalBroadcastEmptyTest(ctx context.Context, req *emptypb.Empty) (*Test, error) {
+	var results []gonats.BroadcastResult[*Test]
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.NormalBroadcastEmptyTestTo(target, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.NormalBroadcastEmptyTest(grpcnats.CallOptions(ctx)...)
+	}
+	return grpcnats.BroadcastResponse(ctx, results, err)
+}
+
+func (b *_TestServiceGRPCBridge) NormalBroadcastTestEmpty(ctx context.Context, req *Test) (*emptypb.Empty, error) {
+	var results []gonats.BroadcastAck
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.NormalBroadcastTestEmptyTo(target, req, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.NormalBroadcastTestEmpty(req, grpcnats.CallOptions(ctx)...)
+	}
+	if _, err := grpcnats.BroadcastResponse(ctx, results, err); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) NormalBroadcastEmptyEmpty(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	var results []gonats.BroadcastAck
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.NormalBroadcastEmptyEmptyTo(target, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.NormalBroadcastEmptyEmpty(grpcnats.CallOptions(ctx)...)
+	}
+	if _, err := grpcnats.BroadcastResponse(ctx, results, err); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) LeaderOnlyTestTest(ctx context.Context, req *Test) (*Test, error) {
+	resp, err := b.client.LeaderOnlyTestTest(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) LeaderOnlyEmptyTest(ctx context.Context, req *emptypb.Empty) (*Test, error) {
+	resp, err := b.client.LeaderOnlyEmptyTest(grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) LeaderOnlyTestEmpty(ctx context.Context, req *Test) (*emptypb.Empty, error) {
+	if err := b.client.LeaderOnlyTestEmpty(req, grpcnats.CallOptions(ctx)...); err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) LeaderOnlyEmptyEmpty(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := b.client.LeaderOnlyEmptyEmpty(grpcnats.CallOptions(ctx)...); err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) LeaderOnlyBroadcastTestTest(ctx context.Context, req *Test) (*Test, error) {
+	var results []gonats.BroadcastResult[*Test]
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.LeaderOnlyBroadcastTestTestTo(target, req, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.LeaderOnlyBroadcastTestTest(req, grpcnats.CallOptions(ctx)...)
+	}
+	return grpcnats.BroadcastResponse(ctx, results, err)
+}
+
+func (b *_TestServiceGRPCBridge) LeaderOnlyBroadcastEmptyTest(ctx context.Context, req *emptypb.Empty) (*Test, error) {
+	var results []gonats.BroadcastResult[*Test]
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.LeaderOnlyBroadcastEmptyTestTo(target, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.LeaderOnlyBroadcastEmptyTest(grpcnats.CallOptions(ctx)...)
+	}
+	return grpcnats.BroadcastResponse(ctx, results, err)
+}
+
+func (b *_TestServiceGRPCBridge) LeaderOnlyBroadcastTestEmpty(ctx context.Context, req *Test) (*emptypb.Empty, error) {
+	var results []gonats.BroadcastAck
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.LeaderOnlyBroadcastTestEmptyTo(target, req, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.LeaderOnlyBroadcastTestEmpty(req, grpcnats.CallOptions(ctx)...)
+	}
+	if _, err := grpcnats.BroadcastResponse(ctx, results, err); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) LeaderOnlyBroadcastEmptyEmpty(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	var results []gonats.BroadcastAck
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.LeaderOnlyBroadcastEmptyEmptyTo(target, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.LeaderOnlyBroadcastEmptyEmpty(grpcnats.CallOptions(ctx)...)
+	}
+	if _, err := grpcnats.BroadcastResponse(ctx, results, err); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) FollowerOnlyTestTest(ctx context.Context, req *Test) (*Test, error) {
+	resp, err := b.client.FollowerOnlyTestTest(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) FollowerOnlyEmptyTest(ctx context.Context, req *emptypb.Empty) (*Test, error) {
+	resp, err := b.client.FollowerOnlyEmptyTest(grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) FollowerOnlyTestEmpty(ctx context.Context, req *Test) (*emptypb.Empty, error) {
+	if err := b.client.FollowerOnlyTestEmpty(req, grpcnats.CallOptions(ctx)...); err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) FollowerOnlyEmptyEmpty(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := b.client.FollowerOnlyEmptyEmpty(grpcnats.CallOptions(ctx)...); err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) FollowerOnlyBroadcastTestTest(ctx context.Context, req *Test) (*Test, error) {
+	var results []gonats.BroadcastResult[*Test]
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.FollowerOnlyBroadcastTestTestTo(target, req, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.FollowerOnlyBroadcastTestTest(req, grpcnats.CallOptions(ctx)...)
+	}
+	return grpcnats.BroadcastResponse(ctx, results, err)
+}
+
+func (b *_TestServiceGRPCBridge) FollowerOnlyBroadcastEmptyTest(ctx context.Context, req *emptypb.Empty) (*Test, error) {
+	var results []gonats.BroadcastResult[*Test]
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.FollowerOnlyBroadcastEmptyTestTo(target, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.FollowerOnlyBroadcastEmptyTest(grpcnats.CallOptions(ctx)...)
+	}
+	return grpcnats.BroadcastResponse(ctx, results, err)
+}
+
+func (b *_TestServiceGRPCBridge) FollowerOnlyBroadcastTestEmpty(ctx context.Context, req *Test) (*emptypb.Empty, error) {
+	var results []gonats.BroadcastAck
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.FollowerOnlyBroadcastTestEmptyTo(target, req, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.FollowerOnlyBroadcastTestEmpty(req, grpcnats.CallOptions(ctx)...)
+	}
+	if _, err := grpcnats.BroadcastResponse(ctx, results, err); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) FollowerOnlyBroadcastEmptyEmpty(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	var results []gonats.BroadcastAck
+	var err error
+	if target, ok := grpcnats.Target(ctx); ok {
+		results, err = b.client.FollowerOnlyBroadcastEmptyEmptyTo(target, grpcnats.CallOptions(ctx)...)
+	} else {
+		results, err = b.client.FollowerOnlyBroadcastEmptyEmpty(grpcnats.CallOptions(ctx)...)
+	}
+	if _, err := grpcnats.BroadcastResponse(ctx, results, err); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) ThreeSecondDelay(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := b.client.ThreeSecondDelay(grpcnats.CallOptions(ctx)...); err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) PooledDelay(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := b.client.PooledDelay(grpcnats.CallOptions(ctx)...); err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) RateLimited(ctx context.Context, req *Test) (*Test, error) {
+	resp, err := b.client.RateLimited(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) AdminOnly(ctx context.Context, req *Test) (*Test, error) {
+	resp, err := b.client.AdminOnly(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) Durable(ctx context.Context, req *Test) (*Test, error) {
+	call, err := b.client.Durable(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	resp, err := call.Wait(ctx)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) OneWay(ctx context.Context, req *Test) (*emptypb.Empty, error) {
+	if err := b.client.OneWay(req, grpcnats.CallOptions(ctx)...); err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return new(emptypb.Empty), nil
+}
+
+func (b *_TestServiceGRPCBridge) HTTPGet(ctx context.Context, req *HTTPTest) (*Test, error) {
+	resp, err := b.client.HTTPGet(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+func (b *_TestServiceGRPCBridge) HTTPPost(ctx context.Context, req *HTTPTest) (*Test, error) {
+	resp, err := b.client.HTTPPost(req, grpcnats.CallOptions(ctx)...)
+	if err != nil {
+		return nil, grpcnats.Status(err)
+	}
+	return resp, nil
+}
+
+//endregion
@@ -1,197 +1,2679 @@
 package test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/nats-io/jwt/v2"
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/nats-io/nkeys"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+	"xiam.li/go-protonats/gonats"
+	"xiam.li/go-protonats/gonats/grpcnats"
 	"xiam.li/protonats/go/protonats"
 )
 
+// must returns a function failing the test if a New...Server function returned an error, and the service otherwise.
+func must(t *testing.T) func(micro.Service, error) micro.Service {
+	return func(service micro.Service, err error) micro.Service {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Error creating server: %v", err)
+		}
+		return service
+	}
+}
+
 func TestInfo(t *testing.T) {
 	t.Parallel()
 	instance := newNATS(t)
 	t.Cleanup(instance.Stop)
 	var ids []string
 	for range 10 {
-		id := NewTestServiceNATSServer(instance.Conn, new(testImplementation), protonats.WithoutLeaderFns(), protonats.WithoutFollowerFns()).Info().ID
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+		ids = append(ids, id)
+	}
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	t.Run("WithFinisher", func(t *testing.T) {
+		now := time.Now()
+		info, err := cli.Info()
+		dur := time.Since(now)
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if dur > 300*time.Millisecond {
+			t.Fatalf("Info did not return within 500ms (%v)", dur)
+		}
+		if len(info) != 10 {
+			t.Fatalf("Expected 10 responses, got %d", len(info))
+		}
+	})
+
+	t.Run("WithoutFinisher", func(t *testing.T) {
+		now := time.Now()
+		info, err := cli.Info(protonats.WithoutFinisher())
+		dur := time.Since(now)
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if dur <= 5000*time.Millisecond {
+			t.Fatalf("Info returned under 4.5s (%v)", dur)
+		}
+		if len(info) != 10 {
+			t.Fatalf("Expected 10 responses, got %d", len(info))
+		}
+	})
+}
+
+func TestServiceConfig(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	srv := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns()))
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	info, err := cli.Info(protonats.WithInstanceID(srv.Info().ID))
+	if err != nil {
+		t.Fatalf("Error calling method: %v", err)
+	}
+	if len(info) != 1 {
+		t.Fatalf("Expected 1 response, got %d", len(info))
+	}
+	metadata := info[0].Metadata
+	if metadata["zone"] != "test" {
+		t.Fatalf("Expected zone metadata to be test, got %q", metadata["zone"])
+	}
+	if info[0].Version != "1.2.3" {
+		t.Fatalf("Expected version to be 1.2.3, got %q", info[0].Version)
+	}
+	if info[0].Description != "Service used for testing the generated code" {
+		t.Fatalf("Unexpected description: %q", info[0].Description)
+	}
+
+	var found int
+	for _, endpoint := range info[0].Endpoints {
+		if endpoint.Name != "NormalTestTest" && endpoint.Name != "NormalTestTest-Direct" {
+			continue
+		}
+		found++
+		if endpoint.Metadata["kind"] != "normal" {
+			t.Fatalf("Expected kind metadata on %s to be normal, got %q", endpoint.Name, endpoint.Metadata["kind"])
+		}
+		if endpoint.Metadata[gonats.EndpointDescriptionMetadata] != "Replies with the request and the instance ID" {
+			t.Fatalf("Unexpected description metadata on %s: %q", endpoint.Name, endpoint.Metadata[gonats.EndpointDescriptionMetadata])
+		}
+	}
+	if found != 2 {
+		t.Fatalf("Expected 2 NormalTestTest endpoints, got %d", found)
+	}
+
+	results, err := cli.NormalBroadcastEmptyEmptyTo(gonats.Version("1.2.3").WithMetadata("zone", "test"))
+	if err != nil {
+		t.Fatalf("Error calling method: %v", err)
+	}
+	if len(results) != 1 || results[0].InstanceID != srv.Info().ID {
+		t.Fatalf("Expected only an acknowledgement from %s, got %v", srv.Info().ID, results)
+	}
+}
+
+func TestReflect(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	set, err := cli.Reflect(protonats.WithInstanceID(id))
+	if err != nil {
+		t.Fatalf("Error calling method: %v", err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		t.Fatalf("Failed to resolve file descriptors: %v", err)
+	}
+	for _, path := range []string{"test.proto", "protonats.proto", "gonats.proto", "google/protobuf/empty.proto", "google/protobuf/descriptor.proto"} {
+		if _, err := files.FindFileByPath(path); err != nil {
+			t.Fatalf("File %s not found in the reflection response: %v", path, err)
+		}
+	}
+	desc, err := files.FindDescriptorByName("protonats.go.test.TestService")
+	if err != nil {
+		t.Fatalf("Service not found in the reflection response: %v", err)
+	}
+	if method := desc.(protoreflect.ServiceDescriptor).Methods().ByName("NormalTestTest"); method == nil || method.Input().FullName() != "protonats.go.test.Test" {
+		t.Fatalf("Unexpected NormalTestTest method in the reflection response: %v", method)
+	}
+}
+
+func TestNormal(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	var ids []string
+	for range 3 {
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+		ids = append(ids, id)
+	}
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	t.Run("TestTest", func(t *testing.T) {
+		t.Parallel()
+		resp, err := cli.NormalTestTest(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		re := regexp.MustCompile(`^server replying to Test Client from ([a-zA-Z0-9]+)$`)
+		matches := re.FindStringSubmatch(resp.Test)
+		if len(matches) != 2 {
+			t.Fatalf("Response format doesn't match: %v", resp.Test)
+		}
+		if !slices.Contains(ids, matches[1]) {
+			t.Fatalf("Server ID not found in the list of IDs: %v", matches[1])
+		}
+	})
+
+	t.Run("EmptyTest", func(t *testing.T) {
+		t.Parallel()
+		resp, err := cli.NormalEmptyTest()
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		re := regexp.MustCompile(`^server replying to empty from ([a-zA-Z0-9]+)$`)
+		matches := re.FindStringSubmatch(resp.Test)
+		if len(matches) != 2 {
+			t.Fatalf("Response format doesn't match: %v", resp.Test)
+		}
+		if !slices.Contains(ids, matches[1]) {
+			t.Fatalf("Server ID not found in the list of IDs: %v", matches[1])
+		}
+	})
+
+	t.Run("TestEmpty", func(t *testing.T) {
+		t.Parallel()
+		err := cli.NormalTestEmpty(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+	})
+
+	t.Run("EmptyEmpty", func(t *testing.T) {
+		t.Parallel()
+		err := cli.NormalEmptyEmpty()
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+	})
+}
+
+func TestNormalBroadcast(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	var ids []string
+	for range 3 {
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+		ids = append(ids, id)
+	}
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	t.Run("TestTest", func(t *testing.T) {
+		t.Parallel()
+		results, err := cli.NormalBroadcastTestTest(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 responses, got %d: %v", len(results), results)
+		}
+		for _, r := range results {
+			re := regexp.MustCompile(`^server replying to Test Client from ([a-zA-Z0-9]+)$`)
+			matches := re.FindStringSubmatch(r.Response.Test)
+			if len(matches) != 2 {
+				t.Fatalf("Response format doesn't match: %v", r.Response.Test)
+			}
+			if !slices.Contains(ids, matches[1]) {
+				t.Fatalf("Server ID not found in the list of IDs: %v", matches[1])
+			}
+			if r.InstanceID != matches[1] {
+				t.Fatalf("Instance ID %s doesn't match the responding server %s", r.InstanceID, matches[1])
+			}
+		}
+	})
+
+	t.Run("EmptyTest", func(t *testing.T) {
+		t.Parallel()
+		results, err := cli.NormalBroadcastEmptyTest()
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 responses, got %d: %v", len(results), results)
+		}
+		for _, r := range results {
+			re := regexp.MustCompile(`^server replying to empty from ([a-zA-Z0-9]+)$`)
+			matches := re.FindStringSubmatch(r.Response.Test)
+			if len(matches) != 2 {
+				t.Fatalf("Response format doesn't match: %v", r.Response.Test)
+			}
+			if !slices.Contains(ids, matches[1]) {
+				t.Fatalf("Server ID not found in the list of IDs: %v", matches[1])
+			}
+			if r.InstanceID != matches[1] {
+				t.Fatalf("Instance ID %s doesn't match the responding server %s", r.InstanceID, matches[1])
+			}
+		}
+	})
+
+	t.Run("TestEmpty", func(t *testing.T) {
+		t.Parallel()
+		results, err := cli.NormalBroadcastTestEmpty(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 acknowledgements, got %d: %v", len(results), results)
+		}
+	})
+
+	t.Run("EmptyEmpty", func(t *testing.T) {
+		t.Parallel()
+		results, err := cli.NormalBroadcastEmptyEmpty()
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 acknowledgements, got %d: %v", len(results), results)
+		}
+	})
+}
+
+func TestTargetedBroadcast(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	var ids []string
+	for range 3 {
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+		ids = append(ids, id)
+	}
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	t.Run("Instances", func(t *testing.T) {
+		t.Parallel()
+		results, err := cli.NormalBroadcastTestTestTo(gonats.Instances(ids[0], ids[2]), &Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 2 {
+			t.Fatalf("Expected 2 responses, got %d: %v", len(results), results)
+		}
+		for _, r := range results {
+			if r.InstanceID != ids[0] && r.InstanceID != ids[2] {
+				t.Fatalf("Response from instance %s which wasn't targeted", r.InstanceID)
+			}
+			if r.Response.Test != "server replying to Test Client from "+r.InstanceID {
+				t.Fatalf("Response format doesn't match: %v", r.Response.Test)
+			}
+		}
+	})
+
+	t.Run("EmptyEmpty", func(t *testing.T) {
+		t.Parallel()
+		results, err := cli.NormalBroadcastEmptyEmptyTo(gonats.Instances(ids[1]))
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if len(results) != 1 || results[0].InstanceID != ids[1] {
+			t.Fatalf("Expected only an acknowledgement from %s, got %v", ids[1], results)
+		}
+	})
+
+	t.Run("InstanceOption", func(t *testing.T) {
+		t.Parallel()
+		// The target is resolved among all instances, regardless of the instance ID passed for the broadcast
+		results, err := cli.NormalBroadcastEmptyEmptyTo(gonats.Version("1.2.3"), protonats.WithInstanceID(ids[0]))
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if len(results) != len(ids) {
+			t.Fatalf("Expected %d responses, got %d: %v", len(ids), len(results), results)
+		}
+	})
+
+	t.Run("NoInstances", func(t *testing.T) {
+		t.Parallel()
+		results, err := cli.NormalBroadcastTestTestTo(gonats.Metadata(map[string]string{"zone": "nowhere"}), &Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if len(results) != 0 {
+			t.Fatalf("Expected no responses, got %d: %v", len(results), results)
+		}
+	})
+}
+
+func TestCLI(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	var ids []string
+	for range 2 {
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
 		ids = append(ids, id)
 	}
-	cli := NewTestServiceNATSClient(instance.Conn)
+	client := NewTestServiceNATSClient(instance.Conn)
+
+	t.Run("Flags", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		if err := NewTestServiceNATSCLI(client, &out).Run([]string{"NormalTestTest", "-instance", ids[0], "-f.test", "Test CLI"}); err != nil {
+			t.Fatalf("Error running command: %v", err)
+		}
+		var resp Test
+		if err := protojson.Unmarshal(out.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode output %q: %v", out.String(), err)
+		}
+		if resp.Test != "server replying to Test CLI from "+ids[0] {
+			t.Fatalf("Unexpected response: %v", resp.Test)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		if err := NewTestServiceNATSCLI(client, &out).Run([]string{"NormalTestTest", "-json", `{"test": "Test JSON"}`}); err != nil {
+			t.Fatalf("Error running command: %v", err)
+		}
+		if !strings.Contains(out.String(), "server replying to Test JSON from ") {
+			t.Fatalf("Unexpected output: %v", out.String())
+		}
+	})
+
+	t.Run("Broadcast", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		if err := NewTestServiceNATSCLI(client, &out).Run([]string{"NormalBroadcastTestEmpty", "-f.test", "Test CLI"}); err != nil {
+			t.Fatalf("Error running command: %v", err)
+		}
+		for _, id := range ids {
+			if !strings.Contains(out.String(), "// Instance "+id) {
+				t.Fatalf("Missing result of instance %s in output: %v", id, out.String())
+			}
+		}
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		err := NewTestServiceNATSCLI(client, &out).Run([]string{"ErrServiceError"})
+		if !protonats.IsServiceError(err) {
+			t.Fatalf("Expected a service error, got %v", err)
+		}
+	})
+
+	t.Run("UnknownMethod", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		if err := NewTestServiceNATSCLI(client, &out).Run([]string{"Unknown"}); err == nil {
+			t.Fatal("Expected an error for an unknown method")
+		}
+		if !strings.Contains(out.String(), "NormalTestTest") {
+			t.Fatalf("Usage doesn't list the methods: %v", out.String())
+		}
+	})
+}
+
+func TestHTTPGateway(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	var ids []string
+	for range 3 {
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+		ids = append(ids, id)
+	}
+	gateway := httptest.NewServer(NewTestServiceNATSHTTPHandler(NewTestServiceNATSClient(instance.Conn), gonats.WithHTTPMaxBodySize(64)))
+	t.Cleanup(gateway.Close)
+	selecting := httptest.NewServer(NewTestServiceNATSHTTPHandler(NewTestServiceNATSClient(instance.Conn), gonats.WithHTTPInstanceSelection()))
+	t.Cleanup(selecting.Close)
+
+	send := func(t *testing.T, url, body, instance string) (*http.Response, []byte) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Error creating request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if instance != "" {
+			req.Header.Set(gonats.InstanceHeader, instance)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Error calling gateway: %v", err)
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Error reading response: %v", err)
+		}
+		return resp, data
+	}
+	post := func(t *testing.T, path, body string) (*http.Response, []byte) {
+		t.Helper()
+		return send(t, gateway.URL+path, body, "")
+	}
+
+	t.Run("Normal", func(t *testing.T) {
+		t.Parallel()
+		resp, data := post(t, "/TestService/NormalTestTest", `{"test": "Test HTTP"}`)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, data)
+		}
+		var out Test
+		if err := protojson.Unmarshal(data, &out); err != nil {
+			t.Fatalf("Failed to decode response %s: %v", data, err)
+		}
+		if !strings.HasPrefix(out.Test, "server replying to Test HTTP from ") {
+			t.Fatalf("Unexpected response: %v", out.Test)
+		}
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		t.Parallel()
+		resp, data := post(t, "/TestService/NormalTestTest", `{"unknown": true}`)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d: %s", resp.StatusCode, data)
+		}
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		t.Parallel()
+		resp, data := post(t, "/TestService/NormalTestTest", `{"test": "`+strings.Repeat("a", 64)+`"}`)
+		if resp.StatusCode != http.StatusRequestEntityTooLarge {
+			t.Fatalf("Expected status 413, got %d: %s", resp.StatusCode, data)
+		}
+	})
+
+	t.Run("InstanceSelection", func(t *testing.T) {
+		t.Parallel()
+		for _, id := range ids {
+			resp, data := send(t, selecting.URL+"/TestService/NormalTestTest", `{"test": "Test HTTP"}`, id)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, data)
+			}
+			var out Test
+			if err := protojson.Unmarshal(data, &out); err != nil {
+				t.Fatalf("Failed to decode response %s: %v", data, err)
+			}
+			if out.Test != "server replying to Test HTTP from "+id {
+				t.Fatalf("Unexpected response: %v", out.Test)
+			}
+		}
+	})
+
+	t.Run("InstanceSelectionDisabled", func(t *testing.T) {
+		t.Parallel()
+		resp, data := send(t, gateway.URL+"/TestService/NormalTestTest", `{"test": "Test HTTP"}`, "unknown")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected the instance header to be ignored, got %d: %s", resp.StatusCode, data)
+		}
+	})
+
+	t.Run("ServerError", func(t *testing.T) {
+		t.Parallel()
+		resp, data := post(t, "/TestService/ErrServerError", `{}`)
+		if resp.StatusCode != http.StatusBadGateway {
+			t.Fatalf("Expected status 502 for a non HTTP error code, got %d: %s", resp.StatusCode, data)
+		}
+		if !strings.Contains(string(data), `"code":"1337"`) {
+			t.Fatalf("Error code missing in response: %s", data)
+		}
+	})
+
+	t.Run("Broadcast", func(t *testing.T) {
+		t.Parallel()
+		resp, data := post(t, "/TestService/NormalBroadcastTestTest", `{"test": "Test HTTP"}`)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, data)
+		}
+		var results []map[string]any
+		if err := json.Unmarshal(data, &results); err != nil {
+			t.Fatalf("Failed to decode response %s: %v", data, err)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 results, got %d: %s", len(results), data)
+		}
+	})
+
+	t.Run("GetBinding", func(t *testing.T) {
+		t.Parallel()
+		resp, err := http.Get(gateway.URL + "/v1/tests/abc?filter=recent")
+		if err != nil {
+			t.Fatalf("Error calling gateway: %v", err)
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Error reading response: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, data)
+		}
+		var out Test
+		if err := protojson.Unmarshal(data, &out); err != nil {
+			t.Fatalf("Failed to decode response %s: %v", data, err)
+		}
+		if !strings.HasPrefix(out.Test, `server replying to abc with filter "recent" from `) {
+			t.Fatalf("Unexpected response: %v", out.Test)
+		}
+	})
+
+	t.Run("PostFieldBody", func(t *testing.T) {
+		t.Parallel()
+		resp, data := post(t, "/v1/tests/abc", `{"test": "Test HTTP"}`)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, data)
+		}
+		var out Test
+		if err := protojson.Unmarshal(data, &out); err != nil {
+			t.Fatalf("Failed to decode response %s: %v", data, err)
+		}
+		if !strings.HasPrefix(out.Test, "server replying to abc with Test HTTP from ") {
+			t.Fatalf("Unexpected response: %v", out.Test)
+		}
+
+		// The default route isn't served for methods with google.api.http rules
+		resp, _ = post(t, "/TestService/HTTPPost", `{"id": "abc"}`)
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("Expected status 404, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("UnknownMethod", func(t *testing.T) {
+		t.Parallel()
+		resp, _ := post(t, "/TestService/Unknown", `{}`)
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("Expected status 404, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestGRPCAdapter(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	impl := new(grpcImplementation)
+	id := must(t)(NewTestServiceNATSServerFromGRPC(instance.Conn, impl, gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	t.Run("Normal", func(t *testing.T) {
+		t.Parallel()
+		resp, err := cli.NormalTestTest(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if resp.Test != "grpc server replying to Test Client from "+id {
+			t.Fatalf("Unexpected response: %v", resp.Test)
+		}
+	})
+
+	t.Run("Metadata", func(t *testing.T) {
+		t.Parallel()
+		data, err := proto.Marshal(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		msg := nats.NewMsg("service.TestService.NormalTestTest")
+		msg.Data = data
+		msg.Header.Set("X-Caller", "tester")
+		reply, err := instance.Conn.RequestMsg(msg, time.Second)
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		var resp Test
+		if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if resp.Test != "grpc server replying to Test Client for tester from "+id {
+			t.Fatalf("Unexpected response: %v", resp.Test)
+		}
+		if served := reply.Header.Get("x-served-by"); served != id {
+			t.Fatalf("Expected x-served-by header %s, got %q", id, served)
+		}
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		t.Parallel()
+		if err := cli.ThreeSecondDelay(protonats.WithTimeout(500 * time.Millisecond)); !errors.Is(err, nats.ErrTimeout) {
+			t.Fatalf("Expected timeout error, got: %v", err)
+		}
+		deadline := time.Now().Add(time.Second)
+		for !impl.canceled.Load() {
+			if time.Now().After(deadline) {
+				t.Fatal("Expected the context of the implementation to end with the timeout of the client")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	t.Run("StatusError", func(t *testing.T) {
+		t.Parallel()
+		_, err := cli.ErrServiceError(&Test{Test: "Test Client"})
+		serviceErr, ok := protonats.AsServiceError(err)
+		if !ok {
+			t.Fatalf("Expected a service error, got %v", err)
+		}
+		if serviceErr.Code != "404" || serviceErr.Description != "This is a gRPC error" {
+			t.Fatalf("Unexpected service error: %v", serviceErr)
+		}
+	})
+
+	t.Run("Unimplemented", func(t *testing.T) {
+		t.Parallel()
+		_, err := cli.NormalEmptyTest()
+		serviceErr, ok := protonats.AsServiceError(err)
+		if !ok {
+			t.Fatalf("Expected a service error, got %v", err)
+		}
+		if serviceErr.Code != "501" {
+			t.Fatalf("Expected code 501, got %v", serviceErr)
+		}
+	})
+}
+
+func TestGRPCBridge(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	var ids []string
+	for range 3 {
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+		ids = append(ids, id)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterTestServiceServer(server, NewTestServiceGRPCBridge(NewTestServiceNATSClient(instance.Conn)))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create gRPC client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	cli := NewTestServiceClient(conn)
+
+	t.Run("Normal", func(t *testing.T) {
+		t.Parallel()
+		resp, err := cli.NormalTestTest(context.Background(), &Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if !strings.HasPrefix(resp.Test, "server replying to Test Client from ") {
+			t.Fatalf("Unexpected response: %v", resp.Test)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+		if _, err := cli.NormalEmptyEmpty(context.Background(), &emptypb.Empty{}); err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+	})
+
+	t.Run("Instance", func(t *testing.T) {
+		t.Parallel()
+		ctx := metadata.AppendToOutgoingContext(context.Background(), grpcnats.InstanceMetadata, ids[1])
+		resp, err := cli.NormalTestTest(ctx, &Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if resp.Test != "server replying to Test Client from "+ids[1] {
+			t.Fatalf("Unexpected response: %v", resp.Test)
+		}
+	})
+
+	t.Run("Broadcast", func(t *testing.T) {
+		t.Parallel()
+		var header metadata.MD
+		resp, err := cli.NormalBroadcastTestTest(context.Background(), &Test{Test: "Test Client"}, grpc.Header(&header))
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if !strings.HasPrefix(resp.Test, "server replying to Test Client from ") {
+			t.Fatalf("Unexpected response: %v", resp.Test)
+		}
+		responded := header.Get(grpcnats.InstanceMetadata)
+		slices.Sort(responded)
+		expected := slices.Clone(ids)
+		slices.Sort(expected)
+		if !slices.Equal(responded, expected) {
+			t.Fatalf("Expected responses from %v, got %v", expected, responded)
+		}
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		t.Parallel()
+		_, err := cli.ErrServiceError(context.Background(), &Test{Test: "Test Client"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("Expected a status error, got %v", err)
+		}
+		// Generated servers respond to ServiceErrors returned by implementations with an internal server error
+		if st.Code() != codes.Internal || st.Message() != "Internal server error" {
+			t.Fatalf("Unexpected status: %v", st)
+		}
+	})
+
+	t.Run("UnknownInstance", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		ctx = metadata.AppendToOutgoingContext(ctx, grpcnats.InstanceMetadata, "unknown")
+		_, err := cli.NormalTestTest(ctx, &Test{Test: "Test Client"})
+		if code := status.Code(err); code != codes.Unavailable && code != codes.DeadlineExceeded {
+			t.Fatalf("Expected code Unavailable or DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+func TestUnimplemented(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	must(t)(NewTestServiceNATSServer(instance.Conn, new(partialImplementation)))
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	t.Run("Implemented", func(t *testing.T) {
+		t.Parallel()
+		resp, err := cli.NormalTestTest(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if resp.Test != "partial server replying to Test Client" {
+			t.Fatalf("Unexpected response: %v", resp.Test)
+		}
+	})
+
+	for name, call := range map[string]func() error{
+		"Normal": func() error {
+			_, err := cli.NormalEmptyTest()
+			return err
+		},
+		"Empty": func() error {
+			return cli.NormalEmptyEmpty()
+		},
+		"Leader": func() error {
+			_, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"})
+			return err
+		},
+		"Follower": func() error {
+			return cli.FollowerOnlyTestEmpty(&Test{Test: "Test Client"})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			serviceErr, ok := protonats.AsServiceError(call())
+			if !ok {
+				t.Fatalf("Expected a service error")
+			}
+			if serviceErr.Code != gonats.UnimplementedCode {
+				t.Fatalf("Expected code %s, got %v", gonats.UnimplementedCode, serviceErr)
+			}
+		})
+	}
+}
+
+func TestServerError(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+
+	// Failing to add the endpoints returns the error instead of panicking
+	service, err := NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithExtraSubject("invalid subject"))
+	if err == nil || service != nil {
+		t.Fatalf("Expected an error creating the server, got %v", service)
+	}
+	if _, err := NewTestServiceNATSServerFromGRPC(instance.Conn, new(grpcImplementation), gonats.WithExtraSubject("invalid subject")); err == nil {
+		t.Fatal("Expected an error creating the gRPC server")
+	}
+
+	// The services were stopped and don't respond anymore
+	if _, err := NewTestServiceNATSClient(instance.Conn).NormalTestTest(&Test{Test: "Test Client"}, protonats.WithTimeout(200*time.Millisecond)); err == nil {
+		t.Fatal("Expected no service to respond")
+	}
+}
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	impl := &hooksImplementation{done: make(chan struct{})}
+	srv := must(t)(NewTestServiceNATSServer(instance.Conn, impl, gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns()))
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	stats, err := cli.Stats(protonats.WithInstanceID(srv.Info().ID))
+	if err != nil {
+		t.Fatalf("Error getting stats: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("Expected stats of 1 instance, got %d", len(stats))
+	}
+	for _, endpoint := range stats[0].Endpoints {
+		// The role endpoints report the role of the instance instead of the stats of the implementation
+		if endpoint.Name == gonats.RoleEndpoint || endpoint.Name == gonats.RoleEndpoint+"-Direct" {
+			continue
+		}
+		var data map[string]string
+		if err := json.Unmarshal(endpoint.Data, &data); err != nil {
+			t.Fatalf("Failed to unmarshal stats data of %s: %v", endpoint.Name, err)
+		}
+		if data["endpoint"] != endpoint.Name {
+			t.Fatalf("Unexpected stats data of %s: %v", endpoint.Name, data)
+		}
+	}
+	requests, err := gonats.InFlightRequests(srv)
+	if err != nil {
+		t.Fatalf("Error getting in-flight requests: %v", err)
+	}
+	if n, ok := requests["NormalTestTest"]; !ok || n != 0 {
+		t.Fatalf("Expected no in-flight requests of NormalTestTest, got %v", requests)
+	}
+
+	if err := srv.Stop(); err != nil {
+		t.Fatalf("Error stopping service: %v", err)
+	}
+	select {
+	case <-impl.done:
+	case <-time.After(time.Second):
+		t.Fatalf("Done was not called after stopping the service")
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	// inFlight returns the in-flight requests of the direct NormalTestTest endpoint of the instance id
+	inFlight := func(id string) int64 {
+		stats, err := cli.Stats(protonats.WithInstanceID(id))
+		if err != nil {
+			t.Fatalf("Error getting stats: %v", err)
+		}
+		for _, endpoint := range stats[0].Endpoints {
+			if endpoint.Name != "NormalTestTest-Direct" {
+				continue
+			}
+			var data gonats.EndpointStatsData
+			if err := json.Unmarshal(endpoint.Data, &data); err != nil {
+				t.Fatalf("Failed to unmarshal stats data: %v", err)
+			}
+			return data.InFlight
+		}
+		t.Fatalf("Endpoint NormalTestTest-Direct not found in stats")
+		return 0
+	}
+
+	t.Run("Drain", func(t *testing.T) {
+		t.Parallel()
+		impl := &blockingImplementation{started: make(chan struct{}), release: make(chan struct{})}
+		srv := must(t)(NewTestServiceNATSServer(instance.Conn, impl, gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns()))
+		id := srv.Info().ID
+		must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns()))
+
+		result := make(chan error)
+		go func() {
+			_, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id), protonats.WithTimeout(5*time.Second))
+			result <- err
+		}()
+		<-impl.started
+		if n := inFlight(id); n != 1 {
+			t.Fatalf("Expected 1 request in flight, got %d", n)
+		}
+
+		shutdown := make(chan error)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			shutdown <- gonats.Shutdown(ctx, srv)
+		}()
+
+		// The subscriptions are drained once the shutdown started, so new requests are handled by the other instances
+		deadline := time.Now().Add(time.Second)
+		for !srv.Stopped() {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected the endpoints to be drained")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		for range 10 {
+			resp, err := cli.NormalEmptyTest()
+			if err != nil {
+				t.Fatalf("Expected new requests to be handled by another instance, got %v", err)
+			}
+			if strings.HasSuffix(resp.Test, id) {
+				t.Fatalf("Request handled by the instance shutting down: %v", resp.Test)
+			}
+		}
+		// The server removes the interest of the drained subscriptions asynchronously
+		for {
+			_, err := cli.NormalEmptyTest(protonats.WithInstanceID(id))
+			if errors.Is(err, nats.ErrNoResponders) {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected no responders for the direct endpoint, got %v", err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		select {
+		case err := <-shutdown:
+			t.Fatalf("Shutdown returned before the in-flight request finished: %v", err)
+		default:
+		}
+
+		close(impl.release)
+		if err := <-result; err != nil {
+			t.Fatalf("In-flight request failed: %v", err)
+		}
+		if err := <-shutdown; err != nil {
+			t.Fatalf("Error shutting down: %v", err)
+		}
+		if !srv.Stopped() {
+			t.Fatalf("Service not stopped after shutdown")
+		}
+	})
+
+	t.Run("Queued", func(t *testing.T) {
+		t.Parallel()
+		impl := &queuedImplementation{started: make(chan struct{}, 4), release: make(chan struct{})}
+		srv := must(t)(NewTestServiceNATSServer(instance.Conn, impl, gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns()))
+		id := srv.Info().ID
+
+		// Without a worker pool, the requests following the first one wait in the subscription until it is handled
+		results := make(chan error, 4)
+		for range 4 {
+			go func() {
+				_, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id), protonats.WithTimeout(5*time.Second))
+				results <- err
+			}()
+		}
+		<-impl.started
+		time.Sleep(100 * time.Millisecond)
+
+		shutdown := make(chan error)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			shutdown <- gonats.Shutdown(ctx, srv)
+		}()
+		time.Sleep(100 * time.Millisecond)
+		close(impl.release)
+		for range 4 {
+			if err := <-results; err != nil {
+				t.Fatalf("Queued request failed: %v", err)
+			}
+		}
+		if err := <-shutdown; err != nil {
+			t.Fatalf("Error shutting down: %v", err)
+		}
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		t.Parallel()
+		impl := &blockingImplementation{started: make(chan struct{}), release: make(chan struct{})}
+		t.Cleanup(func() {
+			close(impl.release)
+		})
+		srv := must(t)(NewTestServiceNATSServer(instance.Conn, impl, gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns()))
+		go func() {
+			_, _ = cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(srv.Info().ID), protonats.WithTimeout(5*time.Second))
+		}()
+		<-impl.started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		if err := gonats.Shutdown(ctx, srv); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected deadline exceeded, got %v", err)
+		}
+		if !srv.Stopped() {
+			t.Fatalf("Service not stopped after shutdown")
+		}
+	})
+
+	t.Run("NotTracked", func(t *testing.T) {
+		t.Parallel()
+		srv, err := micro.AddService(instance.Conn, micro.Config{Name: "Untracked", Version: "0.0.1"})
+		if err != nil {
+			t.Fatalf("Error adding service: %v", err)
+		}
+		t.Cleanup(func() {
+			_ = srv.Stop()
+		})
+		if err := gonats.Shutdown(context.Background(), srv); !errors.Is(err, gonats.ErrNotTracked) {
+			t.Fatalf("Expected ErrNotTracked, got %v", err)
+		}
+		if _, err := gonats.InFlightRequests(srv); !errors.Is(err, gonats.ErrNotTracked) {
+			t.Fatalf("Expected ErrNotTracked, got %v", err)
+		}
+	})
+}
+
+func TestWorkerPool(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	// callConcurrently calls fn n times concurrently and returns the number of successful and overloaded calls
+	callConcurrently := func(t *testing.T, n int, fn func() error) (succeeded, overloaded int) {
+		errs := make(chan error, n)
+		for range n {
+			go func() {
+				errs <- fn()
+			}()
+		}
+		for range n {
+			err := <-errs
+			if err == nil {
+				succeeded++
+				continue
+			}
+			if serviceErr, ok := protonats.AsServiceError(err); ok && serviceErr.Code == gonats.OverloadedCode {
+				// The non standard code is translated for gRPC and HTTP
+				if code := status.Code(grpcnats.Status(err)); code != codes.ResourceExhausted {
+					t.Errorf("Expected gRPC code %v, got %v", codes.ResourceExhausted, code)
+				}
+				if code := gonats.HTTPStatus(serviceErr.Code); code != http.StatusServiceUnavailable {
+					t.Errorf("Expected HTTP status %d, got %d", http.StatusServiceUnavailable, code)
+				}
+				overloaded++
+				continue
+			}
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return succeeded, overloaded
+	}
+
+	t.Run("ServerOption", func(t *testing.T) {
+		t.Parallel()
+		impl := &delayImplementation{delay: 300 * time.Millisecond}
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, impl, gonats.WithWorkerPool(2, 0), protonats.WithoutLeaderFns(), protonats.WithoutFollowerFns())).Info().ID
+
+		now := time.Now()
+		succeeded, overloaded := callConcurrently(t, 3, func() error {
+			_, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id))
+			return err
+		})
+		if succeeded != 2 || overloaded != 1 {
+			t.Fatalf("Expected 2 succeeded and 1 overloaded call, got %d and %d", succeeded, overloaded)
+		}
+		// Both succeeded calls ran concurrently
+		if dur := time.Since(now); dur >= 600*time.Millisecond {
+			t.Fatalf("Expected calls to run concurrently, took %v", dur)
+		}
+	})
+
+	t.Run("MethodOption", func(t *testing.T) {
+		t.Parallel()
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+
+		// 2 workers and a queue of 1
+		succeeded, overloaded := callConcurrently(t, 4, func() error {
+			return cli.PooledDelay(protonats.WithInstanceID(id), protonats.WithTimeout(5*time.Second))
+		})
+		if succeeded != 3 || overloaded != 1 {
+			t.Fatalf("Expected 3 succeeded and 1 overloaded call, got %d and %d", succeeded, overloaded)
+		}
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	// expectRateLimited fails if err isn't a rate limited error with a retry after duration
+	expectRateLimited := func(t *testing.T, err error) {
+		serviceErr, ok := protonats.AsServiceError(err)
+		if !ok {
+			t.Fatalf("Expected a service error, got %v", err)
+		}
+		if serviceErr.Code != gonats.RateLimitedCode {
+			t.Fatalf("Expected code %s, got %v", gonats.RateLimitedCode, serviceErr)
+		}
+		if retryAfter, ok := gonats.RetryAfter(err); !ok || retryAfter <= 0 {
+			t.Fatalf("Expected a retry after duration, got %v", retryAfter)
+		}
+	}
+
+	t.Run("MethodOption", func(t *testing.T) {
+		t.Parallel()
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+
+		// The burst of 2 is shared by all endpoints of the method
+		if _, err := cli.RateLimited(&Test{Test: "Test Client"}, protonats.WithInstanceID(id)); err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if _, err := cli.RateLimited(&Test{Test: "Test Client"}, protonats.WithInstanceID(id)); err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		_, err := cli.RateLimited(&Test{Test: "Test Client"}, protonats.WithInstanceID(id))
+		expectRateLimited(t, err)
+
+		// Other methods aren't limited
+		if _, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id)); err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+	})
+
+	t.Run("ServerOption", func(t *testing.T) {
+		t.Parallel()
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRateLimit("NormalTestTest", 2, 1), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+
+		if _, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id)); err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		_, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id))
+		expectRateLimited(t, err)
+
+		// A token is added every 500ms
+		retryAfter, _ := gonats.RetryAfter(err)
+		time.Sleep(retryAfter)
+		if _, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id)); err != nil {
+			t.Fatalf("Error calling method after %v: %v", retryAfter, err)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRateLimit("RateLimited", 0, 0), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+
+		for range 5 {
+			if _, err := cli.RateLimited(&Test{Test: "Test Client"}, protonats.WithInstanceID(id)); err != nil {
+				t.Fatalf("Error calling method: %v", err)
+			}
+		}
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	trusted, err := nkeys.CreateAccount()
+	if err != nil {
+		t.Fatalf("Failed to create account key: %v", err)
+	}
+	trustedKey, err := trusted.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get account public key: %v", err)
+	}
+	var authorized []string
+	var mu sync.Mutex
+	id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithAuthorizer(func(a gonats.Authorization) error {
+		mu.Lock()
+		authorized = append(authorized, a.Method)
+		mu.Unlock()
+		return gonats.TagAuthorizer(a)
+	}), gonats.WithTrustedIssuers(trustedKey), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+	cli := NewTestServiceNATSClient(instance.Conn)
+
+	// userJWT returns a user JWT with the given tags issued by account, and the key of the user
+	userJWT := func(t *testing.T, account nkeys.KeyPair, tags ...string) (string, nkeys.KeyPair) {
+		user, err := nkeys.CreateUser()
+		if err != nil {
+			t.Fatalf("Failed to create user key: %v", err)
+		}
+		pub, err := user.PublicKey()
+		if err != nil {
+			t.Fatalf("Failed to get user public key: %v", err)
+		}
+		claims := jwt.NewUserClaims(pub)
+		claims.Tags.Add(tags...)
+		token, err := claims.Encode(account)
+		if err != nil {
+			t.Fatalf("Failed to encode user claims: %v", err)
+		}
+		return token, user
+	}
+
+	// callAdminOnly calls AdminOnly with token in the JWTHeader, if it isn't empty, and signed by signer, unless it is nil
+	callAdminOnly := func(t *testing.T, token string, signer nkeys.KeyPair, header nats.Header) *nats.Msg {
+		data, err := proto.Marshal(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		msg := nats.NewMsg("service.TestService.AdminOnly." + id)
+		msg.Data = data
+		for key, values := range header {
+			msg.Header[key] = values
+		}
+		if token != "" {
+			msg.Header.Set(gonats.JWTHeader, token)
+		}
+		if signer != nil {
+			if err := gonats.SignRequest(msg, signer); err != nil {
+				t.Fatalf("Failed to sign request: %v", err)
+			}
+		}
+		reply, err := instance.Conn.RequestMsg(msg, time.Second)
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		return reply
+	}
+
+	// expectForbidden fails unless reply is a forbidden error
+	expectForbidden := func(t *testing.T, reply *nats.Msg) {
+		if code := reply.Header.Get(micro.ErrorCodeHeader); code != gonats.ForbiddenCode {
+			t.Fatalf("Expected code %s, got %q", gonats.ForbiddenCode, code)
+		}
+	}
+
+	t.Run("Allowed", func(t *testing.T) {
+		t.Parallel()
+		token, user := userJWT(t, trusted, "admin")
+		reply := callAdminOnly(t, token, user, nil)
+		if code := reply.Header.Get(micro.ErrorCodeHeader); code != "" {
+			t.Fatalf("Unexpected error %s: %s", code, reply.Header.Get(micro.ErrorHeader))
+		}
+	})
+
+	t.Run("MissingRole", func(t *testing.T) {
+		t.Parallel()
+		token, user := userJWT(t, trusted, "user")
+		expectForbidden(t, callAdminOnly(t, token, user, nil))
+	})
+
+	t.Run("UntrustedAccount", func(t *testing.T) {
+		t.Parallel()
+		account, err := nkeys.CreateAccount()
+		if err != nil {
+			t.Fatalf("Failed to create account key: %v", err)
+		}
+		token, user := userJWT(t, account, "admin")
+		expectForbidden(t, callAdminOnly(t, token, user, nil))
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		t.Parallel()
+		token, _ := userJWT(t, trusted, "admin")
+		expectForbidden(t, callAdminOnly(t, token, nil, nil))
+	})
+
+	t.Run("OtherSigner", func(t *testing.T) {
+		t.Parallel()
+		token, _ := userJWT(t, trusted, "admin")
+		other, err := nkeys.CreateUser()
+		if err != nil {
+			t.Fatalf("Failed to create user key: %v", err)
+		}
+		expectForbidden(t, callAdminOnly(t, token, other, nil))
+	})
+
+	t.Run("RequestInfo", func(t *testing.T) {
+		t.Parallel()
+		header := nats.Header{gonats.RequestInfoHeader: []string{`{"acc":"ACCOUNT","tags":["admin"]}`}}
+		expectForbidden(t, callAdminOnly(t, "", nil, header))
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		t.Parallel()
+		_, err := cli.AdminOnly(&Test{Test: "Test Client"}, protonats.WithInstanceID(id))
+		serviceErr, ok := protonats.AsServiceError(err)
+		if !ok {
+			t.Fatalf("Expected a service error, got %v", err)
+		}
+		if serviceErr.Code != gonats.ForbiddenCode {
+			t.Fatalf("Expected code %s, got %v", gonats.ForbiddenCode, serviceErr)
+		}
+	})
+
+	t.Run("NoRoles", func(t *testing.T) {
+		t.Parallel()
+		if _, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id)); err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if !slices.Contains(authorized, "NormalTestTest") {
+			t.Fatalf("Authorizer not called for NormalTestTest, only for %v", authorized)
+		}
+	})
+}
+
+func TestSigning(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	trusted, err := nkeys.CreateUser()
+	if err != nil {
+		t.Fatalf("Failed to create user key: %v", err)
+	}
+	trustedKey, err := trusted.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+	signers := make(chan string, 1)
+	id := must(t)(NewTestServiceNATSServer(instance.Conn, new(signerImplementation), gonats.WithTrustedSigners(trustedKey), gonats.WithAuthorizer(func(a gonats.Authorization) error {
+		if a.Method == "NormalEmptyTest" {
+			signers <- a.Caller.Signer
+		}
+		return nil
+	}), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+
+	// expectUnauthenticated fails if err isn't an unauthenticated error
+	expectUnauthenticated := func(t *testing.T, err error) {
+		serviceErr, ok := protonats.AsServiceError(err)
+		if !ok {
+			t.Fatalf("Expected a service error, got %v", err)
+		}
+		if serviceErr.Code != gonats.UnauthenticatedCode {
+			t.Fatalf("Expected code %s, got %v", gonats.UnauthenticatedCode, serviceErr)
+		}
+	}
+
+	t.Run("Trusted", func(t *testing.T) {
+		t.Parallel()
+		cli := NewTestServiceNATSClient(instance.Conn)
+		cli.SetSigner(trusted)
+		if _, err := cli.NormalEmptyTest(protonats.WithInstanceID(id)); err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if signer := <-signers; signer != trustedKey {
+			t.Fatalf("Expected signer %s, got %q", trustedKey, signer)
+		}
+		resp, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id))
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if resp.Test != trustedKey {
+			t.Fatalf("Expected the handler to get signer %s, got %q", trustedKey, resp.Test)
+		}
+		results, err := cli.NormalBroadcastTestTestTo(gonats.Instances(id), &Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if errs := gonats.Errors(results); len(errs) > 0 {
+			t.Fatalf("Unexpected errors: %v", errs)
+		}
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewTestServiceNATSClient(instance.Conn).NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id))
+		expectUnauthenticated(t, err)
+	})
+
+	t.Run("Encrypted", func(t *testing.T) {
+		t.Parallel()
+		xkey, err := nkeys.CreateCurveKeys()
+		if err != nil {
+			t.Fatalf("Failed to create xkey: %v", err)
+		}
+		encrypted := must(t)(NewTestServiceNATSServer(instance.Conn, new(signerImplementation), gonats.WithTrustedSigners(trustedKey), gonats.WithEncryption(xkey),
+			gonats.WithExtraSubject("encrypted"), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns()))
+		t.Cleanup(func() {
+			_ = encrypted.Stop()
+		})
+		cli := NewTestServiceNATSClient(instance.Conn)
+		cli.SetSigner(trusted)
+		if err := cli.EnableEncryption(protonats.WithInstanceID(encrypted.Info().ID)); err != nil {
+			t.Fatalf("Failed to enable encryption: %v", err)
+		}
+		resp, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(encrypted.Info().ID), protonats.WithExtraSubject("encrypted"))
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if resp.Test != trustedKey {
+			t.Fatalf("Expected the handler to get signer %s, got %q", trustedKey, resp.Test)
+		}
+	})
+
+	t.Run("Untrusted", func(t *testing.T) {
+		t.Parallel()
+		untrusted, err := nkeys.CreateUser()
+		if err != nil {
+			t.Fatalf("Failed to create user key: %v", err)
+		}
+		cli := NewTestServiceNATSClient(instance.Conn)
+		cli.SetSigner(untrusted)
+		_, err = cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id))
+		expectUnauthenticated(t, err)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		t.Parallel()
+		data, err := proto.Marshal(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		msg, err := gonats.NewRequest(instance.Conn, "service.TestService.NormalTestTest."+id, data, trusted, nil)
+		if err != nil {
+			t.Fatalf("Failed to sign request: %v", err)
+		}
+		msg.Data = append(msg.Data, 0)
+		reply, err := instance.Conn.RequestMsg(msg, time.Second)
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if code := reply.Header.Get(micro.ErrorCodeHeader); code != gonats.UnauthenticatedCode {
+			t.Fatalf("Expected code %s, got %q", gonats.UnauthenticatedCode, code)
+		}
+	})
+}
+
+func TestEncryption(t *testing.T) {
+	t.Parallel()
+	instance := newNATS(t)
+	t.Cleanup(instance.Stop)
+	xkey, err := nkeys.CreateCurveKeys()
+	if err != nil {
+		t.Fatalf("Failed to create curve key: %v", err)
+	}
+	pub, err := xkey.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+	signer, err := nkeys.CreateUser()
+	if err != nil {
+		t.Fatalf("Failed to create user key: %v", err)
+	}
+	signerKey, err := signer.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+	// The authorizer only accepts decrypted requests
+	authorizer := gonats.WithAuthorizer(func(a gonats.Authorization) error {
+		if a.Method != "NormalTestTest" {
+			return nil
+		}
+		var req Test
+		if err := proto.Unmarshal(a.Request.Data(), &req); err != nil || req.Test != "Test Client" {
+			return errors.New("request is not decrypted")
+		}
+		return nil
+	})
+	id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithEncryption(xkey), gonats.WithTrustedSigners(signerKey), authorizer, gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns())).Info().ID
+
+	t.Run("Encrypted", func(t *testing.T) {
+		t.Parallel()
+		cli := NewTestServiceNATSClient(instance.Conn)
+		cli.SetSigner(signer)
+		if err := cli.EnableEncryption(); err != nil {
+			t.Fatalf("Failed to enable encryption: %v", err)
+		}
+		resp, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id))
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if expected := "server replying to Test Client from " + id; resp.Test != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.Test)
+		}
+		results, err := cli.NormalBroadcastTestTestTo(gonats.Instances(id), &Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if errs := gonats.Errors(results); len(errs) > 0 {
+			t.Fatalf("Unexpected errors: %v", errs)
+		}
+	})
+
+	t.Run("Advertised", func(t *testing.T) {
+		t.Parallel()
+		infos, err := NewTestServiceNATSClient(instance.Conn).Info(protonats.WithInstanceID(id))
+		if err != nil {
+			t.Fatalf("Error getting info: %v", err)
+		}
+		if advertised, err := gonats.ServiceXKey(infos); err != nil || advertised != pub {
+			t.Fatalf("Expected xkey %s, got %q (%v)", pub, advertised, err)
+		}
+	})
+
+	t.Run("Plaintext", func(t *testing.T) {
+		t.Parallel()
+		cli := NewTestServiceNATSClient(instance.Conn)
+		cli.SetSigner(signer)
+		_, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(id))
+		serviceErr, ok := protonats.AsServiceError(err)
+		if !ok {
+			t.Fatalf("Expected a service error, got %v", err)
+		}
+		if serviceErr.Code != gonats.EncryptionRequiredCode {
+			t.Fatalf("Expected code %s, got %v", gonats.EncryptionRequiredCode, serviceErr)
+		}
+	})
+
+	t.Run("Ciphertext", func(t *testing.T) {
+		t.Parallel()
+		data, err := proto.Marshal(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		sealer, err := gonats.NewSealer(pub)
+		if err != nil {
+			t.Fatalf("Failed to create sealer: %v", err)
+		}
+		msg, err := gonats.NewRequest(instance.Conn, "service.TestService.NormalTestTest."+id, data, signer, sealer)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		if bytes.Equal(msg.Data, data) {
+			t.Fatal("Expected the request to be encrypted")
+		}
+		reply, err := instance.Conn.RequestMsg(msg, time.Second)
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if reply.Header.Get(gonats.XKeyHeader) != pub {
+			t.Fatalf("Expected response encrypted by %s, got %q", pub, reply.Header.Get(gonats.XKeyHeader))
+		}
+		var resp Test
+		if err := proto.Unmarshal(reply.Data, &resp); err == nil && strings.Contains(resp.Test, "Test Client") {
+			t.Fatal("Expected the response to be encrypted")
+		}
+		opened, err := sealer.Open(reply)
+		if err != nil {
+			t.Fatalf("Failed to decrypt response: %v", err)
+		}
+		if err := proto.Unmarshal(opened, &resp); err != nil || !strings.Contains(resp.Test, "Test Client") {
+			t.Fatalf("Unexpected response %q (%v)", resp.Test, err)
+		}
+	})
+
+	t.Run("UnencryptedResponse", func(t *testing.T) {
+		t.Parallel()
+		sealer, err := gonats.NewSealer(pub)
+		if err != nil {
+			t.Fatalf("Failed to create sealer: %v", err)
+		}
+		other, err := nkeys.CreateCurveKeys()
+		if err != nil {
+			t.Fatalf("Failed to create curve key: %v", err)
+		}
+		otherKey, err := other.PublicKey()
+		if err != nil {
+			t.Fatalf("Failed to get public key: %v", err)
+		}
+		if _, err := sealer.Open(&nats.Msg{Data: []byte("plaintext")}); !errors.Is(err, gonats.ErrUnencryptedResponse) {
+			t.Fatalf("Expected ErrUnencryptedResponse, got %v", err)
+		}
+		forged := &nats.Msg{Header: nats.Header{gonats.XKeyHeader: []string{otherKey}}, Data: []byte("forged")}
+		if _, err := sealer.Open(forged); !errors.Is(err, gonats.ErrUnexpectedXKey) {
+			t.Fatalf("Expected ErrUnexpectedXKey, got %v", err)
+		}
+		// Errors of requests rejected before decryption have no data and aren't encrypted
+		if data, err := sealer.Open(&nats.Msg{Header: nats.Header{}}); err != nil || len(data) != 0 {
+			t.Fatalf("Expected empty data, got %q (%v)", data, err)
+		}
+	})
+}
+
+func TestDurable(t *testing.T) {
+	// Not parallel, as it shortens the retry delay of all durable methods
+	retryDelay := gonats.DurableRetryDelay
+	gonats.DurableRetryDelay = 50 * time.Millisecond
+	t.Cleanup(func() {
+		gonats.DurableRetryDelay = retryDelay
+	})
+
+	// wait waits for the result of call
+	wait := func(t *testing.T, call *gonats.DurableCall[*Test]) (*Test, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return call.Wait(ctx)
+	}
+
+	t.Run("Result", func(t *testing.T) {
+		instance := newJetStream(t)
+		t.Cleanup(instance.Stop)
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation))).Info().ID
+		call, err := NewTestServiceNATSClient(instance.Conn).Durable(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if call.ID == "" || call.Sequence == 0 {
+			t.Fatalf("Expected a receipt, got %+v", call.DurableReceipt)
+		}
+		resp, err := wait(t, call)
+		if err != nil {
+			t.Fatalf("Error waiting for result: %v", err)
+		}
+		if expected := "server replying to Test Client from " + id; resp.Test != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.Test)
+		}
+	})
+
+	t.Run("ReplyTo", func(t *testing.T) {
+		instance := newJetStream(t)
+		t.Cleanup(instance.Stop)
+		must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation)))
+		sub, err := instance.Conn.SubscribeSync(instance.Conn.NewRespInbox())
+		if err != nil {
+			t.Fatalf("Failed to subscribe: %v", err)
+		}
+		call, err := NewTestServiceNATSClient(instance.Conn).DurableReplyTo(sub.Subject, &Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		msg, err := sub.NextMsg(10 * time.Second)
+		if err != nil {
+			t.Fatalf("Failed to receive reply: %v", err)
+		}
+		resp, err := call.Decode(msg)
+		if err != nil {
+			t.Fatalf("Error decoding reply: %v", err)
+		}
+		if !strings.Contains(resp.Test, "Test Client") {
+			t.Fatalf("Unexpected reply %q", resp.Test)
+		}
+	})
+
+	t.Run("ReplyToNotInbox", func(t *testing.T) {
+		instance := newJetStream(t)
+		t.Cleanup(instance.Stop)
+		must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation)))
+		if _, err := NewTestServiceNATSClient(instance.Conn).DurableReplyTo("service.TestService.NormalTestTest", &Test{Test: "Test Client"}); !errors.Is(err, gonats.ErrDurableReply) {
+			t.Fatalf("Expected ErrDurableReply, got %v", err)
+		}
+	})
+
+	t.Run("ServiceDown", func(t *testing.T) {
+		instance := newJetStream(t)
+		t.Cleanup(instance.Stop)
+		// The first instance creates the stream
+		if err := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation))).Stop(); err != nil {
+			t.Fatalf("Failed to stop service: %v", err)
+		}
+		call, err := NewTestServiceNATSClient(instance.Conn).Durable(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		if _, err := call.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected no result while the service is down, got %v", err)
+		}
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation))).Info().ID
+		resp, err := wait(t, call)
+		if err != nil {
+			t.Fatalf("Error waiting for result: %v", err)
+		}
+		if expected := "server replying to Test Client from " + id; resp.Test != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.Test)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		instance := newJetStream(t)
+		t.Cleanup(instance.Stop)
+		impl := &flakyImplementation{err: protonats.NewServerErr(gonats.ShuttingDownCode, "Temporarily unavailable")}
+		impl.failures.Store(2)
+		must(t)(NewTestServiceNATSServer(instance.Conn, impl))
+		call, err := NewTestServiceNATSClient(instance.Conn).Durable(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		if _, err := wait(t, call); err != nil {
+			t.Fatalf("Expected the request to succeed after retrying, got %v", err)
+		}
+		if failures := impl.failures.Load(); failures >= 0 {
+			t.Fatalf("Expected the request to be delivered 3 times, %d failures left", failures)
+		}
+	})
+
+	t.Run("NotRetried", func(t *testing.T) {
+		instance := newJetStream(t)
+		t.Cleanup(instance.Stop)
+		impl := &flakyImplementation{err: errors.New("internal failure")}
+		impl.failures.Store(2)
+		must(t)(NewTestServiceNATSServer(instance.Conn, impl))
+		call, err := NewTestServiceNATSClient(instance.Conn).Durable(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		_, err = wait(t, call)
+		if serviceErr, ok := protonats.AsServiceError(err); !ok || serviceErr.Code != "500" {
+			t.Fatalf("Expected an internal server error, got %v", err)
+		}
+		if failures := impl.failures.Load(); failures != 1 {
+			t.Fatalf("Expected the request to be delivered once, %d failures left", failures)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		instance := newJetStream(t)
+		t.Cleanup(instance.Stop)
+		must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation)))
+		call, err := NewTestServiceNATSClient(instance.Conn).Durable(&Test{Test: "invalid"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		_, err = wait(t, call)
+		serviceErr, ok := protonats.AsServiceError(err)
+		if !ok {
+			t.Fatalf("Expected a service error, got %v", err)
+		}
+		if serviceErr.Code != "400" {
+			t.Fatalf("Expected code 400, got %v", serviceErr)
+		}
+	})
+
+	t.Run("Encrypted", func(t *testing.T) {
+		instance := newJetStream(t)
+		t.Cleanup(instance.Stop)
+		xkey, err := nkeys.CreateCurveKeys()
+		if err != nil {
+			t.Fatalf("Failed to create curve key: %v", err)
+		}
+		must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithEncryption(xkey)))
+		cli := NewTestServiceNATSClient(instance.Conn)
+		if err := cli.EnableEncryption(); err != nil {
+			t.Fatalf("Failed to enable encryption: %v", err)
+		}
+		call, err := cli.Durable(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		resp, err := wait(t, call)
+		if err != nil {
+			t.Fatalf("Error waiting for result: %v", err)
+		}
+		if !strings.Contains(resp.Test, "Test Client") {
+			t.Fatalf("Unexpected response %q", resp.Test)
+		}
+	})
+
+	t.Run("ExtraSubject", func(t *testing.T) {
+		instance := newJetStream(t)
+		t.Cleanup(instance.Stop)
+		ids := make(map[string]string)
+		for _, extra := range []string{"eu", "us.east"} {
+			ids[extra] = must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithExtraSubject(extra))).Info().ID
+		}
+		cli := NewTestServiceNATSClient(instance.Conn)
+		for extra, id := range ids {
+			// Every request is handled by the server with its extra subject, as each has its own stream
+			for range 5 {
+				call, err := cli.Durable(&Test{Test: "Test Client"}, protonats.WithExtraSubject(extra))
+				if err != nil {
+					t.Fatalf("Error calling method: %v", err)
+				}
+				resp, err := wait(t, call)
+				if err != nil {
+					t.Fatalf("Error waiting for result: %v", err)
+				}
+				if expected := "server replying to Test Client from " + id; resp.Test != expected {
+					t.Fatalf("Expected %q, got %q", expected, resp.Test)
+				}
+			}
+		}
+	})
+}
+
+func TestOneWay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NoResponders", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		if err := NewTestServiceNATSClient(instance.Conn).OneWay(&Test{Test: "event"}); err != nil {
+			t.Fatalf("Expected publishing without a server to succeed, got %v", err)
+		}
+	})
+
+	t.Run("Received", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		impl := &oneWayImplementation{received: make(chan string, 1)}
+		must(t)(NewTestServiceNATSServer(instance.Conn, impl))
+		if err := NewTestServiceNATSClient(instance.Conn).OneWay(&Test{Test: "event"}); err != nil {
+			t.Fatalf("Error calling method: %v", err)
+		}
+		select {
+		case received := <-impl.received:
+			if received != "event" {
+				t.Fatalf("Expected event, got %q", received)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Server did not receive the request")
+		}
+	})
+
+	t.Run("NoResponse", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		impl := &oneWayImplementation{received: make(chan string, 2)}
+		must(t)(NewTestServiceNATSServer(instance.Conn, impl))
+		for _, event := range []string{"event", "fail"} {
+			data, err := proto.Marshal(&Test{Test: event})
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+			if _, err := instance.Conn.Request("service.TestService.OneWay", data, 250*time.Millisecond); !errors.Is(err, nats.ErrTimeout) {
+				t.Fatalf("Expected no response for %s, got %v", event, err)
+			}
+			if received := <-impl.received; received != event {
+				t.Fatalf("Expected %s, got %q", event, received)
+			}
+		}
+	})
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Subject", func(t *testing.T) {
+		t.Parallel()
+		subject, err := OrderCreatedSubject(&OrderCreated{Region: "eu", Id: "1"})
+		if err != nil {
+			t.Fatalf("Error building subject: %v", err)
+		}
+		if subject != "orders.eu.created" {
+			t.Fatalf("Expected orders.eu.created, got %s", subject)
+		}
+		for _, region := range []string{"", "eu.west", "*", ">", "eu west"} {
+			if _, err := OrderCreatedSubject(&OrderCreated{Region: region}); !errors.Is(err, gonats.ErrInvalidToken) {
+				t.Fatalf("Expected ErrInvalidToken for %q, got %v", region, err)
+			}
+		}
+	})
+
+	t.Run("Received", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		received := make(chan *OrderCreated, 2)
+		sub, err := SubscribeOrderCreated(instance.Conn, func(event *OrderCreated, msg *nats.Msg) {
+			received <- event
+		})
+		if err != nil {
+			t.Fatalf("Error subscribing: %v", err)
+		}
+		t.Cleanup(func() { _ = sub.Unsubscribe() })
+		for _, region := range []string{"eu", "us"} {
+			if err := PublishOrderCreated(instance.Conn, &OrderCreated{Region: region, Id: "1"}); err != nil {
+				t.Fatalf("Error publishing event: %v", err)
+			}
+		}
+		for _, region := range []string{"eu", "us"} {
+			select {
+			case event := <-received:
+				if event.GetRegion() != region || event.GetId() != "1" {
+					t.Fatalf("Expected event in %s, got %v", region, event)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Did not receive event in %s", region)
+			}
+		}
+	})
+
+	t.Run("Token", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		received := make(chan *OrderCreated, 2)
+		sub, err := SubscribeOrderCreated(instance.Conn, func(event *OrderCreated, msg *nats.Msg) {
+			received <- event
+		}, gonats.WithToken("region", "eu"))
+		if err != nil {
+			t.Fatalf("Error subscribing: %v", err)
+		}
+		t.Cleanup(func() { _ = sub.Unsubscribe() })
+		if sub.Subject != "orders.eu.created" {
+			t.Fatalf("Expected subscription to orders.eu.created, got %s", sub.Subject)
+		}
+		for _, region := range []string{"us", "eu"} {
+			if err := PublishOrderCreated(instance.Conn, &OrderCreated{Region: region}); err != nil {
+				t.Fatalf("Error publishing event: %v", err)
+			}
+		}
+		select {
+		case event := <-received:
+			if event.GetRegion() != "eu" {
+				t.Fatalf("Expected only events in eu, got %v", event)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Did not receive event")
+		}
+	})
+
+	t.Run("DecodeError", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		errs := make(chan error, 1)
+		sub, err := SubscribeOrderCreated(instance.Conn, func(event *OrderCreated, msg *nats.Msg) {
+			t.Errorf("Expected undecodable event not to be handled, got %v", event)
+		}, gonats.WithDecodeErrorHandler(func(msg *nats.Msg, err error) {
+			errs <- err
+		}))
+		if err != nil {
+			t.Fatalf("Error subscribing: %v", err)
+		}
+		t.Cleanup(func() { _ = sub.Unsubscribe() })
+		if err := instance.Conn.Publish("orders.eu.created", []byte{0xff}); err != nil {
+			t.Fatalf("Error publishing: %v", err)
+		}
+		select {
+		case err := <-errs:
+			var decodeErr gonats.DecodeError
+			if !errors.As(err, &decodeErr) || decodeErr.Subject != "orders.eu.created" {
+				t.Fatalf("Expected DecodeError on orders.eu.created, got %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Decode error was not reported")
+		}
+	})
+}
+
+func TestLeaderElection(t *testing.T) {
+	t.Parallel()
+	instance := newJetStream(t)
+	t.Cleanup(instance.Stop)
+
+	// awaitRole waits until impl is notified about role
+	awaitRole := func(t *testing.T, impl *electionImplementation, role gonats.Role) {
+		t.Helper()
+		select {
+		case changed := <-impl.roles:
+			if changed != role {
+				t.Fatalf("Expected role %s, got %s", role, changed)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Role did not change to %s", role)
+		}
+	}
+
+	first := &electionImplementation{roles: make(chan gonats.Role, 4)}
+	firstService := must(t)(NewTestServiceNATSServer(instance.Conn, first, gonats.WithLeaderElection(time.Second)))
+	awaitRole(t, first, gonats.RoleLeader)
+	second := &electionImplementation{roles: make(chan gonats.Role, 4)}
+	secondService := must(t)(NewTestServiceNATSServer(instance.Conn, second, gonats.WithLeaderElection(time.Second)))
+	t.Cleanup(func() {
+		_ = secondService.Stop()
+	})
+	awaitRole(t, second, gonats.RoleFollower)
+
+	cli := NewTestServiceNATSClient(instance.Conn)
+	for range 5 {
+		resp, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling leader method: %v", err)
+		}
+		if expected := "leader replying to Test Client from " + firstService.Info().ID; resp.GetTest() != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
+		}
+		resp, err = cli.FollowerOnlyTestTest(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Error calling follower method: %v", err)
+		}
+		if expected := "follower replying to Test Client from " + secondService.Info().ID; resp.GetTest() != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
+		}
+	}
 
-	t.Run("WithFinisher", func(t *testing.T) {
-		now := time.Now()
-		info, err := cli.Info()
-		dur := time.Since(now)
-		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+	// The leader resigns on shutdown, so that the follower takes over
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := gonats.Shutdown(ctx, firstService); err != nil {
+		t.Fatalf("Error shutting down leader: %v", err)
+	}
+	awaitRole(t, second, gonats.RoleLeader)
+	resp, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"})
+	if err != nil {
+		t.Fatalf("Error calling leader method after failover: %v", err)
+	}
+	if expected := "leader replying to Test Client from " + secondService.Info().ID; resp.GetTest() != expected {
+		t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
+	}
+	if _, err := cli.FollowerOnlyTestTest(&Test{Test: "Test Client"}, protonats.WithTimeout(250*time.Millisecond)); err == nil {
+		t.Fatal("Expected no instance to serve follower methods")
+	}
+}
+
+func TestRoleSwitching(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Switch", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		impl := &electionImplementation{roles: make(chan gonats.Role, 4)}
+		service := must(t)(NewTestServiceNATSServer(instance.Conn, impl, gonats.WithRole(gonats.RoleFollower)))
+		t.Cleanup(func() {
+			_ = service.Stop()
+		})
+		id := service.Info().ID
+		cli := NewTestServiceNATSClient(instance.Conn)
+
+		// serves checks whether the instance serves the leader and follower methods
+		serves := func(t *testing.T, leader, follower bool) {
+			t.Helper()
+			_, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, protonats.WithTimeout(250*time.Millisecond))
+			if (err == nil) != leader {
+				t.Fatalf("Expected leader method to be served: %t, got %v", leader, err)
+			}
+			_, err = cli.FollowerOnlyTestTest(&Test{Test: "Test Client"}, protonats.WithTimeout(250*time.Millisecond))
+			if (err == nil) != follower {
+				t.Fatalf("Expected follower method to be served: %t, got %v", follower, err)
+			}
 		}
-		if dur > 300*time.Millisecond {
-			t.Fatalf("Info did not return within 500ms (%v)", dur)
+
+		if gonats.RoleOf(service).Role() != gonats.RoleFollower {
+			t.Fatalf("Expected initial role follower, got %s", gonats.RoleOf(service).Role())
 		}
-		if len(info) != 10 {
-			t.Fatalf("Expected 10 responses, got %d", len(info))
+		serves(t, false, true)
+		if err := gonats.RoleOf(service).BecomeLeader(); err != nil {
+			t.Fatalf("Error becoming leader: %v", err)
+		}
+		if role := <-impl.roles; role != gonats.RoleLeader {
+			t.Fatalf("Expected to be notified about role leader, got %s", role)
+		}
+		serves(t, true, false)
+		if err := gonats.RoleOf(service).BecomeFollower(); err != nil {
+			t.Fatalf("Error becoming follower: %v", err)
+		}
+		if role := <-impl.roles; role != gonats.RoleFollower {
+			t.Fatalf("Expected to be notified about role follower, got %s", role)
+		}
+		serves(t, false, true)
+		if service.Info().ID != id {
+			t.Fatalf("Expected instance ID %s to be kept, got %s", id, service.Info().ID)
 		}
 	})
 
-	t.Run("WithoutFinisher", func(t *testing.T) {
-		now := time.Now()
-		info, err := cli.Info(protonats.WithoutFinisher())
-		dur := time.Since(now)
+	t.Run("Reported", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		service := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleLeader)))
+		t.Cleanup(func() {
+			_ = service.Stop()
+		})
+		cli := NewTestServiceNATSClient(instance.Conn)
+		if _, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}); err != nil {
+			t.Fatalf("Error calling leader method: %v", err)
+		}
+
+		var endpoints []string
+		for _, endpoint := range service.Info().Endpoints {
+			endpoints = append(endpoints, endpoint.Name)
+		}
+		if !slices.Contains(endpoints, "LeaderOnlyTestTest") || slices.Contains(endpoints, "FollowerOnlyTestTest") {
+			t.Fatalf("Expected only the leader endpoints in the info, got %v", endpoints)
+		}
+		for _, endpoint := range service.Stats().Endpoints {
+			if endpoint.Name == "LeaderOnlyTestTest" && endpoint.NumRequests != 1 {
+				t.Fatalf("Expected 1 request in the stats of %s, got %d", endpoint.Name, endpoint.NumRequests)
+			}
+		}
+
+		// $SRV.STATS only reports them in the data of the role endpoint
+		stats, err := cli.Stats(protonats.WithInstanceID(service.Info().ID))
 		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+			t.Fatalf("Error getting stats: %v", err)
 		}
-		if dur <= 5000*time.Millisecond {
-			t.Fatalf("Info returned under 4.5s (%v)", dur)
+		i := slices.IndexFunc(stats[0].Endpoints, func(endpoint *micro.EndpointStats) bool {
+			return endpoint.Name == gonats.RoleEndpoint
+		})
+		if i < 0 {
+			t.Fatalf("Role endpoint not found in stats")
 		}
-		if len(info) != 10 {
-			t.Fatalf("Expected 10 responses, got %d", len(info))
+		var data gonats.RoleStatsData
+		if err := json.Unmarshal(stats[0].Endpoints[i].Data, &data); err != nil {
+			t.Fatalf("Failed to unmarshal stats data: %v", err)
+		}
+		if data.Role != gonats.RoleLeader {
+			t.Fatalf("Expected role leader, got %q", data.Role)
+		}
+		j := slices.IndexFunc(data.Endpoints, func(endpoint *micro.EndpointStats) bool {
+			return endpoint.Name == "LeaderOnlyTestTest"
+		})
+		if j < 0 || data.Endpoints[j].NumRequests != 1 {
+			t.Fatalf("Expected 1 request to LeaderOnlyTestTest in the role stats, got %+v", data.Endpoints)
+		}
+	})
+
+	t.Run("NotSwitchable", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		service := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation)))
+		if err := gonats.RoleOf(service).BecomeLeader(); !errors.Is(err, gonats.ErrRoleNotSwitchable) {
+			t.Fatalf("Expected ErrRoleNotSwitchable, got %v", err)
 		}
 	})
 }
 
-func TestNormal(t *testing.T) {
+func TestLeaderDiscovery(t *testing.T) {
 	t.Parallel()
-	instance := newNATS(t)
-	t.Cleanup(instance.Stop)
-	var ids []string
-	for range 3 {
-		id := NewTestServiceNATSServer(instance.Conn, new(testImplementation), protonats.WithoutLeaderFns(), protonats.WithoutFollowerFns()).Info().ID
-		ids = append(ids, id)
-	}
-	cli := NewTestServiceNATSClient(instance.Conn)
 
-	t.Run("TestTest", func(t *testing.T) {
+	t.Run("Pinned", func(t *testing.T) {
 		t.Parallel()
-		resp, err := cli.NormalTestTest(&Test{Test: "Test Client"})
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		first := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleLeader)))
+		second := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleFollower)))
+		t.Cleanup(func() {
+			_ = first.Stop()
+			_ = second.Stop()
+		})
+		cli := NewTestServiceNATSClient(instance.Conn)
+
+		leader, err := cli.Leader()
 		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+			t.Fatalf("Error discovering leader: %v", err)
 		}
-		re := regexp.MustCompile(`^server replying to Test Client from ([a-zA-Z0-9]+)$`)
-		matches := re.FindStringSubmatch(resp.Test)
-		if len(matches) != 2 {
-			t.Fatalf("Response format doesn't match: %v", resp.Test)
+		if leader != first.Info().ID {
+			t.Fatalf("Expected leader %s, got %s", first.Info().ID, leader)
 		}
-		if !slices.Contains(ids, matches[1]) {
-			t.Fatalf("Server ID not found in the list of IDs: %v", matches[1])
+		resp, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, gonats.WithLeader())
+		if err != nil {
+			t.Fatalf("Error calling leader: %v", err)
+		}
+		if expected := "leader replying to Test Client from " + first.Info().ID; resp.GetTest() != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
+		}
+
+		// The cached leader is refreshed once a call to it failed, and the call repeated with the new leader
+		if err := gonats.RoleOf(first).BecomeFollower(); err != nil {
+			t.Fatalf("Error switching role: %v", err)
+		}
+		if err := gonats.RoleOf(second).BecomeLeader(); err != nil {
+			t.Fatalf("Error switching role: %v", err)
+		}
+		resp, err = cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, gonats.WithLeader(), protonats.WithTimeout(250*time.Millisecond))
+		if err != nil {
+			t.Fatalf("Error calling new leader: %v", err)
+		}
+		if expected := "leader replying to Test Client from " + second.Info().ID; resp.GetTest() != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
 		}
 	})
 
-	t.Run("EmptyTest", func(t *testing.T) {
+	t.Run("LeaderStopped", func(t *testing.T) {
 		t.Parallel()
-		resp, err := cli.NormalEmptyTest()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		first := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleLeader)))
+		second := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleFollower)))
+		t.Cleanup(func() {
+			_ = second.Stop()
+		})
+		cli := NewTestServiceNATSClient(instance.Conn)
+
+		resp, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, gonats.WithLeader())
 		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+			t.Fatalf("Error calling leader: %v", err)
 		}
-		re := regexp.MustCompile(`^server replying to empty from ([a-zA-Z0-9]+)$`)
-		matches := re.FindStringSubmatch(resp.Test)
-		if len(matches) != 2 {
-			t.Fatalf("Response format doesn't match: %v", resp.Test)
+		if expected := "leader replying to Test Client from " + first.Info().ID; resp.GetTest() != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
 		}
-		if !slices.Contains(ids, matches[1]) {
-			t.Fatalf("Server ID not found in the list of IDs: %v", matches[1])
+
+		// The cached leader stops without losing its leadership first, so that the next call gets no responders
+		if err := gonats.Shutdown(context.Background(), first); err != nil {
+			t.Fatalf("Error shutting down leader: %v", err)
+		}
+		if err := gonats.RoleOf(second).BecomeLeader(); err != nil {
+			t.Fatalf("Error switching role: %v", err)
+		}
+		resp, err = cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, gonats.WithLeader())
+		if err != nil {
+			t.Fatalf("Error calling new leader: %v", err)
+		}
+		if expected := "leader replying to Test Client from " + second.Info().ID; resp.GetTest() != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
 		}
 	})
 
-	t.Run("TestEmpty", func(t *testing.T) {
+	t.Run("PinnedOtherCalls", func(t *testing.T) {
 		t.Parallel()
-		err := cli.NormalTestEmpty(&Test{Test: "Test Client"})
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		first := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleLeader)))
+		second := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleFollower)))
+		t.Cleanup(func() {
+			_ = first.Stop()
+			_ = second.Stop()
+		})
+		cli := NewTestServiceNATSClient(instance.Conn)
+
+		pings, err := cli.Ping(gonats.WithLeader())
 		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+			t.Fatalf("Error pinging leader: %v", err)
+		}
+		if len(pings) != 1 || pings[0].ID != first.Info().ID {
+			t.Fatalf("Expected a ping from leader %s, got %v", first.Info().ID, pings)
+		}
+		results, err := cli.NormalBroadcastTestTest(&Test{Test: "Test Client"}, gonats.WithLeader())
+		if err != nil {
+			t.Fatalf("Error broadcasting to leader: %v", err)
+		}
+		if len(results) != 1 || results[0].InstanceID != first.Info().ID {
+			t.Fatalf("Expected a result from leader %s, got %v", first.Info().ID, results)
+		}
+
+		// Pings can't notice a change of leadership, so they discover the leader every time
+		if err := gonats.RoleOf(first).BecomeFollower(); err != nil {
+			t.Fatalf("Error switching role: %v", err)
+		}
+		if err := gonats.RoleOf(second).BecomeLeader(); err != nil {
+			t.Fatalf("Error switching role: %v", err)
+		}
+		pings, err = cli.Ping(gonats.WithLeader())
+		if err != nil {
+			t.Fatalf("Error pinging leader: %v", err)
+		}
+		if len(pings) != 1 || pings[0].ID != second.Info().ID {
+			t.Fatalf("Expected a ping from new leader %s, got %v", second.Info().ID, pings)
+		}
+
+		if _, err := cli.Durable(&Test{Test: "Test Client"}, gonats.WithLeader()); !errors.Is(err, gonats.ErrLeaderUnsupported) {
+			t.Fatalf("Expected ErrLeaderUnsupported, got %v", err)
 		}
 	})
 
-	t.Run("EmptyEmpty", func(t *testing.T) {
+	t.Run("SwitchedMetadata", func(t *testing.T) {
 		t.Parallel()
-		err := cli.NormalEmptyEmpty()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		service := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleFollower)))
+		t.Cleanup(func() {
+			_ = service.Stop()
+		})
+		if role := service.Info().Metadata[gonats.RoleMetadata]; role != string(gonats.RoleFollower) {
+			t.Fatalf("Expected follower to report its role, got %q", role)
+		}
+		if err := gonats.RoleOf(service).BecomeLeader(); err != nil {
+			t.Fatalf("Error switching role: %v", err)
+		}
+		if role := service.Info().Metadata[gonats.RoleMetadata]; role != string(gonats.RoleLeader) {
+			t.Fatalf("Expected leader to report its role, got %q", role)
+		}
+	})
+
+	t.Run("Metadata", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		leader := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutFollowerFns()))
+		follower := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns()))
+		t.Cleanup(func() {
+			_ = leader.Stop()
+			_ = follower.Stop()
+		})
+		if role := leader.Info().Metadata[gonats.RoleMetadata]; role != string(gonats.RoleLeader) {
+			t.Fatalf("Expected leader to advertise its role, got %q", role)
+		}
+		if role := follower.Info().Metadata[gonats.RoleMetadata]; role != string(gonats.RoleFollower) {
+			t.Fatalf("Expected follower to advertise its role, got %q", role)
+		}
+		id, err := NewTestServiceNATSClient(instance.Conn).Leader()
 		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+			t.Fatalf("Error discovering leader: %v", err)
+		}
+		if id != leader.Info().ID {
+			t.Fatalf("Expected leader %s, got %s", leader.Info().ID, id)
+		}
+	})
+
+	t.Run("NoLeader", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleFollower)))
+		cli := NewTestServiceNATSClient(instance.Conn)
+		if _, err := cli.Leader(); !errors.Is(err, gonats.ErrNoLeader) {
+			t.Fatalf("Expected ErrNoLeader, got %v", err)
+		}
+		if _, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, gonats.WithLeader()); !errors.Is(err, gonats.ErrNoLeader) {
+			t.Fatalf("Expected ErrNoLeader, got %v", err)
 		}
 	})
 }
 
-func TestNormalBroadcast(t *testing.T) {
+func TestLeaderForwarding(t *testing.T) {
 	t.Parallel()
-	instance := newNATS(t)
-	t.Cleanup(instance.Stop)
-	var ids []string
-	for range 3 {
-		id := NewTestServiceNATSServer(instance.Conn, new(testImplementation), protonats.WithoutLeaderFns(), protonats.WithoutFollowerFns()).Info().ID
-		ids = append(ids, id)
-	}
-	cli := NewTestServiceNATSClient(instance.Conn)
 
-	t.Run("TestTest", func(t *testing.T) {
+	t.Run("Dynamic", func(t *testing.T) {
 		t.Parallel()
-		resp, srvErrs, err := cli.NormalBroadcastTestTest(&Test{Test: "Test Client"})
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		leader := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleLeader), gonats.WithLeaderForwarding()))
+		follower := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleFollower), gonats.WithLeaderForwarding()))
+		t.Cleanup(func() {
+			_ = leader.Stop()
+			_ = follower.Stop()
+		})
+		cli := NewTestServiceNATSClient(instance.Conn)
+
+		// A stale route to the follower is forwarded to the leader
+		resp, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(follower.Info().ID))
 		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+			t.Fatalf("Error calling follower: %v", err)
+		}
+		if expected := "leader replying to Test Client from " + leader.Info().ID; resp.GetTest() != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+
+		// After a change of leadership, the follower forwards to the new leader
+		if err := gonats.RoleOf(leader).BecomeFollower(); err != nil {
+			t.Fatalf("Error switching role: %v", err)
 		}
-		if len(resp) != 3 {
-			t.Fatalf("Expected 3 responses, got %d: %v", len(resp), resp)
+		if err := gonats.RoleOf(follower).BecomeLeader(); err != nil {
+			t.Fatalf("Error switching role: %v", err)
 		}
-		for _, r := range resp {
-			re := regexp.MustCompile(`^server replying to Test Client from ([a-zA-Z0-9]+)$`)
-			matches := re.FindStringSubmatch(r.Test)
-			if len(matches) != 2 {
-				t.Fatalf("Response format doesn't match: %v", r.Test)
-			}
-			if !slices.Contains(ids, matches[1]) {
-				t.Fatalf("Server ID not found in the list of IDs: %v", matches[1])
-			}
+		resp, err = cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(leader.Info().ID))
+		if err != nil {
+			t.Fatalf("Error calling previous leader: %v", err)
+		}
+		if expected := "leader replying to Test Client from " + follower.Info().ID; resp.GetTest() != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
 		}
 	})
 
-	t.Run("EmptyTest", func(t *testing.T) {
+	t.Run("Static", func(t *testing.T) {
 		t.Parallel()
-		resp, srvErrs, err := cli.NormalBroadcastEmptyTest()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		signer, err := nkeys.CreateUser()
 		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+			t.Fatalf("Failed to create user key: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		signerKey, err := signer.PublicKey()
+		if err != nil {
+			t.Fatalf("Failed to get public key: %v", err)
 		}
-		if len(resp) != 3 {
-			t.Fatalf("Expected 3 responses, got %d: %v", len(resp), resp)
+		forwarder, err := nkeys.CreateUser()
+		if err != nil {
+			t.Fatalf("Failed to create forwarder key: %v", err)
 		}
-		for _, r := range resp {
-			re := regexp.MustCompile(`^server replying to empty from ([a-zA-Z0-9]+)$`)
-			matches := re.FindStringSubmatch(r.Test)
-			if len(matches) != 2 {
-				t.Fatalf("Response format doesn't match: %v", r.Test)
+		forwarderKey, err := forwarder.PublicKey()
+		if err != nil {
+			t.Fatalf("Failed to get public key: %v", err)
+		}
+		leader := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutFollowerFns(), gonats.WithTrustedSigners(signerKey, forwarderKey)))
+		follower := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithLeaderForwarding(), gonats.WithForwarderSigner(forwarder)))
+		t.Cleanup(func() {
+			_ = leader.Stop()
+			_ = follower.Stop()
+		})
+		cli := NewTestServiceNATSClient(instance.Conn)
+		cli.SetSigner(signer)
+
+		// The leader verifies the signature against the subject the request was originally sent to
+		resp, err := cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(follower.Info().ID))
+		if err != nil {
+			t.Fatalf("Error calling follower: %v", err)
+		}
+		if expected := "leader replying to Test Client from " + leader.Info().ID; resp.GetTest() != expected {
+			t.Fatalf("Expected %q, got %q", expected, resp.GetTest())
+		}
+	})
+
+	t.Run("Spoofed", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		signer, err := nkeys.CreateUser()
+		if err != nil {
+			t.Fatalf("Failed to create user key: %v", err)
+		}
+		signerKey, err := signer.PublicKey()
+		if err != nil {
+			t.Fatalf("Failed to get public key: %v", err)
+		}
+		rogue, err := nkeys.CreateUser()
+		if err != nil {
+			t.Fatalf("Failed to create rogue key: %v", err)
+		}
+		rogueKey, err := rogue.PublicKey()
+		if err != nil {
+			t.Fatalf("Failed to get public key: %v", err)
+		}
+		leader := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutFollowerFns(), gonats.WithTrustedSigners(signerKey)))
+		follower := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithLeaderForwarding(), gonats.WithForwarderSigner(rogue)))
+		t.Cleanup(func() {
+			_ = leader.Stop()
+			_ = follower.Stop()
+		})
+		data, err := proto.Marshal(&Test{Test: "Test Client"})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+
+		// A request signed for the follower is sent to the leader by a client claiming it was forwarded
+		original := "service.TestService.LeaderOnlyTestTest." + follower.Info().ID
+		for name, headers := range map[string]map[string]string{
+			"Unsigned":           {},
+			"ForgedSignature":    {gonats.ForwarderKeyHeader: signerKey, gonats.ForwarderSignatureHeader: "c2lnbmF0dXJl"},
+			"UntrustedSignature": {gonats.ForwarderKeyHeader: rogueKey, gonats.ForwarderSignatureHeader: "c2lnbmF0dXJl"},
+		} {
+			msg, err := gonats.NewRequest(instance.Conn, original, data, signer, nil)
+			if err != nil {
+				t.Fatalf("Failed to sign request: %v", err)
 			}
-			if !slices.Contains(ids, matches[1]) {
-				t.Fatalf("Server ID not found in the list of IDs: %v", matches[1])
+			msg.Subject = "service.TestService.LeaderOnlyTestTest." + leader.Info().ID
+			msg.Header.Set(gonats.ForwardedHeader, original)
+			for key, value := range headers {
+				msg.Header.Set(key, value)
 			}
+			reply, err := instance.Conn.RequestMsg(msg, 2*time.Second)
+			if err != nil {
+				t.Fatalf("%s: Error calling leader: %v", name, err)
+			}
+			if code := reply.Header.Get(micro.ErrorCodeHeader); code != gonats.UnauthenticatedCode {
+				t.Fatalf("%s: Expected code %s, got %q", name, gonats.UnauthenticatedCode, code)
+			}
+		}
+
+		// A rogue $SRV.INFO responder advertising the key of the follower doesn't make the leader trust it
+		sub, err := instance.Conn.Subscribe("$SRV.INFO.TestService.>", func(msg *nats.Msg) {
+			info, _ := json.Marshal(micro.Info{
+				ServiceIdentity: micro.ServiceIdentity{Name: "TestService", ID: follower.Info().ID, Metadata: map[string]string{"protonats.forwarder": rogueKey}},
+				Type:            micro.InfoResponseType,
+			})
+			_ = msg.Respond(info)
+		})
+		if err != nil {
+			t.Fatalf("Failed to subscribe: %v", err)
+		}
+		t.Cleanup(func() {
+			_ = sub.Unsubscribe()
+		})
+		cli := NewTestServiceNATSClient(instance.Conn)
+		cli.SetSigner(signer)
+		_, err = cli.LeaderOnlyTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(follower.Info().ID))
+		if serviceErr, ok := protonats.AsServiceError(err); !ok || serviceErr.Code != gonats.UnauthenticatedCode {
+			t.Fatalf("Expected code %s, got %v", gonats.UnauthenticatedCode, err)
 		}
 	})
 
-	t.Run("TestEmpty", func(t *testing.T) {
+	t.Run("NoTrustedSigners", func(t *testing.T) {
 		t.Parallel()
-		srvErrs, err := cli.NormalBroadcastTestEmpty(&Test{Test: "Test Client"})
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		forwarder, err := nkeys.CreateUser()
 		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+			t.Fatalf("Failed to create forwarder key: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		leader := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutFollowerFns()))
+		follower := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithLeaderForwarding(), gonats.WithForwarderSigner(forwarder)))
+		t.Cleanup(func() {
+			_ = leader.Stop()
+			_ = follower.Stop()
+		})
+
+		// Without trusted signers, the leader doesn't trust any forwarded subject
+		_, err = NewTestServiceNATSClient(instance.Conn).LeaderOnlyTestTest(&Test{Test: "Test Client"}, protonats.WithInstanceID(follower.Info().ID))
+		if serviceErr, ok := protonats.AsServiceError(err); !ok || serviceErr.Code != gonats.UnauthenticatedCode {
+			t.Fatalf("Expected code %s, got %v", gonats.UnauthenticatedCode, err)
 		}
 	})
 
-	t.Run("EmptyEmpty", func(t *testing.T) {
+	t.Run("NoLeader", func(t *testing.T) {
 		t.Parallel()
-		srvErrs, err := cli.NormalBroadcastEmptyEmpty()
-		if err != nil {
-			t.Fatalf("Error calling method: %v", err)
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		follower := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithRole(gonats.RoleFollower), gonats.WithLeaderForwarding()))
+		t.Cleanup(func() {
+			_ = follower.Stop()
+		})
+		_, err := NewTestServiceNATSClient(instance.Conn).LeaderOnlyTestTest(&Test{Test: "Test Client"})
+		serviceErr, ok := protonats.AsServiceError(err)
+		if !ok || serviceErr.Code != gonats.NoLeaderCode {
+			t.Fatalf("Expected error with code %s, got %v", gonats.NoLeaderCode, err)
+		}
+	})
+}
+
+func TestBalancing(t *testing.T) {
+	t.Parallel()
+
+	// serve starts count instances and returns a client calling NormalTestTest with balancer, returning the ID of the responding instance
+	serve := func(t *testing.T, count int, balancer gonats.Balancer) ([]string, func() string) {
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		var ids []string
+		for range count {
+			service := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns()))
+			t.Cleanup(func() {
+				_ = service.Stop()
+			})
+			ids = append(ids, service.Info().ID)
+		}
+		cli := NewTestServiceNATSClient(instance.Conn)
+		cli.SetBalancer(balancer)
+		return ids, func() string {
+			resp, err := cli.NormalTestTest(&Test{Test: "Test Client"})
+			if err != nil {
+				t.Fatalf("Error calling service: %v", err)
+			}
+			return strings.TrimPrefix(resp.GetTest(), "server replying to Test Client from ")
+		}
+	}
+
+	t.Run("RoundRobin", func(t *testing.T) {
+		t.Parallel()
+		ids, call := serve(t, 3, gonats.RoundRobin())
+		calls := make(map[string]int)
+		for range 6 {
+			calls[call()]++
+		}
+		for _, id := range ids {
+			if calls[id] != 2 {
+				t.Fatalf("Expected 2 calls to each instance, got %v", calls)
+			}
+		}
+	})
+
+	t.Run("LeastLatency", func(t *testing.T) {
+		t.Parallel()
+		ids, call := serve(t, 2, gonats.LeastLatency())
+		first := call()
+		if !slices.Contains(ids, first) {
+			t.Fatalf("Unexpected instance %s", first)
+		}
+		for range 4 {
+			if id := call(); id != first {
+				t.Fatalf("Expected all calls to %s, got %s", first, id)
+			}
+		}
+	})
+
+	t.Run("PowerOfTwoChoices", func(t *testing.T) {
+		t.Parallel()
+		ids, call := serve(t, 2, gonats.PowerOfTwoChoices())
+		for range 4 {
+			if id := call(); !slices.Contains(ids, id) {
+				t.Fatalf("Unexpected instance %s", id)
+			}
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+	})
+
+	t.Run("ExtraSubject", func(t *testing.T) {
+		t.Parallel()
+		instance := newNATS(t)
+		t.Cleanup(instance.Stop)
+		ids := make(map[string][]string)
+		for _, extra := range []string{"first", "first", "second"} {
+			service := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns(), gonats.WithExtraSubject(extra)))
+			t.Cleanup(func() {
+				_ = service.Stop()
+			})
+			ids[extra] = append(ids[extra], service.Info().ID)
+		}
+		cli := NewTestServiceNATSClient(instance.Conn)
+		cli.SetBalancer(gonats.RoundRobin())
+		for extra, served := range ids {
+			calls := make(map[string]int)
+			for range 2 * len(served) {
+				resp, err := cli.NormalTestTest(&Test{Test: "Test Client"}, protonats.WithExtraSubject(extra))
+				if err != nil {
+					t.Fatalf("Error calling service with extra subject %s: %v", extra, err)
+				}
+				calls[strings.TrimPrefix(resp.GetTest(), "server replying to Test Client from ")]++
+			}
+			for _, id := range served {
+				if calls[id] != 2 {
+					t.Fatalf("Expected 2 calls to each instance with extra subject %s, got %v", extra, calls)
+				}
+			}
 		}
 	})
 }
@@ -200,7 +2682,7 @@ func TestErr(t *testing.T) {
 	t.Parallel()
 	instance := newNATS(t)
 	t.Cleanup(instance.Stop)
-	NewTestServiceNATSServer(instance.Conn, new(testImplementation), protonats.WithoutLeaderFns(), protonats.WithoutFollowerFns())
+	must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation), gonats.WithoutLeaderFns(), gonats.WithoutFollowerFns()))
 	cli := NewTestServiceNATSClient(instance.Conn)
 
 	t.Run("ServiceError", func(t *testing.T) {
@@ -238,46 +2720,62 @@ func TestErrBroadcast(t *testing.T) {
 	t.Cleanup(instance.Stop)
 	var ids []string
 	for range 3 {
-		id := NewTestServiceNATSServer(instance.Conn, new(testImplementation)).Info().ID
+		id := must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation))).Info().ID
 		ids = append(ids, id)
 	}
 	cli := NewTestServiceNATSClient(instance.Conn)
 
 	t.Run("ServiceError", func(t *testing.T) {
 		t.Parallel()
-		resp, srvErrs, err := cli.ErrServiceErrorBroadcast(&Test{Test: "Test Client"})
+		results, err := cli.ErrServiceErrorBroadcast(&Test{Test: "Test Client"})
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(resp) != 0 {
-			t.Fatalf("Unexpected responses: %v", resp)
-		}
-		if len(srvErrs) != 3 {
-			t.Fatalf("Expected 3 service errors, got %d: %v", len(srvErrs), srvErrs)
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 results, got %d: %v", len(results), results)
 		}
-		for _, e := range srvErrs {
-			if !protonats.IsServiceError(e) {
-				t.Fatalf("Expected service error, got: %v", e)
+		var responders []string
+		for _, r := range results {
+			if !protonats.IsServiceError(r.Err) {
+				t.Fatalf("Expected service error, got: %v", r.Err)
 			}
+			if r.Response != nil {
+				t.Fatalf("Unexpected response: %v", r.Response)
+			}
+			if !slices.Contains(ids, r.InstanceID) {
+				t.Fatalf("Instance ID not found in the list of IDs: %v", r.InstanceID)
+			}
+			if slices.Contains(responders, r.InstanceID) {
+				t.Fatalf("Instance %s responded more than once", r.InstanceID)
+			}
+			responders = append(responders, r.InstanceID)
 		}
 	})
 
 	t.Run("ServerError", func(t *testing.T) {
 		t.Parallel()
-		resp, srvErrs, err := cli.ErrServerErrorBroadcast(&Test{Test: "Test Client"})
+		results, err := cli.ErrServerErrorBroadcast(&Test{Test: "Test Client"})
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(resp) != 0 {
-			t.Fatalf("Unexpected responses: %v", resp)
-		}
-		if len(srvErrs) != 3 {
-			t.Fatalf("Expected 3 service errors, got %d: %v", len(srvErrs), srvErrs)
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 results, got %d: %v", len(results), results)
 		}
-		for _, e := range srvErrs {
-			if !protonats.IsServiceError(e) {
-				t.Fatalf("Expected service error, got: %v", e)
+		var responders []string
+		for _, r := range results {
+			if !protonats.IsServiceError(r.Err) {
+				t.Fatalf("Expected service error, got: %v", r.Err)
 			}
+			if r.Response != nil {
+				t.Fatalf("Unexpected response: %v", r.Response)
+			}
+			if !slices.Contains(ids, r.InstanceID) {
+				t.Fatalf("Instance ID not found in the list of IDs: %v", r.InstanceID)
+			}
+			if slices.Contains(responders, r.InstanceID) {
+				t.Fatalf("Instance %s responded more than once", r.InstanceID)
+			}
+			responders = append(responders, r.InstanceID)
 		}
 	})
 }
@@ -287,10 +2785,10 @@ func TestLeaderOnly(t *testing.T) {
 	instance := newNATS(t)
 	t.Cleanup(instance.Stop)
 	for range 3 {
-		NewTestServiceNATSFollowerServer(instance.Conn, new(testImplementation))
+		must(t)(NewTestServiceNATSFollowerServer(instance.Conn, new(testImplementation)))
 	}
 	leaderImpl := new(testImplementation)
-	NewTestServiceNATSLeaderServer(instance.Conn, leaderImpl)
+	must(t)(NewTestServiceNATSLeaderServer(instance.Conn, leaderImpl))
 	if leaderImpl.id == "" {
 		t.Fatalf("Server id is empty")
 	}
@@ -341,64 +2839,76 @@ func TestLeaderOnlyBroadcast(t *testing.T) {
 	instance := newNATS(t)
 	t.Cleanup(instance.Stop)
 	for range 3 {
-		NewTestServiceNATSFollowerServer(instance.Conn, new(testImplementation))
+		must(t)(NewTestServiceNATSFollowerServer(instance.Conn, new(testImplementation)))
 	}
-	id := NewTestServiceNATSLeaderServer(instance.Conn, new(testImplementation)).Info().ID
+	id := must(t)(NewTestServiceNATSLeaderServer(instance.Conn, new(testImplementation))).Info().ID
 	cli := NewTestServiceNATSClient(instance.Conn)
 
 	t.Run("TestTest", func(t *testing.T) {
 		t.Parallel()
-		resp, srvErrs, err := cli.LeaderOnlyBroadcastTestTest(&Test{Test: "Test Client"})
+		results, err := cli.LeaderOnlyBroadcastTestTest(&Test{Test: "Test Client"})
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected only one response, got %d: %v", len(results), results)
 		}
-		if len(resp) != 1 {
-			t.Fatalf("Expected only one response, got %d: %v", len(resp), resp)
+		if results[0].Response.Test != "leader replying to Test Client from "+id {
+			t.Fatalf("Unexpected response: %v", results[0].Response.Test)
 		}
-		if resp[0].Test != "leader replying to Test Client from "+id {
-			t.Fatalf("Unexpected response: %v", resp[0].Test)
+		if results[0].InstanceID != id {
+			t.Fatalf("Unexpected instance ID: %v", results[0].InstanceID)
 		}
 	})
 
 	t.Run("EmptyTest", func(t *testing.T) {
 		t.Parallel()
-		resp, srvErrs, err := cli.LeaderOnlyBroadcastEmptyTest()
+		results, err := cli.LeaderOnlyBroadcastEmptyTest()
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
 		}
-		if len(resp) != 1 {
-			t.Fatalf("Expected only one response, got %d: %v", len(resp), resp)
+		if len(results) != 1 {
+			t.Fatalf("Expected only one response, got %d: %v", len(results), results)
 		}
-		if resp[0].Test != "leader replying to empty from "+id {
-			t.Fatalf("Unexpected response: %v", resp[0].Test)
+		if results[0].Response.Test != "leader replying to empty from "+id {
+			t.Fatalf("Unexpected response: %v", results[0].Response.Test)
+		}
+		if results[0].InstanceID != id {
+			t.Fatalf("Unexpected instance ID: %v", results[0].InstanceID)
 		}
 	})
 
 	t.Run("TestEmpty", func(t *testing.T) {
 		t.Parallel()
-		srvErrs, err := cli.LeaderOnlyBroadcastTestEmpty(&Test{Test: "Test Client"})
+		results, err := cli.LeaderOnlyBroadcastTestEmpty(&Test{Test: "Test Client"})
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected only one acknowledgement, got %d: %v", len(results), results)
 		}
 	})
 
 	t.Run("EmptyEmpty", func(t *testing.T) {
 		t.Parallel()
-		srvErrs, err := cli.LeaderOnlyBroadcastEmptyEmpty()
+		results, err := cli.LeaderOnlyBroadcastEmptyEmpty()
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected only one acknowledgement, got %d: %v", len(results), results)
 		}
 	})
 }
@@ -409,10 +2919,10 @@ func TestFollowerOnly(t *testing.T) {
 	t.Cleanup(instance.Stop)
 	var ids []string
 	for range 3 {
-		id := NewTestServiceNATSFollowerServer(instance.Conn, new(testImplementation)).Info().ID
+		id := must(t)(NewTestServiceNATSFollowerServer(instance.Conn, new(testImplementation))).Info().ID
 		ids = append(ids, id)
 	}
-	NewTestServiceNATSLeaderServer(instance.Conn, new(testImplementation))
+	must(t)(NewTestServiceNATSLeaderServer(instance.Conn, new(testImplementation)))
 
 	cli := NewTestServiceNATSClient(instance.Conn)
 
@@ -471,80 +2981,92 @@ func TestFollowerOnlyBroadcast(t *testing.T) {
 	t.Cleanup(instance.Stop)
 	var ids []string
 	for range 3 {
-		id := NewTestServiceNATSFollowerServer(instance.Conn, new(testImplementation)).Info().ID
+		id := must(t)(NewTestServiceNATSFollowerServer(instance.Conn, new(testImplementation))).Info().ID
 		ids = append(ids, id)
 	}
-	NewTestServiceNATSLeaderServer(instance.Conn, new(testImplementation))
+	must(t)(NewTestServiceNATSLeaderServer(instance.Conn, new(testImplementation)))
 
 	cli := NewTestServiceNATSClient(instance.Conn)
 
 	t.Run("TestTest", func(t *testing.T) {
 		t.Parallel()
-		resp, srvErrs, err := cli.FollowerOnlyBroadcastTestTest(&Test{Test: "Test Client"})
+		results, err := cli.FollowerOnlyBroadcastTestTest(&Test{Test: "Test Client"})
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
 		}
-		if len(resp) != 3 {
-			t.Fatalf("Expected 3 responses, got %d: %v", len(resp), resp)
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 responses, got %d: %v", len(results), results)
 		}
-		for _, r := range resp {
+		for _, r := range results {
 			re := regexp.MustCompile(`^follower replying to Test Client from ([a-zA-Z0-9]+)$`)
-			matches := re.FindStringSubmatch(r.Test)
+			matches := re.FindStringSubmatch(r.Response.Test)
 			if len(matches) != 2 {
-				t.Fatalf("Response format doesn't match: %v", r.Test)
+				t.Fatalf("Response format doesn't match: %v", r.Response.Test)
 			}
 			if !slices.Contains(ids, matches[1]) {
 				t.Fatalf("Server ID not found in the list of follower IDs: %v", matches[1])
 			}
+			if r.InstanceID != matches[1] {
+				t.Fatalf("Instance ID %s doesn't match the responding server %s", r.InstanceID, matches[1])
+			}
 		}
 	})
 
 	t.Run("EmptyTest", func(t *testing.T) {
 		t.Parallel()
-		resp, srvErrs, err := cli.FollowerOnlyBroadcastEmptyTest()
+		results, err := cli.FollowerOnlyBroadcastEmptyTest()
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
 		}
-		if len(resp) != 3 {
-			t.Fatalf("Expected 3 responses, got %d: %v", len(resp), resp)
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 responses, got %d: %v", len(results), results)
 		}
-		for _, r := range resp {
+		for _, r := range results {
 			re := regexp.MustCompile(`^follower replying to empty from ([a-zA-Z0-9]+)$`)
-			matches := re.FindStringSubmatch(r.Test)
+			matches := re.FindStringSubmatch(r.Response.Test)
 			if len(matches) != 2 {
-				t.Fatalf("Response format doesn't match: %v", r.Test)
+				t.Fatalf("Response format doesn't match: %v", r.Response.Test)
 			}
 			if !slices.Contains(ids, matches[1]) {
 				t.Fatalf("Server ID not found in the list of follower IDs: %v", matches[1])
 			}
+			if r.InstanceID != matches[1] {
+				t.Fatalf("Instance ID %s doesn't match the responding server %s", r.InstanceID, matches[1])
+			}
 		}
 	})
 
 	t.Run("TestEmpty", func(t *testing.T) {
 		t.Parallel()
-		srvErrs, err := cli.FollowerOnlyBroadcastTestEmpty(&Test{Test: "Test Client"})
+		results, err := cli.FollowerOnlyBroadcastTestEmpty(&Test{Test: "Test Client"})
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 acknowledgements, got %d: %v", len(results), results)
 		}
 	})
 
 	t.Run("EmptyEmpty", func(t *testing.T) {
 		t.Parallel()
-		srvErrs, err := cli.FollowerOnlyBroadcastEmptyEmpty()
+		results, err := cli.FollowerOnlyBroadcastEmptyEmpty()
 		if err != nil {
 			t.Fatalf("Error calling method: %v", err)
 		}
-		if len(srvErrs) != 0 {
-			t.Fatalf("Unexpected server errors: %v", srvErrs)
+		if errs := gonats.Errors(results); len(errs) != 0 {
+			t.Fatalf("Unexpected server errors: %v", errs)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 acknowledgements, got %d: %v", len(results), results)
 		}
 	})
 }
@@ -557,7 +3079,16 @@ func TestExtraSubject(t *testing.T) {
 	for i := range 3 {
 		id := fmt.Sprintf("instance%02d", i)
 		impl := new(testImplementation)
-		_ = NewTestServiceNATSServer(instance.Conn, impl, protonats.WithExtraSubjectSrv(id))
+		opt := gonats.WithExtraSubject(id)
+		switch i {
+		case 1:
+			// Options of protonats are passed directly
+			opt = protonats.WithExtraSubjectSrv(id)
+		case 2:
+			// or combined with those of gonats through WithServerOptions
+			opt = gonats.WithServerOptions(protonats.WithExtraSubjectSrv(id), gonats.WithWorkerPool(2, 1))
+		}
+		_ = must(t)(NewTestServiceNATSServer(instance.Conn, impl, opt))
 		ids = append(ids, impl.id)
 		impl.id = impl.id + " aka " + id
 	}
@@ -567,21 +3098,21 @@ func TestExtraSubject(t *testing.T) {
 		id := fmt.Sprintf("instance%02d", i)
 		t.Run("EmptyTest/"+id, func(t *testing.T) {
 			t.Parallel()
-			resp, srvErrs, err := cli.NormalBroadcastEmptyTest(protonats.WithExtraSubject(id))
+			results, err := cli.NormalBroadcastEmptyTest(protonats.WithExtraSubject(id))
 			if err != nil {
 				t.Fatalf("Error calling method: %v", err)
 			}
-			if len(srvErrs) != 0 {
-				t.Fatalf("Unexpected server errors: %v", srvErrs)
+			if errs := gonats.Errors(results); len(errs) != 0 {
+				t.Fatalf("Unexpected server errors: %v", errs)
 			}
-			if len(resp) != 1 {
-				t.Fatalf("Expected one response, got %d: %v", len(resp), resp)
+			if len(results) != 1 {
+				t.Fatalf("Expected one response, got %d: %v", len(results), results)
 			}
-			for _, r := range resp {
+			for _, r := range results {
 				re := regexp.MustCompile(`^server replying to empty from ([a-zA-Z0-9]+) aka ([a-zA-Z0-9]+)$`)
-				matches := re.FindStringSubmatch(r.Test)
+				matches := re.FindStringSubmatch(r.Response.Test)
 				if len(matches) != 3 {
-					t.Fatalf("Response format doesn't match: %v", r.Test)
+					t.Fatalf("Response format doesn't match: %v", r.Response.Test)
 				}
 				if !slices.Contains(ids, matches[1]) {
 					t.Fatalf("Server ID not found in the list of follower IDs: %v", matches[1])
@@ -589,6 +3120,9 @@ func TestExtraSubject(t *testing.T) {
 				if matches[2] != id {
 					t.Fatalf("Extra subject doesn't match: %v", matches[2])
 				}
+				if r.InstanceID != matches[1] {
+					t.Fatalf("Instance ID %s doesn't match the responding server %s", r.InstanceID, matches[1])
+				}
 			}
 		})
 	}
@@ -598,7 +3132,7 @@ func TestContext(t *testing.T) {
 	t.Parallel()
 	instance := newNATS(t)
 	t.Cleanup(instance.Stop)
-	_ = NewTestServiceNATSServer(instance.Conn, new(testImplementation))
+	_ = must(t)(NewTestServiceNATSServer(instance.Conn, new(testImplementation)))
 	cli := NewTestServiceNATSClient(instance.Conn)
 
 	t.Run("WithTimeout", func(t *testing.T) {